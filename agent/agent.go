@@ -0,0 +1,177 @@
+// Package agent serves UUIDv7s from one coordinated generator over a local
+// socket, so processes on the same host (written in any language) share a
+// single monotonic sequence.
+//
+// The wire protocol is deliberately simple. A request is a 4-byte big-endian
+// count n (1 <= n <= MaxBatch). The response is a status byte followed by:
+//
+//	status 0: n*16 bytes, the UUIDs in generation order
+//	status 1: a 2-byte big-endian length and a UTF-8 error message
+//
+// A connection may carry any number of requests.
+package agent
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// MaxBatch is the largest number of UUIDs a single request may ask for
+const MaxBatch = 1 << 16
+
+const (
+	statusOK    = 0
+	statusError = 1
+)
+
+// ErrServerClosed is returned by Serve after Close is called
+var ErrServerClosed = errors.New("agent: server closed")
+
+// Server hands out UUIDs from a single Generator to socket clients
+type Server struct {
+	gen *guuid.Generator
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// NewServer returns a Server backed by gen, or the default generator if gen is nil
+func NewServer(gen *guuid.Generator) *Server {
+	if gen == nil {
+		gen = guuid.Default()
+	}
+	return &Server{
+		gen:   gen,
+		conns: make(map[net.Conn]struct{}),
+	}
+}
+
+// Serve accepts connections on l until Close is called
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrServerClosed
+	}
+	s.listener = l
+	s.mu.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return ErrServerClosed
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+// Close stops the listener, closes all connections and waits for handlers to exit
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+// handle serves requests on a single connection until it is closed
+func (s *Server) handle(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+		s.wg.Done()
+	}()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	var hdr [4]byte
+	for {
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(hdr[:])
+		if err := s.respond(w, n); err != nil {
+			return
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// respond writes the response for a request of n UUIDs
+func (s *Server) respond(w *bufio.Writer, n uint32) error {
+	if n == 0 || n > MaxBatch {
+		return writeError(w, "batch size out of range")
+	}
+
+	ids := make([]guuid.UUID, n)
+	for i := range ids {
+		id, err := s.gen.New()
+		if err != nil {
+			return writeError(w, err.Error())
+		}
+		ids[i] = id
+	}
+
+	if err := w.WriteByte(statusOK); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if _, err := w.Write(id[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeError writes an error response
+func writeError(w *bufio.Writer, msg string) error {
+	if len(msg) > 0xFFFF {
+		msg = msg[:0xFFFF]
+	}
+	var hdr [3]byte
+	hdr[0] = statusError
+	binary.BigEndian.PutUint16(hdr[1:], uint16(len(msg)))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err := w.WriteString(msg)
+	return err
+}
@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func startServer(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	srv := NewServer(guuid.NewGenerator())
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+	return path
+}
+
+func TestClient_New(t *testing.T) {
+	path := startServer(t)
+	c, err := Dial(path, 16)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer c.Close()
+
+	var prev guuid.UUID
+	for i := 0; i < 100; i++ {
+		id, err := c.New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if id.Version() != guuid.VersionTimeSorted {
+			t.Errorf("New() version = %v, want %v", id.Version(), guuid.VersionTimeSorted)
+		}
+		if id.Compare(prev) <= 0 {
+			t.Errorf("New() = %v, not after %v", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestClient_NewBatch(t *testing.T) {
+	path := startServer(t)
+	c, err := Dial(path, 0)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer c.Close()
+
+	ids, err := c.NewBatch(1000)
+	if err != nil {
+		t.Fatalf("NewBatch() error = %v", err)
+	}
+	if len(ids) != 1000 {
+		t.Fatalf("NewBatch() returned %d IDs, want 1000", len(ids))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i].Compare(ids[i-1]) <= 0 {
+			t.Fatalf("NewBatch() not ordered at %d", i)
+		}
+	}
+
+	if _, err := c.NewBatch(MaxBatch + 1); err == nil {
+		t.Error("NewBatch() expected error for oversized batch")
+	}
+}
+
+func TestServer_RejectsBadBatchSize(t *testing.T) {
+	path := startServer(t)
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	c := NewClient(conn, 1)
+	defer c.Close()
+
+	// Bypass client-side validation to exercise the server's error response
+	if _, err := c.fetch(0); err == nil {
+		t.Error("fetch(0) expected server error")
+	}
+	if _, err := c.New(); err != nil {
+		t.Errorf("connection unusable after error response: %v", err)
+	}
+}
+
+func TestClients_SharedSequenceUnique(t *testing.T) {
+	path := startServer(t)
+	var mu sync.Mutex
+	seen := make(map[guuid.UUID]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := Dial(path, 8)
+			if err != nil {
+				t.Errorf("Dial() error = %v", err)
+				return
+			}
+			defer c.Close()
+			for j := 0; j < 200; j++ {
+				id, err := c.New()
+				if err != nil {
+					t.Errorf("New() error = %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("duplicate UUID %v", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// DefaultBatchSize is the number of UUIDs a Client fetches per round trip
+const DefaultBatchSize = 128
+
+// Client fetches UUIDs from an agent in batches and serves them from a local
+// buffer. IDs are unique and each batch is ordered, but when several clients
+// hold batches concurrently their consumption order interleaves; use a batch
+// size of 1 if every ID must be newer than all IDs issued before it.
+//
+// Client is safe for concurrent use.
+type Client struct {
+	batch int
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+	buf  []guuid.UUID
+}
+
+// Dial connects to the agent listening on the unix socket at path
+func Dial(path string, batchSize int) (*Client, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn, batchSize), nil
+}
+
+// NewClient returns a Client using an established connection.
+// A batchSize outside 1..MaxBatch selects DefaultBatchSize.
+func NewClient(conn net.Conn, batchSize int) *Client {
+	if batchSize < 1 || batchSize > MaxBatch {
+		batchSize = DefaultBatchSize
+	}
+	return &Client{
+		batch: batchSize,
+		conn:  conn,
+		r:     bufio.NewReader(conn),
+	}
+}
+
+// New returns the next UUID, fetching a new batch when the buffer is empty
+func (c *Client) New() (guuid.UUID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.buf) == 0 {
+		ids, err := c.fetch(c.batch)
+		if err != nil {
+			return guuid.Nil, err
+		}
+		c.buf = ids
+	}
+	id := c.buf[0]
+	c.buf = c.buf[1:]
+	return id, nil
+}
+
+// NewBatch fetches n fresh UUIDs directly from the agent, bypassing the buffer
+func (c *Client) NewBatch(n int) ([]guuid.UUID, error) {
+	if n < 1 || n > MaxBatch {
+		return nil, fmt.Errorf("agent: batch size %d out of range", n)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetch(n)
+}
+
+// Close closes the connection; buffered UUIDs are discarded
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf = nil
+	return c.conn.Close()
+}
+
+// fetch performs one request/response round trip
+func (c *Client) fetch(n int) ([]guuid.UUID, error) {
+	var hdr [4]byte
+	binary.BigEndian.PutUint32(hdr[:], uint32(n))
+	if _, err := c.conn.Write(hdr[:]); err != nil {
+		return nil, err
+	}
+
+	status, err := c.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if status != statusOK {
+		var lenBuf [2]byte
+		if _, err := io.ReadFull(c.r, lenBuf[:]); err != nil {
+			return nil, err
+		}
+		msg := make([]byte, binary.BigEndian.Uint16(lenBuf[:]))
+		if _, err := io.ReadFull(c.r, msg); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("agent: " + string(msg))
+	}
+
+	ids := make([]guuid.UUID, n)
+	for i := range ids {
+		if _, err := io.ReadFull(c.r, ids[i][:]); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
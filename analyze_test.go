@@ -0,0 +1,82 @@
+package guuid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeStream(t *testing.T) {
+	gen := NewGenerator()
+	at := time.UnixMilli(1700000000000)
+	a := Must(gen.NewWithTime(at))
+	b := Must(gen.NewWithTime(at))                            // gap 0
+	c := Must(gen.NewWithTime(at.Add(3 * time.Millisecond)))  // gap 3
+	d := Must(NewV7FromParts(at.UnixMilli()-5, 0, [8]byte{})) // 8ms back
+	v5 := NewV5(NamespaceURL, []byte("https://example.com"))
+
+	input := strings.Join([]string{
+		a.String(),
+		"",
+		"  " + b.String() + "  ",
+		`"` + c.String() + `"`,
+		"not-a-uuid",
+		d.String(),
+		v5.String(),
+		b.String(),
+	}, "\n")
+
+	rep, err := AnalyzeStream(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("AnalyzeStream() error = %v", err)
+	}
+	if rep.Total != 6 || rep.Invalid != 1 {
+		t.Errorf("Total, Invalid = %d, %d, want 6, 1", rep.Total, rep.Invalid)
+	}
+	if rep.Versions[VersionTimeSorted] != 5 || rep.Versions[VersionNameBasedSHA1] != 1 {
+		t.Errorf("Versions = %v, want 5 v7 and 1 v5", rep.Versions)
+	}
+	if !rep.First.Equal(d.Time()) || !rep.Last.Equal(c.Time()) {
+		t.Errorf("First, Last = %v, %v, want %v, %v", rep.First, rep.Last, d.Time(), c.Time())
+	}
+	// d after c, and b after d moves forward again
+	if rep.OutOfOrder != 1 || rep.MaxBackstep != 8*time.Millisecond {
+		t.Errorf("OutOfOrder, MaxBackstep = %d, %v, want 1, 8ms", rep.OutOfOrder, rep.MaxBackstep)
+	}
+	if rep.Duplicates != 1 {
+		t.Errorf("Duplicates = %d, want 1", rep.Duplicates)
+	}
+	// gaps: a->b 0ms, b->c 3ms, d->b 5ms
+	want := map[int]int{0: 1, 2: 1, 3: 1}
+	for i, n := range rep.InterArrival {
+		if n != want[i] {
+			t.Errorf("InterArrival[%d] = %d, want %d", i, n, want[i])
+		}
+	}
+}
+
+func TestAnalyzeStream_LongGap(t *testing.T) {
+	a := Must(NewV7FromParts(0, 0, [8]byte{}))
+	b := Must(NewV7FromParts(int64(time.Hour/time.Millisecond)*24, 0, [8]byte{}))
+	rep, err := AnalyzeStream(strings.NewReader(a.String() + "\n" + b.String()))
+	if err != nil {
+		t.Fatalf("AnalyzeStream() error = %v", err)
+	}
+	if got := rep.InterArrival[len(rep.InterArrival)-1]; got != 1 {
+		t.Errorf("last InterArrival bucket = %d, want 1", got)
+	}
+}
+
+func TestAnalyzeStream_ReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader(Must(New()).String()+"\n"), &brokenReader{})
+	rep, err := AnalyzeStream(r)
+	if !errors.Is(err, bytes.ErrTooLarge) {
+		t.Errorf("AnalyzeStream() error = %v, want %v", err, bytes.ErrTooLarge)
+	}
+	if rep == nil || rep.Total != 1 {
+		t.Errorf("AnalyzeStream() report = %+v, want 1 UUID", rep)
+	}
+}
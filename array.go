@@ -0,0 +1,153 @@
+package guuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Array is a []UUID that scans from and is written as a Postgres uuid[]
+// value, e.g. to pass a set of IDs to ANY:
+//
+//	rows, err := db.QueryContext(ctx, `SELECT ... WHERE id = ANY($1)`, guuid.Array(ids))
+//	err = row.Scan((*guuid.Array)(&ids))
+//
+// A nil Array is written as NULL and an empty one as '{}'.
+type Array []UUID
+
+// Scan implements the sql.Scanner interface. A NULL element is an error;
+// scan into a NullArray if the column may contain NULLs.
+func (a *Array) Scan(src interface{}) error {
+	elems, err := scanArray(src)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	ids := make(Array, len(elems))
+	for i, e := range elems {
+		if e.null {
+			*a = nil
+			return fmt.Errorf("guuid: NULL element %d in uuid[], use NullArray", i)
+		}
+		if ids[i], err = Parse(e.text); err != nil {
+			*a = nil
+			return err
+		}
+	}
+	*a = ids
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (a Array) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.Grow(2 + len(a)*37)
+	b.WriteByte('{')
+	for i, u := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(u.String())
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// NullArray is a []NullUUID that scans from and is written as a Postgres
+// uuid[] value whose elements may be NULL. A nil NullArray is written as
+// NULL.
+type NullArray []NullUUID
+
+// Scan implements the sql.Scanner interface
+func (a *NullArray) Scan(src interface{}) error {
+	elems, err := scanArray(src)
+	if err != nil || elems == nil {
+		*a = nil
+		return err
+	}
+	ids := make(NullArray, len(elems))
+	for i, e := range elems {
+		if e.null {
+			continue
+		}
+		if ids[i].UUID, err = Parse(e.text); err != nil {
+			*a = nil
+			return err
+		}
+		ids[i].Valid = true
+	}
+	*a = ids
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (a NullArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var b strings.Builder
+	b.Grow(2 + len(a)*37)
+	b.WriteByte('{')
+	for i, n := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if n.Valid {
+			b.WriteString(n.UUID.String())
+		} else {
+			b.WriteString("NULL")
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+// arrayElem is an element of a Postgres array literal
+type arrayElem struct {
+	text string
+	null bool
+}
+
+// scanArray splits a one-dimensional Postgres array literal such as
+// {a,"b",NULL} into its elements. A nil src yields nil elements.
+func scanArray(src interface{}) ([]arrayElem, error) {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return nil, fmt.Errorf("guuid: cannot scan type %T into uuid[]", src)
+	}
+
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("guuid: invalid uuid[] literal %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if body == "" {
+		return []arrayElem{}, nil
+	}
+	if strings.ContainsAny(body, "{}") {
+		return nil, fmt.Errorf("guuid: multidimensional uuid[] literal %q", s)
+	}
+
+	parts := strings.Split(body, ",")
+	elems := make([]arrayElem, len(parts))
+	for i, p := range parts {
+		switch {
+		case len(p) >= 2 && p[0] == '"' && p[len(p)-1] == '"':
+			elems[i].text = p[1 : len(p)-1]
+		case strings.EqualFold(p, "NULL"):
+			elems[i].null = true
+		default:
+			elems[i].text = p
+		}
+	}
+	return elems, nil
+}
@@ -0,0 +1,95 @@
+package guuid
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestArray_Value(t *testing.T) {
+	a := MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70")
+	b := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+
+	tests := []struct {
+		name string
+		arr  Array
+		want driver.Value
+	}{
+		{"nil", nil, nil},
+		{"empty", Array{}, "{}"},
+		{"one", Array{a}, "{" + a.String() + "}"},
+		{"two", Array{a, b}, "{" + a.String() + "," + b.String() + "}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arr.Value()
+			if err != nil || got != tt.want {
+				t.Errorf("Value() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestArray_Scan(t *testing.T) {
+	a := MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70")
+	b := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Array
+		wantErr bool
+	}{
+		{"nil", nil, nil, false},
+		{"empty", "{}", Array{}, false},
+		{"string", "{" + a.String() + "," + b.String() + "}", Array{a, b}, false},
+		{"bytes", []byte("{" + a.String() + "}"), Array{a}, false},
+		{"quoted", `{"` + a.String() + `"}`, Array{a}, false},
+		{"null element", "{" + a.String() + ",NULL}", nil, true},
+		{"bad element", "{nope}", nil, true},
+		{"no braces", a.String(), nil, true},
+		{"nested", "{{" + a.String() + "}}", nil, true},
+		{"bad type", 42, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Array{b}
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || len(got) != len(tt.want) {
+				t.Fatalf("Scan() = %#v, want %#v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Scan()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNullArray(t *testing.T) {
+	a := MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70")
+	arr := NullArray{{UUID: a, Valid: true}, {}}
+
+	v, err := arr.Value()
+	if want := "{" + a.String() + ",NULL}"; err != nil || v != want {
+		t.Fatalf("Value() = %v, %v, want %v", v, err, want)
+	}
+
+	var got NullArray
+	if err := got.Scan(v); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != arr[0] || got[1] != arr[1] {
+		t.Errorf("Scan() = %v, want %v", got, arr)
+	}
+
+	if err := got.Scan("{bad}"); err == nil || got != nil {
+		t.Errorf("Scan(bad) = %v, %v, want nil and an error", got, err)
+	}
+	if v, _ := NullArray(nil).Value(); v != nil {
+		t.Errorf("nil Value() = %v, want nil", v)
+	}
+}
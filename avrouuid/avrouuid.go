@@ -0,0 +1,85 @@
+// Package avrouuid encodes UUIDs for Avro's uuid logical type, in either of
+// its two representations:
+//
+//	{"type": "string", "logicalType": "uuid"}
+//	{"type": "fixed", "name": "uuid", "size": 16, "logicalType": "uuid"}
+//
+// With hamba/avro no helper is needed for struct fields: guuid.UUID is a
+// [16]byte array, which the codec maps to fixed(16), and it implements
+// encoding.TextMarshaler, which the codec uses for string schemas. The
+// Append and Read functions here produce and consume the raw Avro binary
+// encoding for code that writes records by hand.
+package avrouuid
+
+import (
+	"errors"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// Schemas for the two representations of the uuid logical type
+const (
+	StringSchema = `{"type":"string","logicalType":"uuid"}`
+	FixedSchema  = `{"type":"fixed","name":"uuid","size":16,"logicalType":"uuid"}`
+)
+
+// stringLen is the length of a canonical UUID string
+const stringLen = 36
+
+// ErrShortBuffer indicates that the input ended before a complete value
+var ErrShortBuffer = errors.New("avrouuid: short buffer")
+
+// AppendString appends u in the Avro binary encoding of a string: a
+// zig-zag varint length followed by the canonical UUID text.
+func AppendString(dst []byte, u guuid.UUID) []byte {
+	dst = append(dst, stringLen<<1) // zig-zag encoding of 36 fits in one byte
+	return append(dst, u.String()...)
+}
+
+// ReadString decodes an Avro string holding a UUID from the start of src and
+// returns it with the number of bytes consumed.
+func ReadString(src []byte) (guuid.UUID, int, error) {
+	n, size, err := readLong(src)
+	if err != nil {
+		return guuid.Nil, 0, err
+	}
+	if n < 0 {
+		return guuid.Nil, 0, guuid.ErrInvalidLength
+	}
+	if int64(len(src)-size) < n {
+		return guuid.Nil, 0, ErrShortBuffer
+	}
+	u, err := guuid.Parse(string(src[size : size+int(n)]))
+	if err != nil {
+		return guuid.Nil, 0, err
+	}
+	return u, size + int(n), nil
+}
+
+// AppendFixed appends u in the Avro binary encoding of fixed(16), which is
+// the 16 raw bytes.
+func AppendFixed(dst []byte, u guuid.UUID) []byte {
+	return append(dst, u[:]...)
+}
+
+// ReadFixed decodes a fixed(16) UUID from the start of src
+func ReadFixed(src []byte) (guuid.UUID, int, error) {
+	if len(src) < 16 {
+		return guuid.Nil, 0, ErrShortBuffer
+	}
+	u, _ := guuid.FromBytes(src[:16])
+	return u, 16, nil
+}
+
+// readLong decodes an Avro zig-zag varint long
+func readLong(src []byte) (int64, int, error) {
+	var v uint64
+	for i := 0; i < len(src) && i < 10; i++ {
+		b := src[i]
+		v |= uint64(b&0x7f) << (7 * i)
+		if b < 0x80 {
+			return int64(v>>1) ^ -int64(v&1), i + 1, nil
+		}
+	}
+	return 0, 0, ErrShortBuffer
+}
@@ -0,0 +1,68 @@
+package avrouuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestString_RoundTrip(t *testing.T) {
+	u := guuid.MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	buf := AppendString([]byte{0xAA}, u)
+
+	// Length 36 zig-zag encodes as 72 (0x48)
+	if buf[1] != 0x48 || string(buf[2:]) != u.String() {
+		t.Fatalf("AppendString() = %x", buf)
+	}
+
+	got, n, err := ReadString(buf[1:])
+	if err != nil {
+		t.Fatalf("ReadString() error = %v", err)
+	}
+	if got != u || n != 37 {
+		t.Errorf("ReadString() = %v, %d, want %v, 37", got, n, u)
+	}
+}
+
+func TestReadString_Errors(t *testing.T) {
+	u := guuid.MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	full := AppendString(nil, u)
+
+	tests := []struct {
+		name string
+		src  []byte
+		want error
+	}{
+		{"empty", nil, ErrShortBuffer},
+		{"truncated", full[:20], ErrShortBuffer},
+		{"unterminated varint", []byte{0x80, 0x80}, ErrShortBuffer},
+		{"negative length", []byte{0x01}, guuid.ErrInvalidLength},
+		{"not a uuid", append([]byte{0x06}, "abc"...), guuid.ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ReadString(tt.src); !errors.Is(err, tt.want) {
+				t.Errorf("ReadString() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixed_RoundTrip(t *testing.T) {
+	u := guuid.Must(guuid.New())
+	buf := AppendFixed(nil, u)
+	if !bytes.Equal(buf, u[:]) {
+		t.Fatalf("AppendFixed() = %x, want %x", buf, u[:])
+	}
+
+	got, n, err := ReadFixed(append(buf, 0xFF))
+	if err != nil || got != u || n != 16 {
+		t.Errorf("ReadFixed() = %v, %d, %v, want %v, 16, nil", got, n, err, u)
+	}
+	if _, _, err := ReadFixed(buf[:15]); !errors.Is(err, ErrShortBuffer) {
+		t.Errorf("ReadFixed(short) error = %v, want %v", err, ErrShortBuffer)
+	}
+}
@@ -0,0 +1,88 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMarshalBatch_RoundTrip(t *testing.T) {
+	gen := NewGenerator()
+	sorted := make([]UUID, 1000)
+	for i := range sorted {
+		sorted[i] = Must(gen.New())
+	}
+	mixed := []UUID{
+		MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+		Nil,
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		sorted[0],
+	}
+
+	tests := []struct {
+		name string
+		ids  []UUID
+	}{
+		{"empty", nil},
+		{"sorted v7", sorted},
+		{"mixed versions and order", mixed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := MarshalBatch(tt.ids)
+			got, err := UnmarshalBatch(data)
+			if err != nil {
+				t.Fatalf("UnmarshalBatch() error = %v", err)
+			}
+			if len(got) != len(tt.ids) {
+				t.Fatalf("UnmarshalBatch() returned %d IDs, want %d", len(got), len(tt.ids))
+			}
+			for i := range got {
+				if got[i] != tt.ids[i] {
+					t.Errorf("ID %d = %v, want %v", i, got[i], tt.ids[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMarshalBatch_Size(t *testing.T) {
+	gen := NewGenerator()
+	start := time.Now()
+	ids := make([]UUID, 10000)
+	for i := range ids {
+		ids[i] = Must(gen.NewWithTime(start.Add(time.Duration(i) * time.Millisecond)))
+	}
+	data := MarshalBatch(ids)
+	if perID := float64(len(data)) / float64(len(ids)); perID > 11.1 {
+		t.Errorf("MarshalBatch() uses %.2f bytes per ID, want about 11", perID)
+	}
+}
+
+func TestUnmarshalBatch_Errors(t *testing.T) {
+	valid := MarshalBatch([]UUID{Must(New()), Must(New())})
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrInvalidFormat},
+		{"bad magic", append([]byte("XX\x01"), valid[3:]...), ErrInvalidFormat},
+		{"bad version", append([]byte("GU\x02"), valid[3:]...), ErrInvalidFormat},
+		{"no count", valid[:3], ErrInvalidLength},
+		{"truncated", valid[:len(valid)-1], ErrInvalidLength},
+		{"trailing bytes", append(valid[:len(valid):len(valid)], 0), ErrInvalidLength},
+		{"huge count", []byte{'G', 'U', 1, 0xff, 0xff, 0xff, 0xff, 0x0f}, ErrInvalidLength},
+		{"negative timestamp", append([]byte{'G', 'U', 1, 1, 0x01}, make([]byte, 10)...), ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := UnmarshalBatch(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("UnmarshalBatch() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
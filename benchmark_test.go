@@ -1,6 +1,8 @@
 package guuid
 
 import (
+	"encoding/json"
+	"io"
 	"testing"
 )
 
@@ -75,6 +77,20 @@ func BenchmarkUUID_MarshalText(b *testing.B) {
 	}
 }
 
+func BenchmarkUUID_MarshalJSON(b *testing.B) {
+	type row struct {
+		ID, Parent, Owner UUID
+	}
+	r := row{Must(New()), Must(New()), Must(New())}
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkUUID_UnmarshalText(b *testing.B) {
 	text := []byte("f47ac10b-58cc-4372-a567-0e02b2c3d479")
 	b.ResetTimer()
@@ -214,3 +230,68 @@ func BenchmarkGenerator_NewBatch(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkEncoder_Encode(b *testing.B) {
+	uuid, _ := New()
+	enc := NewEncoder(io.Discard)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := enc.Encode(uuid); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalBatch(b *testing.B) {
+	gen := NewGenerator()
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+	}
+	buf := make([]byte, 0, 16*len(ids))
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendBatch(buf[:0], ids)
+	}
+}
+
+func BenchmarkUnmarshalBatch(b *testing.B) {
+	gen := NewGenerator()
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+	}
+	data := MarshalBatch(ids)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := UnmarshalBatch(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBatchValuer_Values(b *testing.B) {
+	gen := NewGenerator()
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+	}
+	bv := BatchValuer{Dialect: DialectPostgres}
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = bv.Values(ids)
+	}
+}
+
+func BenchmarkUUID_Hash64(b *testing.B) {
+	u := Must(New())
+	var sink uint64
+	for i := 0; i < b.N; i++ {
+		sink += u.Hash64(uint64(i))
+	}
+	_ = sink
+}
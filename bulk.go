@@ -0,0 +1,85 @@
+package guuid
+
+import (
+	"strconv"
+	"strings"
+)
+
+// BatchValuer converts many UUIDs at once into arguments for multi-row
+// INSERT statements and COPY, encoding all of them into one shared buffer
+// instead of allocating a string per row with UUID.Value:
+//
+//	bv := guuid.BatchValuer{Dialect: guuid.DialectPostgres}
+//	query := "INSERT INTO events (id) VALUES " + bv.Placeholders(len(ids), 1, 1)
+//	_, err := db.ExecContext(ctx, query, bv.Values(ids)...)
+type BatchValuer struct {
+	// Dialect selects the placeholder style, ? by default, $n for Postgres
+	// and @pn for SQL Server, and the binary byte order for SQL Server
+	Dialect Dialect
+
+	// Binary sends 16-byte values, e.g. for MySQL BINARY(16) columns,
+	// instead of canonical strings
+	Binary bool
+}
+
+// Values returns ids encoded as driver values, in order
+func (b BatchValuer) Values(ids []UUID) []any {
+	vals := make([]any, len(ids))
+	if b.Binary {
+		buf := make([]byte, 16*len(ids))
+		for i, u := range ids {
+			if b.Dialect == DialectMSSQL {
+				u = swapMSSQL(u)
+			}
+			v := buf[16*i : 16*(i+1) : 16*(i+1)]
+			copy(v, u[:])
+			vals[i] = v
+		}
+		return vals
+	}
+
+	buf := make([]byte, 36*len(ids))
+	for i, u := range ids {
+		encodeHex(buf[36*i:36*(i+1)], u)
+	}
+	s := string(buf)
+	for i := range ids {
+		vals[i] = s[36*i : 36*(i+1)]
+	}
+	return vals
+}
+
+// Placeholders returns the row list of a multi-row VALUES clause for rows
+// rows of cols columns each, such as "($1,$2),($3,$4)", numbering parameters
+// from start in dialects that number them
+func (b BatchValuer) Placeholders(rows, cols, start int) string {
+	if rows <= 0 || cols <= 0 {
+		return ""
+	}
+	var sb strings.Builder
+	n := start
+	for r := 0; r < rows; r++ {
+		if r > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('(')
+		for c := 0; c < cols; c++ {
+			if c > 0 {
+				sb.WriteByte(',')
+			}
+			switch b.Dialect {
+			case DialectPostgres:
+				sb.WriteByte('$')
+				sb.WriteString(strconv.Itoa(n))
+			case DialectMSSQL:
+				sb.WriteString("@p")
+				sb.WriteString(strconv.Itoa(n))
+			default:
+				sb.WriteByte('?')
+			}
+			n++
+		}
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package guuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBatchValuer_Values(t *testing.T) {
+	ids := testIDs(3)
+
+	text := BatchValuer{}.Values(ids)
+	for i, v := range text {
+		if v != ids[i].String() {
+			t.Errorf("Values()[%d] = %v, want %v", i, v, ids[i])
+		}
+	}
+
+	bin := BatchValuer{Binary: true}.Values(ids)
+	for i, v := range bin {
+		b, ok := v.([]byte)
+		if !ok || !bytes.Equal(b, ids[i][:]) {
+			t.Errorf("binary Values()[%d] = %v, want %v", i, v, ids[i][:])
+		}
+	}
+	// values must not share capacity, or a driver appending to one would
+	// overwrite the next
+	if b := bin[0].([]byte); cap(b) != 16 {
+		t.Errorf("cap(Values()[0]) = %d, want 16", cap(b))
+	}
+
+	mssql := BatchValuer{Dialect: DialectMSSQL, Binary: true}.Values(ids)
+	for i, v := range mssql {
+		var u UUID
+		if err := ScanAs(&u, DialectMSSQL).Scan(v); err != nil || u != ids[i] {
+			t.Errorf("MSSQL Values()[%d] scans as %v, %v, want %v", i, u, err, ids[i])
+		}
+	}
+
+	if got := (BatchValuer{}).Values(nil); len(got) != 0 {
+		t.Errorf("Values(nil) = %v, want empty", got)
+	}
+}
+
+func TestBatchValuer_Placeholders(t *testing.T) {
+	tests := []struct {
+		d                 Dialect
+		rows, cols, start int
+		want              string
+	}{
+		{DialectDefault, 2, 2, 1, "(?,?),(?,?)"},
+		{DialectPostgres, 2, 2, 1, "($1,$2),($3,$4)"},
+		{DialectPostgres, 1, 3, 5, "($5,$6,$7)"},
+		{DialectMSSQL, 2, 1, 1, "(@p1),(@p2)"},
+		{DialectPostgres, 0, 2, 1, ""},
+	}
+	for _, tt := range tests {
+		if got := (BatchValuer{Dialect: tt.d}).Placeholders(tt.rows, tt.cols, tt.start); got != tt.want {
+			t.Errorf("Placeholders(%d, %d, %d) for %v = %q, want %q", tt.rows, tt.cols, tt.start, tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package guuid
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CensusReport summarizes the versions and variants of a set of UUIDs
+type CensusReport struct {
+	Total int
+	Nil   int // Nil UUIDs, counted in no version or variant
+
+	Variants map[Variant]int
+	Versions map[Version]int // RFC 4122 variant UUIDs per version
+
+	// FirstIndex maps each version in Versions to the index of its first
+	// UUID, to locate offenders
+	FirstIndex map[Version]int
+}
+
+// Census counts the versions and variants of ids, e.g. to audit a dataset
+// that has historically mixed v1, v4 and v7 keys before migrating it
+func Census(ids []UUID) CensusReport {
+	r := CensusReport{
+		Total:      len(ids),
+		Variants:   make(map[Variant]int),
+		Versions:   make(map[Version]int),
+		FirstIndex: make(map[Version]int),
+	}
+	for i, u := range ids {
+		if u == Nil {
+			r.Nil++
+			continue
+		}
+		variant := u.Variant()
+		r.Variants[variant]++
+		if variant != VariantRFC4122 {
+			continue
+		}
+		v := u.Version()
+		if r.Versions[v] == 0 {
+			r.FirstIndex[v] = i
+		}
+		r.Versions[v]++
+	}
+	return r
+}
+
+// Unexpected returns the versions found other than allowed, in ascending order
+func (r CensusReport) Unexpected(allowed ...Version) []Version {
+	var found []Version
+	for v := range r.Versions {
+		if !slices.Contains(allowed, v) {
+			found = append(found, v)
+		}
+	}
+	slices.Sort(found)
+	return found
+}
+
+// Validate returns an error describing every UUID that is not an RFC 4122
+// UUID of one of the allowed versions, wrapping ErrInvalidVersion or
+// ErrInvalidVariant, or nil if there are none. Nil UUIDs are not checked.
+func (r CensusReport) Validate(allowed ...Version) error {
+	var problems []string
+	for _, v := range r.Unexpected(allowed...) {
+		problems = append(problems, fmt.Sprintf("%d x %v (first at index %d)", r.Versions[v], v, r.FirstIndex[v]))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidVersion, strings.Join(problems, ", "))
+	}
+	if other := r.Total - r.Nil - r.Variants[VariantRFC4122]; other > 0 {
+		return fmt.Errorf("%w: %d non-RFC 4122 UUIDs", ErrInvalidVariant, other)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package guuid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestCensus(t *testing.T) {
+	v7 := Must(New())
+	v5 := NewV5(NamespaceDNS, []byte("example.com"))
+	v4 := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	ms := MustParse("f47ac10b-58cc-4372-c567-0e02b2c3d479") // Microsoft variant
+
+	r := Census([]UUID{v7, v4, v7, Nil, v5, ms, v4})
+
+	if r.Total != 7 || r.Nil != 1 {
+		t.Errorf("Total, Nil = %d, %d, want 7, 1", r.Total, r.Nil)
+	}
+	if r.Variants[VariantRFC4122] != 5 || r.Variants[VariantMicrosoft] != 1 {
+		t.Errorf("Variants = %v", r.Variants)
+	}
+	wantVersions := map[Version]int{VersionTimeSorted: 2, VersionRandom: 2, VersionNameBasedSHA1: 1}
+	for v, n := range wantVersions {
+		if r.Versions[v] != n {
+			t.Errorf("Versions[%v] = %d, want %d", v, r.Versions[v], n)
+		}
+	}
+	if len(r.Versions) != len(wantVersions) {
+		t.Errorf("Versions = %v, want %v", r.Versions, wantVersions)
+	}
+	if r.FirstIndex[VersionRandom] != 1 || r.FirstIndex[VersionNameBasedSHA1] != 4 {
+		t.Errorf("FirstIndex = %v", r.FirstIndex)
+	}
+
+	if got := r.Unexpected(VersionTimeSorted); !slices.Equal(got, []Version{VersionRandom, VersionNameBasedSHA1}) {
+		t.Errorf("Unexpected(v7) = %v", got)
+	}
+	if err := r.Validate(VersionTimeSorted); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("Validate(v7) error = %v, want ErrInvalidVersion", err)
+	}
+	if err := r.Validate(VersionTimeSorted, VersionRandom, VersionNameBasedSHA1); !errors.Is(err, ErrInvalidVariant) {
+		t.Errorf("Validate(all) error = %v, want ErrInvalidVariant", err)
+	}
+}
+
+func TestCensus_Clean(t *testing.T) {
+	r := Census(append(testIDs(10), Nil))
+	if err := r.Validate(VersionTimeSorted); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if got := r.Unexpected(VersionTimeSorted); got != nil {
+		t.Errorf("Unexpected() = %v, want nil", got)
+	}
+}
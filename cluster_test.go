@@ -0,0 +1,76 @@
+package guuid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memCounter is an in-memory ClusterCounter shared by several generators
+type memCounter struct {
+	mu      sync.Mutex
+	ms      int64
+	counter uint16
+	err     error
+}
+
+func (m *memCounter) Next(_ context.Context, floor int64) (int64, uint16, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return 0, 0, m.err
+	}
+	switch {
+	case floor > m.ms:
+		m.ms, m.counter = floor, 0
+	case m.counter == 0x0FFF:
+		m.ms, m.counter = m.ms+1, 0
+	default:
+		m.counter++
+	}
+	return m.ms, m.counter, nil
+}
+
+func TestClusterGenerator(t *testing.T) {
+	counter := &memCounter{}
+	nodes := []*ClusterGenerator{NewClusterGenerator(counter), NewClusterGenerator(counter)}
+
+	before := time.Now().UnixMilli()
+	var prev UUID
+	for i := 0; i < 10000; i++ {
+		u := Must(nodes[i%2].New())
+		if u.Version() != VersionTimeSorted || u.Variant() != VariantRFC4122 {
+			t.Fatalf("version/variant = %v/%v, want v7 RFC", u.Version(), u.Variant())
+		}
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("New() #%d = %v, not after %v", i, u, prev)
+		}
+		prev = u
+	}
+	if ts := prev.Timestamp(); ts < before {
+		t.Errorf("Timestamp() = %d, want >= %d", ts, before)
+	}
+}
+
+func TestClusterGenerator_ClockSkew(t *testing.T) {
+	counter := &memCounter{ms: time.Now().Add(time.Hour).UnixMilli()}
+	gen := NewClusterGenerator(counter)
+
+	u := Must(gen.New())
+	if got, want := u.Timestamp(), counter.ms; got != want {
+		t.Errorf("Timestamp() = %d, want cluster time %d", got, want)
+	}
+	if got := uint16(u[6]&0x0F)<<8 | uint16(u[7]); got != 1 {
+		t.Errorf("counter = %d, want 1", got)
+	}
+}
+
+func TestClusterGenerator_Error(t *testing.T) {
+	errDown := errors.New("store down")
+	gen := NewClusterGenerator(&memCounter{err: errDown})
+	if _, err := gen.NewWithContext(context.Background()); !errors.Is(err, errDown) {
+		t.Errorf("NewWithContext() error = %v, want %v", err, errDown)
+	}
+}
@@ -0,0 +1,48 @@
+// Command guuid-agent serves UUIDv7s over a unix socket so that every process
+// on the host draws from one coordinated, monotonic generator. See package
+// github.com/Lzww0608/guuid/agent for the wire protocol and a Go client.
+//
+// Usage:
+//
+//	guuid-agent -socket /run/guuid.sock
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Lzww0608/guuid"
+	"github.com/Lzww0608/guuid/agent"
+)
+
+func main() {
+	socket := flag.String("socket", "/tmp/guuid-agent.sock", "unix socket path to listen on")
+	flag.Parse()
+
+	// Remove a stale socket left behind by an unclean shutdown
+	if err := os.Remove(*socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("guuid-agent: %v", err)
+	}
+	l, err := net.Listen("unix", *socket)
+	if err != nil {
+		log.Fatalf("guuid-agent: %v", err)
+	}
+
+	srv := agent.NewServer(guuid.NewGenerator())
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sig
+		srv.Close()
+	}()
+
+	log.Printf("guuid-agent listening on %s", *socket)
+	if err := srv.Serve(l); err != nil && !errors.Is(err, agent.ErrServerClosed) {
+		log.Fatalf("guuid-agent: %v", err)
+	}
+}
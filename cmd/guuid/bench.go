@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// benchCase is a single throughput measurement
+type benchCase struct {
+	name string
+	fn   func(b *testing.B)
+}
+
+// benchCases returns the operations measured by the bench command
+func benchCases() []benchCase {
+	sample := guuid.Must(guuid.New())
+	canonical := sample.String()
+
+	return []benchCase{
+		{"new", func(b *testing.B) {
+			gen := guuid.NewGenerator()
+			for i := 0; i < b.N; i++ {
+				if _, err := gen.New(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"new-parallel", func(b *testing.B) {
+			gen := guuid.NewGenerator()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := gen.New(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}},
+		{"parse", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := guuid.Parse(canonical); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"string", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = sample.String()
+			}
+		}},
+		{"base64", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = sample.EncodeToBase64()
+			}
+		}},
+	}
+}
+
+// runBench implements "guuid bench". The comparison against google/uuid and
+// gofrs/uuid is in the others/benchcompare module, which keeps those
+// dependencies out of the root module.
+func runBench(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	filter := fs.String("run", "", "only run cases whose name contains this string")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: guuid bench [flags]")
+		fmt.Fprintln(stderr, "Measures guuid on this machine; run others/benchcompare to compare it with google/uuid and gofrs/uuid.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	fmt.Fprintf(stdout, "%s %s/%s, GOMAXPROCS=%d\n", runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.GOMAXPROCS(0))
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "case\tns/op\tops/s\tallocs/op")
+	for _, c := range benchCases() {
+		if *filter != "" && !strings.Contains(c.name, *filter) {
+			continue
+		}
+		fn := c.fn
+		res := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			fn(b)
+		})
+		nsPerOp := float64(res.T.Nanoseconds()) / float64(res.N)
+		fmt.Fprintf(tw, "%s\t%.1f\t%.0f\t%d\n", c.name, nsPerOp, 1e9/nsPerOp, res.AllocsPerOp())
+	}
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintf(stderr, "guuid bench: %v\n", err)
+		return 1
+	}
+	return 0
+}
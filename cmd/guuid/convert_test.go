@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunConvert(t *testing.T) {
+	const (
+		v1 = "c232ab00-9414-11ec-b3c8-9f6bdeced846"
+		v6 = "1ec9414c-232a-6b00-b3c8-9f6bdeced846"
+	)
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		want     string
+		wantCode int
+	}{
+		{"hex to base58", []string{"--from", "hex", "--to", "base58", "f47ac10b58cc4372a5670e02b2c3d479"}, "", "XBz3jkFgmHZpHEmghHCsXn\n", 0},
+		{"base58 to canonical", []string{"-from", "base58", "XBz3jkFgmHZpHEmghHCsXn"}, "", "f47ac10b-58cc-4372-a567-0e02b2c3d479\n", 0},
+		{"stdin", []string{"-to", "hex"}, v1 + "\n\n" + v6 + "\n", "c232ab00941411ecb3c89f6bdeced846\n1ec9414c232a6b00b3c89f6bdeced846\n", 0},
+		{"v1 to v6", []string{"-version", "v6", v1}, "", v6 + "\n", 0},
+		{"v6 to v1", []string{"-version", "1", v6}, "", v1 + "\n", 0},
+		{"v4 cannot convert", []string{"-version", "v7", "f47ac10b-58cc-4372-a567-0e02b2c3d479"}, "", "", 1},
+		{"bad input", []string{"-from", "hex", "xyz"}, "", "", 1},
+		{"unknown encoding", []string{"-to", "rot13"}, "", "", 2},
+		{"unsupported version", []string{"-version", "v4"}, "", "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runConvert(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("runConvert() = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			if tt.want != "" && stdout.String() != tt.want {
+				t.Errorf("output = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertVersion_RoundTrip(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	const v7 = "0186d4b4-3ab1-7d0a-8e3f-4f1a2b3c4d5e"
+	if code := runConvert([]string{"-version", "v6", v7}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("runConvert(v7 -> v6) = %d, stderr %q", code, stderr.String())
+	}
+	v6 := strings.TrimSpace(stdout.String())
+
+	stdout.Reset()
+	if code := runConvert([]string{"-version", "v7", v6}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("runConvert(v6 -> v7) = %d, stderr %q", code, stderr.String())
+	}
+	if got := strings.TrimSpace(stdout.String()); got != v7 {
+		t.Errorf("v7 -> v6 -> v7 = %s, want %s", got, v7)
+	}
+}
@@ -0,0 +1,74 @@
+// Command guuid generates, inspects and benchmarks UUIDs from the command line.
+//
+// Usage:
+//
+//	guuid <command> [flags]
+//
+// Commands:
+//
+//	new      generate UUIDv7s as text, CSV or NDJSON
+//	validate check UUIDs read from files or stdin, one per line
+//	convert  convert UUIDs between encodings and time-based versions
+//	uuidgen  generate UUIDs using util-linux uuidgen flags
+//	bench    measure generation, parsing and encoding throughput
+//
+// When the binary is installed or linked under the name uuidgen, it behaves as
+// "guuid uuidgen" so it can replace the system tool in existing scripts.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// command is a guuid subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// commands lists the available subcommands in help order
+var commands = []command{
+	{"new", "generate UUIDv7s as text, CSV or NDJSON", runNew},
+	{"validate", "check UUIDs read from files or stdin, one per line", runValidate},
+	{"convert", "convert UUIDs between encodings and time-based versions", runConvert},
+	{"uuidgen", "generate UUIDs using util-linux uuidgen flags", runUUIDGen},
+	{"bench", "measure generation, parsing and encoding throughput", runBench},
+}
+
+func main() {
+	if name := filepath.Base(os.Args[0]); strings.TrimSuffix(name, ".exe") == "uuidgen" {
+		os.Exit(runUUIDGen(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run dispatches args to a subcommand and returns the process exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		return 2
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], stdin, stdout, stderr)
+		}
+	}
+	fmt.Fprintf(stderr, "guuid: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+// usage prints the top-level help text
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: guuid <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("run() with no args = %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), "Commands:") {
+		t.Errorf("usage output missing command list: %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if code := run([]string{"nope"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("run() with unknown command = %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), `unknown command "nope"`) {
+		t.Errorf("unexpected error output: %q", stderr.String())
+	}
+}
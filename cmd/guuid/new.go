@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// timestampLayout is the RFC 3339 layout used for timestamp columns
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// runNew implements "guuid new"
+func runNew(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	n := fs.Int("n", 1, "number of UUIDs to generate")
+	format := fs.String("format", "text", "output format: text, csv or ndjson")
+	withTime := fs.Bool("timestamp", false, "add the timestamp embedded in each UUID (csv and ndjson)")
+	out := fs.String("o", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *n < 0 {
+		fmt.Fprintln(stderr, "guuid new: -n must not be negative")
+		return 2
+	}
+
+	var write func(w *bufio.Writer, id guuid.UUID)
+	switch *format {
+	case "text":
+		write = func(w *bufio.Writer, id guuid.UUID) {
+			w.WriteString(id.String())
+			w.WriteByte('\n')
+		}
+	case "csv":
+		write = func(w *bufio.Writer, id guuid.UUID) {
+			w.WriteString(id.String())
+			if *withTime {
+				w.WriteByte(',')
+				w.WriteString(id.Time().UTC().Format(timestampLayout))
+			}
+			w.WriteByte('\n')
+		}
+	case "ndjson":
+		write = func(w *bufio.Writer, id guuid.UUID) {
+			w.WriteString(`{"id":"`)
+			w.WriteString(id.String())
+			if *withTime {
+				w.WriteString(`","timestamp":"`)
+				w.WriteString(id.Time().UTC().Format(timestampLayout))
+			}
+			w.WriteString("\"}\n")
+		}
+	default:
+		fmt.Fprintf(stderr, "guuid new: unknown format %q\n", *format)
+		return 2
+	}
+
+	dst := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(stderr, "guuid new: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		dst = f
+	}
+
+	w := bufio.NewWriterSize(dst, 64<<10)
+	if *format == "csv" {
+		if *withTime {
+			w.WriteString("id,timestamp\n")
+		} else {
+			w.WriteString("id\n")
+		}
+	}
+
+	gen := guuid.NewGenerator()
+	for i := 0; i < *n; i++ {
+		id, err := gen.New()
+		if err != nil {
+			fmt.Fprintf(stderr, "guuid new: %v\n", err)
+			return 1
+		}
+		write(w, id)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "guuid new: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestRunNew_Formats(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		check func(t *testing.T, out string)
+	}{
+		{"text", []string{"-n", "3"}, func(t *testing.T, out string) {
+			lines := strings.Split(strings.TrimSpace(out), "\n")
+			if len(lines) != 3 {
+				t.Fatalf("got %d lines, want 3", len(lines))
+			}
+			for _, l := range lines {
+				if _, err := guuid.Parse(l); err != nil {
+					t.Errorf("Parse(%q) error = %v", l, err)
+				}
+			}
+		}},
+		{"csv with timestamp", []string{"-n", "3", "-format", "csv", "-timestamp"}, func(t *testing.T, out string) {
+			records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(records) != 4 || records[0][0] != "id" || records[0][1] != "timestamp" {
+				t.Fatalf("unexpected CSV: %q", records)
+			}
+			for _, r := range records[1:] {
+				id := guuid.MustParse(r[0])
+				ts, err := time.Parse(time.RFC3339, r[1])
+				if err != nil {
+					t.Fatalf("time.Parse(%q) error = %v", r[1], err)
+				}
+				if !ts.Equal(id.Time()) {
+					t.Errorf("timestamp = %v, want %v", ts, id.Time())
+				}
+			}
+		}},
+		{"ndjson", []string{"-n", "2", "-format", "ndjson"}, func(t *testing.T, out string) {
+			for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+				var rec map[string]string
+				if err := json.Unmarshal([]byte(l), &rec); err != nil {
+					t.Fatalf("Unmarshal(%q) error = %v", l, err)
+				}
+				if _, ok := rec["timestamp"]; ok {
+					t.Errorf("unexpected timestamp field in %q", l)
+				}
+				if _, err := guuid.Parse(rec["id"]); err != nil {
+					t.Errorf("Parse(%q) error = %v", rec["id"], err)
+				}
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runNew(tt.args, nil, &stdout, &stderr); code != 0 {
+				t.Fatalf("runNew() = %d, stderr %q", code, stderr.String())
+			}
+			tt.check(t, stdout.String())
+		})
+	}
+}
+
+func TestRunNew_OutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.csv")
+	var stdout, stderr bytes.Buffer
+	if code := runNew([]string{"-n", "1000", "-format", "csv", "-o", path}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("runNew() = %d, stderr %q", code, stderr.String())
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want empty", stdout.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := strings.Count(string(data), "\n"); got != 1001 {
+		t.Errorf("file has %d lines, want 1001", got)
+	}
+}
+
+func TestRunNew_BadFlags(t *testing.T) {
+	for _, args := range [][]string{{"-format", "xml"}, {"-n", "-1"}} {
+		var stdout, stderr bytes.Buffer
+		if code := runNew(args, nil, &stdout, &stderr); code != 2 {
+			t.Errorf("runNew(%q) = %d, want 2", args, code)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// uuidgenNamespaces are the predefined namespaces accepted by -n
+var uuidgenNamespaces = map[string]guuid.UUID{
+	"@dns":  guuid.NamespaceDNS,
+	"@url":  guuid.NamespaceURL,
+	"@oid":  guuid.NamespaceOID,
+	"@x500": guuid.NamespaceX500,
+}
+
+// runUUIDGen implements "guuid uuidgen", which accepts the util-linux uuidgen
+// flags. It also runs when the binary is invoked under the name uuidgen.
+// Unlike uuidgen, the default is a UUIDv7 rather than a random UUIDv4.
+func runUUIDGen(args []string, _ io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("uuidgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var (
+		random, timeV1, timeV7, useMD5, useSHA1, nameHex bool
+		namespace, name                                  string
+		count                                            int
+	)
+	for _, f := range []struct {
+		p           *bool
+		short, long string
+		usage       string
+	}{
+		{&random, "r", "random", "generate a random UUID (v4)"},
+		{&timeV1, "t", "time", "generate a time-based UUID (v1)"},
+		{&timeV7, "7", "time-v7", "generate a time-sorted UUID (v7, the default)"},
+		{&useMD5, "m", "md5", "generate a name-based MD5 UUID (v3)"},
+		{&useSHA1, "s", "sha1", "generate a name-based SHA-1 UUID (v5)"},
+		{&nameHex, "x", "hex", "interpret the -N name as a hex string"},
+	} {
+		fs.BoolVar(f.p, f.short, false, f.usage)
+		fs.BoolVar(f.p, f.long, false, "alias for -"+f.short)
+	}
+	fs.StringVar(&namespace, "n", "", "namespace UUID or @dns, @url, @oid, @x500 (with -m or -s)")
+	fs.StringVar(&namespace, "namespace", "", "alias for -n")
+	fs.StringVar(&name, "N", "", "name to hash (with -m or -s)")
+	fs.StringVar(&name, "name", "", "alias for -N")
+	fs.IntVar(&count, "C", 1, "number of UUIDs to generate")
+	fs.IntVar(&count, "count", 1, "alias for -C")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "uuidgen: unexpected argument %q\n", fs.Arg(0))
+		return 2
+	}
+
+	modes := 0
+	for _, set := range []bool{random, timeV1, timeV7, useMD5, useSHA1} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		fmt.Fprintln(stderr, "uuidgen: only one of -r, -t, -7, -m and -s may be given")
+		return 2
+	}
+
+	var gen func() (guuid.UUID, error)
+	switch {
+	case useMD5 || useSHA1:
+		if namespace == "" || name == "" {
+			fmt.Fprintln(stderr, "uuidgen: -m and -s require -n and -N")
+			return 2
+		}
+		ns, ok := uuidgenNamespaces[namespace]
+		if !ok {
+			var err error
+			if ns, err = guuid.Parse(namespace); err != nil {
+				fmt.Fprintf(stderr, "uuidgen: invalid namespace %q\n", namespace)
+				return 2
+			}
+		}
+		data := []byte(name)
+		if nameHex {
+			var err error
+			if data, err = hex.DecodeString(name); err != nil {
+				fmt.Fprintf(stderr, "uuidgen: invalid hex name %q\n", name)
+				return 2
+			}
+		}
+		id := guuid.NewV3(ns, data)
+		if useSHA1 {
+			id = guuid.NewV5(ns, data)
+		}
+		gen = func() (guuid.UUID, error) { return id, nil }
+	case random:
+		gen = newV4
+	case timeV1:
+		gen = func() (guuid.UUID, error) {
+			id, err := guuid.New()
+			if err != nil {
+				return id, err
+			}
+			return guuid.V7ToV1(id)
+		}
+	default:
+		gen = guuid.New
+	}
+
+	for i := 0; i < count; i++ {
+		id, err := gen()
+		if err != nil {
+			fmt.Fprintf(stderr, "uuidgen: %v\n", err)
+			return 1
+		}
+		fmt.Fprintln(stdout, id)
+	}
+	return 0
+}
+
+// newV4 returns a random UUIDv4
+func newV4() (guuid.UUID, error) {
+	var id guuid.UUID
+	if _, err := rand.Read(id[:]); err != nil {
+		return guuid.Nil, err
+	}
+	id.SetVersion(guuid.VersionRandom)
+	id.SetVariant(guuid.VariantRFC4122)
+	return id, nil
+}
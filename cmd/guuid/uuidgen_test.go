@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestRunUUIDGen(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantVersion guuid.Version
+		wantOut     string
+	}{
+		{"default", nil, guuid.VersionTimeSorted, ""},
+		{"random", []string{"-r"}, guuid.VersionRandom, ""},
+		{"random long", []string{"--random"}, guuid.VersionRandom, ""},
+		{"time", []string{"-t"}, guuid.VersionTimeBased, ""},
+		{"v7", []string{"-7"}, guuid.VersionTimeSorted, ""},
+		// Values match util-linux uuidgen and Python's uuid module
+		{"md5 dns", []string{"-m", "-n", "@dns", "-N", "python.org"}, guuid.VersionNameBasedMD5, "6fa459ea-ee8a-3ca4-894e-db77e160355e"},
+		{"sha1 dns", []string{"--sha1", "--namespace", "@dns", "--name", "python.org"}, guuid.VersionNameBasedSHA1, "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{"sha1 hex name", []string{"-s", "-n", "@dns", "-N", "707974686f6e2e6f7267", "-x"}, guuid.VersionNameBasedSHA1, "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runUUIDGen(tt.args, nil, &stdout, &stderr); code != 0 {
+				t.Fatalf("runUUIDGen() = %d, stderr %q", code, stderr.String())
+			}
+			out := strings.TrimSpace(stdout.String())
+			id, err := guuid.Parse(out)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", out, err)
+			}
+			if id.Version() != tt.wantVersion || id.Variant() != guuid.VariantRFC4122 {
+				t.Errorf("version/variant = %v/%v, want %v/RFC 4122", id.Version(), id.Variant(), tt.wantVersion)
+			}
+			if tt.wantOut != "" && out != tt.wantOut {
+				t.Errorf("output = %s, want %s", out, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestRunUUIDGen_Count(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runUUIDGen([]string{"-r", "-C", "5"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("runUUIDGen() = %d, stderr %q", code, stderr.String())
+	}
+	if got := strings.Count(stdout.String(), "\n"); got != 5 {
+		t.Errorf("got %d lines, want 5", got)
+	}
+}
+
+func TestRunUUIDGen_BadFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"-r", "-t"},
+		{"-m", "-N", "x"},
+		{"-s", "-n", "nope", "-N", "x"},
+		{"-s", "-n", "@dns", "-N", "zz", "-x"},
+		{"extra"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := runUUIDGen(args, nil, &stdout, &stderr); code != 2 {
+			t.Errorf("runUUIDGen(%q) = %d, want 2", args, code)
+		}
+	}
+}
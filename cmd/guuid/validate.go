@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// runValidate implements "guuid validate"
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	version := fs.Int("version", 0, "require this UUID version (0 accepts any)")
+	maxSkew := fs.Duration("max-skew", 0, "require UUIDv7s within this distance of the current time")
+	quiet := fs.Bool("q", false, "print only the summary")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: guuid validate [flags] [file ...]")
+		fmt.Fprintln(stderr, "Reads one UUID per line from the files, or stdin if none are given.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	v := validator{version: *version, maxSkew: *maxSkew, quiet: *quiet, out: stdout}
+	if fs.NArg() == 0 {
+		if err := v.check("<stdin>", stdin); err != nil {
+			fmt.Fprintf(stderr, "guuid validate: %v\n", err)
+			return 2
+		}
+	}
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(stderr, "guuid validate: %v\n", err)
+			return 2
+		}
+		err = v.check(name, f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(stderr, "guuid validate: %s: %v\n", name, err)
+			return 2
+		}
+	}
+
+	fmt.Fprintf(stderr, "%d lines checked, %d invalid\n", v.total, v.invalid)
+	if v.invalid > 0 {
+		return 1
+	}
+	return 0
+}
+
+// validator accumulates results across input files
+type validator struct {
+	version int
+	maxSkew time.Duration
+	quiet   bool
+	out     io.Writer
+
+	total, invalid int
+}
+
+// check validates every non-blank line read from r
+func (v *validator) check(name string, r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+		v.total++
+		if reason := v.reason(text); reason != "" {
+			v.invalid++
+			if !v.quiet {
+				fmt.Fprintf(v.out, "%s:%d: %s: %q\n", name, line, reason, text)
+			}
+		}
+	}
+	return sc.Err()
+}
+
+// reason explains why s fails validation, or returns "" if it passes
+func (v *validator) reason(s string) string {
+	u, err := guuid.Parse(s)
+	if err != nil {
+		return formatReason(s)
+	}
+	if v.version != 0 && int(u.Version()) != v.version {
+		return fmt.Sprintf("version %d, want %d", u.Version(), v.version)
+	}
+	if v.maxSkew > 0 {
+		switch err := guuid.ValidateV7(u, v.maxSkew); err {
+		case nil:
+		case guuid.ErrInvalidVersion:
+			return fmt.Sprintf("version %d, want 7", u.Version())
+		case guuid.ErrInvalidVariant:
+			return "variant " + u.Variant().String() + ", want RFC 4122"
+		default:
+			return "timestamp " + u.Time().UTC().Format(timestampLayout) + " outside allowed skew"
+		}
+	}
+	return ""
+}
+
+// formatReason describes why Parse rejected s
+func formatReason(s string) string {
+	body := strings.TrimPrefix(s, "urn:uuid:")
+	body = strings.TrimPrefix(body, "{")
+	offset := len(s) - len(body)
+	body = strings.TrimSuffix(body, "}")
+
+	switch len(body) {
+	case 36:
+		for _, i := range []int{8, 13, 18, 23} {
+			if body[i] != '-' {
+				return fmt.Sprintf("expected '-' at column %d", offset+i+1)
+			}
+		}
+	case 32:
+	default:
+		return fmt.Sprintf("length %d, want 32 or 36 hex digits and hyphens", len(body))
+	}
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == '-' && len(body) == 36 {
+			continue
+		}
+		if !isHex(c) {
+			return fmt.Sprintf("invalid character %q at column %d", c, offset+i+1)
+		}
+	}
+	return "invalid format"
+}
+
+// isHex reports whether c is a hexadecimal digit
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestRunValidate(t *testing.T) {
+	v7 := guuid.Must(guuid.New()).String()
+	v4 := "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		wantCode int
+		wantOut  []string
+	}{
+		{"all valid", nil, v7 + "\n\n" + v4 + "\n", 0, nil},
+		{"bad lines", nil, v7 + "\nnot-a-uuid\n" + strings.Replace(v7, "-", "x", 1) + "\n", 1, []string{
+			"<stdin>:2: length 10",
+			"<stdin>:3: expected '-' at column 9",
+		}},
+		{"non-hex", nil, "550e8400-e29b-41d4-a716-44665544000g\n", 1, []string{
+			`<stdin>:1: invalid character 'g' at column 36`,
+		}},
+		{"version", []string{"-version", "7"}, v7 + "\n" + v4 + "\n", 1, []string{
+			"<stdin>:2: version 4, want 7",
+		}},
+		{"skew", []string{"-max-skew", "1h"}, v7 + "\n017f22e2-79b0-7cc3-98c4-dc0c0c07398f\n", 1, []string{
+			"<stdin>:2: timestamp 2022-02-22T19:22:22.000Z outside allowed skew",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := runValidate(tt.args, strings.NewReader(tt.input), &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("runValidate() = %d, want %d (stdout %q)", code, tt.wantCode, stdout.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output %q missing %q", stdout.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunValidate_Files(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.txt")
+	bad := filepath.Join(dir, "bad.txt")
+	os.WriteFile(good, []byte(guuid.Must(guuid.New()).String()+"\n"), 0o644)
+	os.WriteFile(bad, []byte("zzz\n"), 0o644)
+
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"-q", good, bad}, nil, &stdout, &stderr); code != 1 {
+		t.Errorf("runValidate() = %d, want 1", code)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("quiet output = %q, want empty", stdout.String())
+	}
+	if got := stderr.String(); !strings.Contains(got, "2 lines checked, 1 invalid") {
+		t.Errorf("summary = %q", got)
+	}
+
+	if code := runValidate([]string{filepath.Join(dir, "missing")}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("runValidate() on missing file = %d, want 2", code)
+	}
+}
@@ -0,0 +1,102 @@
+// Command guuidd serves UUIDv7s over HTTP. See package
+// github.com/Lzww0608/guuid/guuidd for the endpoints.
+//
+// Usage:
+//
+//	guuidd -addr :8080 [-keys keys.txt] [-quota 1000 -burst 5000]
+//
+// On SIGTERM or SIGINT the server fails /readyz, waits -drain-delay for load
+// balancers to stop routing to it, then lets in-flight requests finish for up
+// to -grace before exiting.
+//
+// The keys file holds one "client key" pair per line; blank lines and lines
+// starting with # are ignored.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+	"github.com/Lzww0608/guuid/guuidd"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	maxBatch := flag.Int("max-batch", guuidd.DefaultMaxBatch, "largest batch size accepted")
+	keysFile := flag.String("keys", "", "file of \"client key\" lines; enables API-key authentication")
+	rate := flag.Float64("quota", 0, "IDs per second allowed per client (0 for unlimited)")
+	burst := flag.Int("burst", 0, "IDs a client may draw at once (default: one second of -quota)")
+	drainDelay := flag.Duration("drain-delay", 5*time.Second, "time between failing /readyz and closing the listener on shutdown")
+	grace := flag.Duration("grace", 30*time.Second, "time allowed for in-flight requests to finish on shutdown")
+	flag.Parse()
+
+	opts := []guuidd.Option{
+		guuidd.WithGenerator(guuid.NewGenerator()),
+		guuidd.WithMaxBatch(*maxBatch),
+	}
+	if *keysFile != "" {
+		keys, err := loadKeys(*keysFile)
+		if err != nil {
+			log.Fatalf("guuidd: %v", err)
+		}
+		opts = append(opts, guuidd.WithAPIKeys(keys))
+	}
+	if *rate > 0 {
+		opts = append(opts, guuidd.WithQuota(guuidd.Quota{Rate: *rate, Burst: *burst}))
+	}
+
+	srv := guuidd.New(opts...)
+	hs := &http.Server{Addr: *addr, Handler: srv}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	done := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		log.Printf("guuidd draining")
+		done <- guuidd.Shutdown(hs, srv, *drainDelay, *grace)
+	}()
+
+	log.Printf("guuidd listening on %s", *addr)
+	if err := hs.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("guuidd: %v", err)
+	}
+	if err := <-done; err != nil {
+		log.Fatalf("guuidd: shutdown: %v", err)
+	}
+}
+
+// loadKeys reads API keys from a file of "client key" lines
+func loadKeys(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := make(map[string]string)
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: want \"client key\"", path, line)
+		}
+		keys[fields[1]] = fields[0]
+	}
+	return keys, sc.Err()
+}
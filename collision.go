@@ -0,0 +1,32 @@
+package guuid
+
+import (
+	"math"
+	"time"
+)
+
+// randomBitsV7 is the number of random bits in a UUIDv7 (12-bit rand_a plus 62-bit rand_b)
+const randomBitsV7 = 74
+
+// CollisionProbability estimates the probability that at least two UUIDv7s
+// collide when perMs IDs are generated independently in every millisecond
+// for the given duration.
+//
+// Only IDs sharing a millisecond can collide, so the birthday bound is applied
+// per millisecond over the 74 random bits and then compounded across all
+// milliseconds in duration:
+//
+//	p = 1 - exp(-ms * n*(n-1) / 2^75)
+//
+// This models independent generators (e.g. separate processes). A single
+// Generator never produces duplicates within a millisecond because rand_a is
+// used as a monotonic counter, so the result is an upper bound for that case.
+func CollisionProbability(perMs int, duration time.Duration) float64 {
+	if perMs < 2 || duration <= 0 {
+		return 0
+	}
+	n := float64(perMs)
+	ms := math.Ceil(float64(duration) / float64(time.Millisecond))
+	exponent := ms * n * (n - 1) / math.Ldexp(1, randomBitsV7+1)
+	return -math.Expm1(-exponent)
+}
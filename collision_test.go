@@ -0,0 +1,45 @@
+package guuid
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCollisionProbability(t *testing.T) {
+	tests := []struct {
+		name     string
+		perMs    int
+		duration time.Duration
+		want     float64
+	}{
+		{"single id per ms", 1, time.Hour, 0},
+		{"zero duration", 1000, 0, 0},
+		// one millisecond with two IDs: 2*1 / 2^75
+		{"two ids one ms", 2, time.Millisecond, 2 / math.Ldexp(1, 75)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CollisionProbability(tt.perMs, tt.duration)
+			if math.Abs(got-tt.want) > tt.want*1e-9 {
+				t.Errorf("CollisionProbability() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollisionProbability_Monotonic(t *testing.T) {
+	low := CollisionProbability(1000, time.Hour)
+	high := CollisionProbability(1000, 24*365*time.Hour)
+	if low <= 0 || high <= low {
+		t.Errorf("CollisionProbability() not increasing with duration: %g, %g", low, high)
+	}
+
+	// 2^30 IDs per ms for 2^20 ms gives an exponent of 2^20*2^60/2^75 = 32;
+	// perMs stays within a 32-bit int
+	saturated := CollisionProbability(1<<30, (1<<20)*time.Millisecond)
+	if saturated < 0.99 || saturated > 1 {
+		t.Errorf("CollisionProbability() = %g, want close to 1", saturated)
+	}
+}
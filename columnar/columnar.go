@@ -0,0 +1,83 @@
+// Package columnar converts UUIDs to and from the 16-byte fixed-width column
+// layout shared by Parquet (FIXED_LEN_BYTE_ARRAY(16) with the UUID logical
+// type) and Arrow (FixedSizeBinary(16), or the canonical arrow.uuid extension).
+// Storing raw bytes keeps columns a third the size of canonical strings, and
+// because both formats compare fixed-width values as unsigned bytes, UUIDv7
+// column statistics and sort orders follow creation time.
+//
+// The package has no dependency on an Arrow or Parquet library. With
+// arrow-go, the arrow.uuid extension type is registered by its extensions
+// package, and values move across as [16]byte:
+//
+//	b := extensions.NewUUIDBuilder(mem)
+//	for _, id := range ids {
+//		b.AppendBytes(id)
+//	}
+//
+// For FixedSizeBinary builders and Parquet writers that take [][]byte, use
+// FixedLenValues; for raw data buffers, AppendContiguous.
+package columnar
+
+import (
+	"errors"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// Width is the byte width of a UUID column value
+const Width = 16
+
+// Type names for schemas that declare UUID columns
+const (
+	ArrowExtensionName = "arrow.uuid"
+	ParquetLogicalType = "UUID"
+)
+
+// ErrInvalidWidth indicates a column value or buffer that is not a whole
+// number of 16-byte UUIDs
+var ErrInvalidWidth = errors.New("columnar: value width is not 16 bytes")
+
+// FixedLenValues returns one 16-byte slice per UUID, all backed by a single
+// allocation, for Parquet FixedLenByteArray columns and Arrow
+// FixedSizeBinary builders.
+func FixedLenValues(ids []guuid.UUID) [][]byte {
+	buf := AppendContiguous(make([]byte, 0, len(ids)*Width), ids)
+	vals := make([][]byte, len(ids))
+	for i := range vals {
+		vals[i] = buf[i*Width : (i+1)*Width : (i+1)*Width]
+	}
+	return vals
+}
+
+// FromFixedLenValues converts 16-byte column values back into UUIDs
+func FromFixedLenValues(vals [][]byte) ([]guuid.UUID, error) {
+	ids := make([]guuid.UUID, len(vals))
+	for i, v := range vals {
+		if len(v) != Width {
+			return nil, ErrInvalidWidth
+		}
+		copy(ids[i][:], v)
+	}
+	return ids, nil
+}
+
+// AppendContiguous appends the UUIDs back to back to dst, which is the data
+// buffer layout of an Arrow FixedSizeBinary(16) array
+func AppendContiguous(dst []byte, ids []guuid.UUID) []byte {
+	for _, id := range ids {
+		dst = append(dst, id[:]...)
+	}
+	return dst
+}
+
+// FromContiguous splits a buffer of back-to-back 16-byte values into UUIDs
+func FromContiguous(buf []byte) ([]guuid.UUID, error) {
+	if len(buf)%Width != 0 {
+		return nil, ErrInvalidWidth
+	}
+	ids := make([]guuid.UUID, len(buf)/Width)
+	for i := range ids {
+		copy(ids[i][:], buf[i*Width:])
+	}
+	return ids, nil
+}
@@ -0,0 +1,77 @@
+package columnar
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func newIDs(n int) []guuid.UUID {
+	gen := guuid.NewGenerator()
+	ids := make([]guuid.UUID, n)
+	for i := range ids {
+		ids[i] = guuid.Must(gen.New())
+	}
+	return ids
+}
+
+func TestFixedLenValues_RoundTrip(t *testing.T) {
+	ids := newIDs(50)
+	vals := FixedLenValues(ids)
+	if len(vals) != len(ids) {
+		t.Fatalf("FixedLenValues() returned %d values, want %d", len(vals), len(ids))
+	}
+	for i, v := range vals {
+		if !bytes.Equal(v, ids[i][:]) {
+			t.Fatalf("value %d = %x, want %x", i, v, ids[i][:])
+		}
+		// Byte order must follow generation order for column statistics
+		if i > 0 && bytes.Compare(vals[i-1], v) >= 0 {
+			t.Fatalf("values not ordered at %d", i)
+		}
+	}
+
+	// Appending to one value must not clobber its neighbour
+	_ = append(vals[0], 0xFF)
+	if vals[1][0] != ids[1][0] {
+		t.Error("values share capacity")
+	}
+
+	back, err := FromFixedLenValues(vals)
+	if err != nil {
+		t.Fatalf("FromFixedLenValues() error = %v", err)
+	}
+	for i := range ids {
+		if back[i] != ids[i] {
+			t.Errorf("round trip %d = %v, want %v", i, back[i], ids[i])
+		}
+	}
+
+	if _, err := FromFixedLenValues([][]byte{make([]byte, 15)}); !errors.Is(err, ErrInvalidWidth) {
+		t.Errorf("FromFixedLenValues(short) error = %v, want %v", err, ErrInvalidWidth)
+	}
+}
+
+func TestContiguous_RoundTrip(t *testing.T) {
+	ids := newIDs(10)
+	buf := AppendContiguous([]byte{1, 2}, ids)
+	if len(buf) != 2+10*Width {
+		t.Fatalf("AppendContiguous() len = %d, want %d", len(buf), 2+10*Width)
+	}
+
+	back, err := FromContiguous(buf[2:])
+	if err != nil {
+		t.Fatalf("FromContiguous() error = %v", err)
+	}
+	for i := range ids {
+		if back[i] != ids[i] {
+			t.Errorf("round trip %d = %v, want %v", i, back[i], ids[i])
+		}
+	}
+
+	if _, err := FromContiguous(buf[1:]); !errors.Is(err, ErrInvalidWidth) {
+		t.Errorf("FromContiguous(misaligned) error = %v, want %v", err, ErrInvalidWidth)
+	}
+}
@@ -0,0 +1,110 @@
+package guuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config holds the generator settings that can be changed on a live
+// generator with Reload. Each field matches the option of the same name.
+// In JSON, as in a Snapshot, durations are encoded in nanoseconds.
+type Config struct {
+	TimestampDither      time.Duration `json:"timestamp_dither"`      // WithTimestampDither window, 0 for none
+	TimestampGranularity time.Duration `json:"timestamp_granularity"` // WithTimestampGranularity period, 0 for none
+
+	OverflowSleep      bool          `json:"overflow_sleep"`       // WithOverflowSleep enabled
+	OverflowSleepLimit time.Duration `json:"overflow_sleep_limit"` // WithOverflowSleep bound, 0 for DefaultOverflowSleepLimit
+
+	RateLimit float64 `json:"rate_limit"` // WithRateLimit IDs per second, 0 for none
+	RateBurst int     `json:"rate_burst"` // WithRateLimit burst
+
+	SmearWindow time.Duration `json:"smear_window"` // WithSmearWindow window, 0 for none
+}
+
+// WithRateLimit makes the generator issue at most rate IDs per second on
+// average with bursts of up to burst IDs; callers over the limit block until
+// they are allowed. A rate of zero or less disables limiting.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(g *Generator) {
+		g.setRateLimit(rate, burst)
+	}
+}
+
+// Config returns the generator's current reloadable settings
+func (g *Generator) Config() Config {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.config()
+}
+
+// config implements Config. It must be called with g.mu held.
+func (g *Generator) config() Config {
+	cfg := Config{
+		TimestampDither:      time.Duration(g.ditherMs) * time.Millisecond,
+		TimestampGranularity: time.Duration(g.granularityMs) * time.Millisecond,
+		OverflowSleep:        g.overflowSleep,
+		OverflowSleepLimit:   g.overflowSleepMax,
+		SmearWindow:          time.Duration(g.smearMs) * time.Millisecond,
+	}
+	if l := g.limiter.Load(); l != nil {
+		cfg.RateLimit, cfg.RateBurst = l.rate, int(l.burst)
+	}
+	return cfg
+}
+
+// Reload atomically replaces the generator's reloadable settings with cfg, so
+// configuration changes can roll out without restarting. IDs generated after
+// Reload returns use the new settings; monotonic state is kept, so IDs stay
+// ordered across the change. A rate limit change starts with a full burst.
+// Returns ErrInvalidConfig for negative values, leaving the settings unchanged.
+func (g *Generator) Reload(cfg Config) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.applyConfig(cfg)
+	return nil
+}
+
+// applyConfig installs the settings of a validated cfg. It must be called
+// with g.mu held.
+func (g *Generator) applyConfig(cfg Config) {
+	g.ditherMs = cfg.TimestampDither.Milliseconds()
+	g.granularityMs = cfg.TimestampGranularity.Milliseconds()
+	g.overflowSleep = cfg.OverflowSleep
+	g.overflowSleepMax = cfg.OverflowSleepLimit
+	if g.smearMs <= 0 && cfg.SmearWindow.Milliseconds() > 0 {
+		// The last ID's rand_b holds no extension to count on from
+		g.smearSeq = smearSeqMax
+	}
+	g.smearMs = cfg.SmearWindow.Milliseconds()
+	g.setRateLimit(cfg.RateLimit, cfg.RateBurst)
+}
+
+// validate rejects negative settings
+func (c Config) validate() error {
+	switch {
+	case c.TimestampDither < 0:
+		return fmt.Errorf("%w: negative TimestampDither %v", ErrInvalidConfig, c.TimestampDither)
+	case c.TimestampGranularity < 0:
+		return fmt.Errorf("%w: negative TimestampGranularity %v", ErrInvalidConfig, c.TimestampGranularity)
+	case c.OverflowSleepLimit < 0:
+		return fmt.Errorf("%w: negative OverflowSleepLimit %v", ErrInvalidConfig, c.OverflowSleepLimit)
+	case c.SmearWindow < 0:
+		return fmt.Errorf("%w: negative SmearWindow %v", ErrInvalidConfig, c.SmearWindow)
+	case c.RateLimit < 0 || c.RateBurst < 0:
+		return fmt.Errorf("%w: negative rate limit %v/%d", ErrInvalidConfig, c.RateLimit, c.RateBurst)
+	}
+	return nil
+}
+
+// setRateLimit installs a new rate limiter, or removes it for rate <= 0
+func (g *Generator) setRateLimit(rate float64, burst int) {
+	if rate <= 0 {
+		g.limiter.Store(nil)
+		return
+	}
+	g.limiter.Store(newTokenBucket(rate, burst))
+}
@@ -0,0 +1,55 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerator_Reload(t *testing.T) {
+	gen := NewGenerator(WithTimestampGranularity(time.Second), WithRateLimit(1000, 10))
+	want := Config{TimestampGranularity: time.Second, RateLimit: 1000, RateBurst: 10}
+	if got := gen.Config(); got != want {
+		t.Errorf("Config() = %+v, want %+v", got, want)
+	}
+
+	before := Must(gen.New())
+	want = Config{TimestampGranularity: time.Minute, OverflowSleep: true, OverflowSleepLimit: time.Millisecond, SmearWindow: time.Second}
+	if err := gen.Reload(want); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := gen.Config(); got != want {
+		t.Errorf("Config() after Reload = %+v, want %+v", got, want)
+	}
+	if gen.limiter.Load() != nil {
+		t.Error("Reload() without RateLimit kept the rate limiter")
+	}
+
+	after := Must(gen.New())
+	if after.Compare(before) <= 0 {
+		t.Errorf("New() after Reload = %v, not after %v", after, before)
+	}
+	if ms := after.Timestamp(); ms%60000 != 0 && ms != before.Timestamp() {
+		t.Errorf("Timestamp() = %d, want minute granularity or the previous timestamp", ms)
+	}
+}
+
+func TestGenerator_ReloadInvalid(t *testing.T) {
+	tests := []Config{
+		{TimestampDither: -time.Second},
+		{TimestampGranularity: -1},
+		{OverflowSleepLimit: -1},
+		{RateLimit: -1},
+		{RateBurst: -1},
+		{SmearWindow: -time.Second},
+	}
+	gen := NewGenerator(WithTimestampDither(time.Second))
+	for _, cfg := range tests {
+		if err := gen.Reload(cfg); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("Reload(%+v) error = %v, want ErrInvalidConfig", cfg, err)
+		}
+	}
+	if got := gen.Config().TimestampDither; got != time.Second {
+		t.Errorf("invalid Reload changed TimestampDither to %v", got)
+	}
+}
@@ -0,0 +1,125 @@
+// Package conformance provides an RFC 9562 UUIDv7 conformance suite that
+// downstream wrappers and forks can run against their own generators.
+//
+// The suite only depends on the 16-byte layout, so it works with any type
+// whose underlying type is [16]byte:
+//
+//	func TestConformance(t *testing.T) {
+//	    conformance.Run(t, func() conformance.GenerateFunc {
+//	        gen := guuid.NewGenerator()
+//	        return func(ts time.Time) ([16]byte, error) {
+//	            return gen.NewWithTime(ts)
+//	        }
+//	    })
+//	}
+package conformance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// GenerateFunc produces a UUIDv7 for the given time
+type GenerateFunc func(t time.Time) ([16]byte, error)
+
+// Factory returns a GenerateFunc backed by a new generator with fresh state
+type Factory func() GenerateFunc
+
+// Vector is a known timestamp and the 48-bit unix_ts_ms field it must produce
+type Vector struct {
+	Name      string
+	Time      time.Time
+	Timestamp uint64 // expected unix_ts_ms field
+}
+
+// Vectors are the layout test vectors checked by Run
+var Vectors = []Vector{
+	// RFC 9562 Appendix A.6: 017F22E2-79B0-7CC3-98C4-DC0C0C07398F
+	{"RFC 9562 A.6", time.UnixMilli(0x017F22E279B0), 0x017F22E279B0},
+	{"Unix epoch", time.Unix(0, 0), 0},
+	{"sub-millisecond truncation", time.Unix(1700000000, 999999), 1700000000000},
+	{"year 2038", time.Date(2038, 1, 19, 3, 14, 8, 0, time.UTC), 2147483648000},
+	{"max 48-bit", time.UnixMilli(1<<48 - 1), 1<<48 - 1},
+}
+
+// CheckLayout verifies that u is a UUIDv7 with the RFC 4122 variant whose
+// unix_ts_ms field equals ts
+func CheckLayout(u [16]byte, ts uint64) error {
+	if v := u[6] >> 4; v != 7 {
+		return fmt.Errorf("version = %d, want 7", v)
+	}
+	if u[8]&0xC0 != 0x80 {
+		return fmt.Errorf("variant bits = %02b, want 10", u[8]>>6)
+	}
+	if got := binary.BigEndian.Uint64(u[0:8]) >> 16; got != ts {
+		return fmt.Errorf("unix_ts_ms = %#x, want %#x", got, ts)
+	}
+	return nil
+}
+
+// Run executes the conformance suite as subtests of t. Each subtest obtains
+// a generator from newGen so that state never leaks between checks.
+func Run(t *testing.T, newGen Factory) {
+	t.Run("Layout", func(t *testing.T) {
+		for _, v := range Vectors {
+			u, err := newGen()(v.Time)
+			if err != nil {
+				t.Fatalf("%s: generate error = %v", v.Name, err)
+			}
+			if err := CheckLayout(u, v.Timestamp); err != nil {
+				t.Errorf("%s: %v", v.Name, err)
+			}
+		}
+	})
+
+	t.Run("SameMillisecondOrdering", func(t *testing.T) {
+		gen := newGen()
+		ts := time.Now()
+		var prev [16]byte
+		for i := 0; i < 10000; i++ {
+			u, err := gen(ts)
+			if err != nil {
+				t.Fatalf("generate error = %v", err)
+			}
+			if i > 0 && bytes.Compare(u[:], prev[:]) <= 0 {
+				t.Fatalf("ID %d not greater than previous: %x <= %x", i, u, prev)
+			}
+			prev = u
+		}
+	})
+
+	t.Run("ClockRollback", func(t *testing.T) {
+		gen := newGen()
+		ts := time.Now()
+		first, err := gen(ts)
+		if err != nil {
+			t.Fatalf("generate error = %v", err)
+		}
+		second, err := gen(ts.Add(-time.Second))
+		if err != nil {
+			t.Fatalf("generate error = %v", err)
+		}
+		if bytes.Compare(second[:], first[:]) <= 0 {
+			t.Errorf("ID after clock rollback not greater: %x <= %x", second, first)
+		}
+	})
+
+	t.Run("Uniqueness", func(t *testing.T) {
+		gen := newGen()
+		ts := time.Now()
+		seen := make(map[[16]byte]bool)
+		for i := 0; i < 10000; i++ {
+			u, err := gen(ts.Add(time.Duration(i%10) * time.Millisecond))
+			if err != nil {
+				t.Fatalf("generate error = %v", err)
+			}
+			if seen[u] {
+				t.Fatalf("duplicate ID %x", u)
+			}
+			seen[u] = true
+		}
+	})
+}
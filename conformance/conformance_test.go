@@ -0,0 +1,33 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestGenerator(t *testing.T) {
+	Run(t, func() GenerateFunc {
+		gen := guuid.NewGenerator()
+		return func(ts time.Time) ([16]byte, error) {
+			return gen.NewWithTime(ts)
+		}
+	})
+}
+
+func TestCheckLayout(t *testing.T) {
+	rfc := guuid.MustParse("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	if err := CheckLayout(rfc, 0x017F22E279B0); err != nil {
+		t.Errorf("CheckLayout(RFC 9562 vector) error = %v", err)
+	}
+	if err := CheckLayout(rfc, 0x017F22E279B1); err == nil {
+		t.Error("CheckLayout() accepted wrong timestamp")
+	}
+	if err := CheckLayout(guuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), 0); err == nil {
+		t.Error("CheckLayout() accepted UUIDv4")
+	}
+	if err := CheckLayout([16]byte{6: 0x70, 8: 0xC0}, 0); err == nil {
+		t.Error("CheckLayout() accepted non-RFC variant")
+	}
+}
@@ -0,0 +1,94 @@
+package guuid
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DedupeGenerator wraps a Generator and checks each issued UUID against a
+// sliding window of the most recent IDs. It is a debugging aid for validating
+// custom entropy sources or generator changes in staging, not for production
+// hot paths: every call takes an extra lock and a map lookup.
+type DedupeGenerator struct {
+	gen         *Generator
+	onDuplicate func(UUID)
+
+	dedupeWindow
+}
+
+// dedupeWindow remembers the most recent IDs in a ring buffer
+type dedupeWindow struct {
+	mu   sync.Mutex
+	ring []UUID
+	next int
+	seen map[UUID]int // UUID -> occurrences in the window
+}
+
+// newDedupeWindow returns a dedupeWindow holding up to size IDs
+func newDedupeWindow(size int) dedupeWindow {
+	if size < 1 {
+		size = 1
+	}
+	return dedupeWindow{
+		ring: make([]UUID, 0, size),
+		seen: make(map[UUID]int, size),
+	}
+}
+
+// NewDedupeGenerator returns a DedupeGenerator remembering the last window IDs.
+// onDuplicate is called for every duplicate found; if nil, a duplicate panics.
+func NewDedupeGenerator(gen *Generator, window int, onDuplicate func(UUID)) *DedupeGenerator {
+	return &DedupeGenerator{
+		gen:          gen,
+		onDuplicate:  onDuplicate,
+		dedupeWindow: newDedupeWindow(window),
+	}
+}
+
+// New generates a UUIDv7 and checks it for duplicates
+func (d *DedupeGenerator) New() (UUID, error) {
+	return d.NewWithTime(time.Now())
+}
+
+// NewWithTime generates a UUIDv7 for t and checks it for duplicates
+func (d *DedupeGenerator) NewWithTime(t time.Time) (UUID, error) {
+	uuid, err := d.gen.NewWithTime(t)
+	if err != nil {
+		return uuid, err
+	}
+	d.check(uuid, d.onDuplicate)
+	return uuid, nil
+}
+
+// check records u and reports it to onDuplicate, or panics if onDuplicate is
+// nil, when it is already in the window
+func (d *dedupeWindow) check(u UUID, onDuplicate func(UUID)) {
+	if !d.record(u) {
+		return
+	}
+	if onDuplicate == nil {
+		panic(fmt.Sprintf("guuid: duplicate UUID generated: %s", u))
+	}
+	onDuplicate(u)
+}
+
+// record adds u to the window and reports whether it was already present
+func (d *dedupeWindow) record(u UUID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dup := d.seen[u] > 0
+	if len(d.ring) < cap(d.ring) {
+		d.ring = append(d.ring, u)
+	} else {
+		evicted := d.ring[d.next]
+		if d.seen[evicted]--; d.seen[evicted] == 0 {
+			delete(d.seen, evicted)
+		}
+		d.ring[d.next] = u
+		d.next = (d.next + 1) % len(d.ring)
+	}
+	d.seen[u]++
+	return dup
+}
@@ -0,0 +1,48 @@
+package guuid
+
+import (
+	"testing"
+)
+
+func TestDedupeGenerator_NoDuplicates(t *testing.T) {
+	dups := 0
+	gen := NewDedupeGenerator(NewGenerator(), 100, func(UUID) { dups++ })
+	for i := 0; i < 1000; i++ {
+		if _, err := gen.New(); err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+	}
+	if dups != 0 {
+		t.Errorf("reported %d duplicates from a healthy generator", dups)
+	}
+	if len(gen.seen) != 100 {
+		t.Errorf("window holds %d IDs, want 100", len(gen.seen))
+	}
+}
+
+func TestDedupeGenerator_DetectsDuplicates(t *testing.T) {
+	var got []UUID
+	gen := NewDedupeGenerator(NewGeneratorWithReader(zeroReader{}), 10, func(u UUID) { got = append(got, u) })
+
+	first := Must(gen.New())
+	// Rewinding the inner generator state makes it reissue the same ID
+	gen.gen.lastTimestamp, gen.gen.clockSeq = 0, 0
+	Must(gen.NewWithTime(first.Time()))
+
+	if len(got) != 1 || got[0] != first {
+		t.Errorf("onDuplicate calls = %v, want [%v]", got, first)
+	}
+}
+
+func TestDedupeGenerator_Panics(t *testing.T) {
+	gen := NewDedupeGenerator(NewGeneratorWithReader(zeroReader{}), 10, nil)
+	first := Must(gen.New())
+	gen.gen.lastTimestamp, gen.gen.clockSeq = 0, 0
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewWithTime() did not panic on duplicate")
+		}
+	}()
+	_, _ = gen.NewWithTime(first.Time())
+}
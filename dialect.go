@@ -0,0 +1,117 @@
+package guuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Dialect selects how UUIDs are exchanged with a particular database driver
+// when its wire shapes differ from what UUID.Scan and UUID.Value assume
+type Dialect int
+
+const (
+	// DialectDefault behaves exactly like UUID.Scan and UUID.Value
+	DialectDefault Dialect = iota
+
+	// DialectPostgres also accepts bytea values in Postgres' hex output
+	// format (`\x` followed by 32 hex digits), as delivered by drivers that
+	// return bytea columns as text
+	DialectPostgres
+
+	// DialectMSSQL treats 16-byte values as SQL Server uniqueidentifiers,
+	// whose first three fields are little-endian, and writes them the same way
+	DialectMSSQL
+)
+
+// String returns the dialect name
+func (d Dialect) String() string {
+	switch d {
+	case DialectDefault:
+		return "default"
+	case DialectPostgres:
+		return "postgres"
+	case DialectMSSQL:
+		return "mssql"
+	}
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+// ScanAs returns a sql.Scanner that decodes into u using dialect d, for use
+// with database/sql:
+//
+//	var id guuid.UUID
+//	err := row.Scan(guuid.ScanAs(&id, guuid.DialectMSSQL))
+func ScanAs(u *UUID, d Dialect) sql.Scanner {
+	return dialectScanner{u, d}
+}
+
+// ValueAs returns a driver.Valuer that encodes u using dialect d
+func ValueAs(u UUID, d Dialect) driver.Valuer {
+	return dialectValuer{u, d}
+}
+
+// dialectScanner is the sql.Scanner returned by ScanAs
+type dialectScanner struct {
+	u *UUID
+	d Dialect
+}
+
+// Scan implements sql.Scanner
+func (s dialectScanner) Scan(src any) error {
+	switch s.d {
+	case DialectPostgres:
+		if text, ok := asText(src); ok && strings.HasPrefix(text, `\x`) {
+			id, err := Parse(text[2:])
+			if err != nil {
+				return err
+			}
+			*s.u = id
+			return nil
+		}
+	case DialectMSSQL:
+		if b, ok := src.([]byte); ok && len(b) == 16 {
+			*s.u = swapMSSQL([16]byte(b))
+			return nil
+		}
+	}
+	return s.u.Scan(src)
+}
+
+// dialectValuer is the driver.Valuer returned by ValueAs
+type dialectValuer struct {
+	u UUID
+	d Dialect
+}
+
+// Value implements driver.Valuer
+func (v dialectValuer) Value() (driver.Value, error) {
+	if v.d == DialectMSSQL {
+		b := swapMSSQL(v.u)
+		return b[:], nil
+	}
+	return v.u.Value()
+}
+
+// asText returns src as a string if it is textual
+func asText(src any) (string, bool) {
+	switch src := src.(type) {
+	case string:
+		return src, true
+	case []byte:
+		return string(src), true
+	}
+	return "", false
+}
+
+// swapMSSQL converts between RFC byte order and SQL Server's, which stores
+// the first three fields little-endian. The conversion is its own inverse.
+func swapMSSQL(b [16]byte) UUID {
+	return UUID{
+		b[3], b[2], b[1], b[0],
+		b[5], b[4],
+		b[7], b[6],
+		b[8], b[9], b[10], b[11], b[12], b[13], b[14], b[15],
+	}
+}
@@ -0,0 +1,62 @@
+package guuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestScanAs(t *testing.T) {
+	want := MustParse("6f9619ff-8b86-d011-b42d-00c04fc964ff")
+	mssqlBytes := []byte{0xff, 0x19, 0x96, 0x6f, 0x86, 0x8b, 0x11, 0xd0, 0xb4, 0x2d, 0x00, 0xc0, 0x4f, 0xc9, 0x64, 0xff}
+
+	tests := []struct {
+		name    string
+		d       Dialect
+		src     any
+		want    UUID
+		wantErr bool
+	}{
+		{"default text", DialectDefault, "6f9619ff-8b86-d011-b42d-00c04fc964ff", want, false},
+		{"default braces", DialectDefault, "{6f9619ff-8b86-d011-b42d-00c04fc964ff}", want, false},
+		{"default bytes", DialectDefault, want[:], want, false},
+		{"postgres bytea hex", DialectPostgres, `\x6f9619ff8b86d011b42d00c04fc964ff`, want, false},
+		{"postgres bytea hex bytes", DialectPostgres, []byte(`\x6f9619ff8b86d011b42d00c04fc964ff`), want, false},
+		{"postgres bad bytea", DialectPostgres, `\x6f96`, Nil, true},
+		{"postgres text", DialectPostgres, "6f9619ff-8b86-d011-b42d-00c04fc964ff", want, false},
+		{"mssql bytes", DialectMSSQL, mssqlBytes, want, false},
+		{"mssql text", DialectMSSQL, "6F9619FF-8B86-D011-B42D-00C04FC964FF", want, false},
+		{"default bad type", DialectDefault, 42, Nil, true},
+	}
+	for _, tt := range tests {
+		var u UUID
+		err := ScanAs(&u, tt.d).Scan(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Scan() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if u != tt.want {
+			t.Errorf("%s: Scan() = %v, want %v", tt.name, u, tt.want)
+		}
+	}
+}
+
+func TestValueAs(t *testing.T) {
+	u := MustParse("6f9619ff-8b86-d011-b42d-00c04fc964ff")
+
+	v, err := ValueAs(u, DialectMSSQL).Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	b, ok := v.([]byte)
+	if !ok || !bytes.Equal(b[:4], []byte{0xff, 0x19, 0x96, 0x6f}) {
+		t.Errorf("MSSQL Value() = %x, want little-endian first field", v)
+	}
+	var back UUID
+	if err := ScanAs(&back, DialectMSSQL).Scan(b); err != nil || back != u {
+		t.Errorf("MSSQL round trip = %v, %v, want %v", back, err, u)
+	}
+
+	if v, _ := ValueAs(u, DialectPostgres).Value(); v != u.String() {
+		t.Errorf("Postgres Value() = %v, want %v", v, u.String())
+	}
+}
@@ -45,6 +45,15 @@
 // All operations are thread-safe. The default generator can be used concurrently
 // from multiple goroutines without additional synchronization.
 //
+// Portability:
+//
+// The core package uses only the standard library and builds for GOOS=js and
+// GOOS=wasip1 with GOARCH=wasm. It avoids cgo and platform-specific APIs so it
+// can also be compiled with TinyGo. Entropy comes from
+// crypto/rand, which maps to crypto.getRandomValues in the browser and to
+// random_get under WASI, so no extra configuration is needed. Platform
+// specific features live in files guarded by build constraints.
+//
 // Standards Compliance:
 //
 // This implementation follows RFC 4122 and RFC 9562 specifications for UUIDs.
@@ -0,0 +1,105 @@
+package guuid
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+)
+
+// Format selects the textual representation written by an Encoder
+type Format int
+
+const (
+	// FormatCanonical is the hyphenated xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form
+	FormatCanonical Format = iota
+	// FormatHex is the 32-character hexadecimal form without hyphens
+	FormatHex
+	// FormatBase64 is the URL-safe base64 form without padding
+	FormatBase64
+	// FormatBase64Std is the standard base64 form with padding
+	FormatBase64Std
+	// FormatBase32Hex is the order-preserving base32hex form without padding
+	FormatBase32Hex
+)
+
+// encodedLen returns the number of bytes a single UUID occupies in format f
+func (f Format) encodedLen() int {
+	switch f {
+	case FormatHex:
+		return 32
+	case FormatBase64:
+		return base64.RawURLEncoding.EncodedLen(16)
+	case FormatBase64Std:
+		return base64.StdEncoding.EncodedLen(16)
+	case FormatBase32Hex:
+		return base32Hex.EncodedLen(16)
+	default:
+		return 36
+	}
+}
+
+// Encoder writes a stream of UUIDs to an io.Writer, one per record.
+// Output is buffered internally; callers must call Flush when done.
+type Encoder struct {
+	w      *bufio.Writer
+	format Format
+	sep    []byte
+	buf    [36]byte
+}
+
+// NewEncoder returns an Encoder that writes canonical UUIDs separated by
+// newlines to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		w:   bufio.NewWriter(w),
+		sep: []byte{'\n'},
+	}
+}
+
+// SetFormat sets the representation used for subsequent UUIDs
+func (e *Encoder) SetFormat(f Format) {
+	e.format = f
+}
+
+// SetSeparator sets the bytes written after each UUID (default "\n")
+func (e *Encoder) SetSeparator(sep string) {
+	e.sep = []byte(sep)
+}
+
+// Encode writes a single UUID followed by the separator
+func (e *Encoder) Encode(u UUID) error {
+	dst := e.buf[:e.format.encodedLen()]
+	switch e.format {
+	case FormatHex:
+		hex.Encode(dst, u[:])
+	case FormatBase64:
+		base64.RawURLEncoding.Encode(dst, u[:])
+	case FormatBase64Std:
+		base64.StdEncoding.Encode(dst, u[:])
+	case FormatBase32Hex:
+		base32Hex.Encode(dst, u[:])
+	default:
+		encodeHex(dst, u)
+	}
+	if _, err := e.w.Write(dst); err != nil {
+		return err
+	}
+	_, err := e.w.Write(e.sep)
+	return err
+}
+
+// EncodeAll writes every UUID in ids, stopping at the first error
+func (e *Encoder) EncodeAll(ids []UUID) error {
+	for _, id := range ids {
+		if err := e.Encode(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered data to the underlying io.Writer
+func (e *Encoder) Flush() error {
+	return e.w.Flush()
+}
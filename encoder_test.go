@@ -0,0 +1,68 @@
+package guuid
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEncoder_Formats(t *testing.T) {
+	uuid := UUID{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79}
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{"canonical", FormatCanonical, uuid.String()},
+		{"hex", FormatHex, uuid.EncodeToHex()},
+		{"base64", FormatBase64, uuid.EncodeToBase64()},
+		{"base64 std", FormatBase64Std, uuid.EncodeToBase64Std()},
+		{"base32hex", FormatBase32Hex, uuid.EncodeToBase32Hex()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf)
+			enc.SetFormat(tt.format)
+			if err := enc.Encode(uuid); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if err := enc.Flush(); err != nil {
+				t.Fatalf("Flush() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want+"\n" {
+				t.Errorf("Encode() = %q, want %q", got, tt.want+"\n")
+			}
+		})
+	}
+}
+
+func TestEncoder_EncodeAll(t *testing.T) {
+	gen := NewGenerator()
+	ids := make([]UUID, 100)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	enc.SetSeparator(",")
+	if err := enc.EncodeAll(ids); err != nil {
+		t.Fatalf("EncodeAll() error = %v", err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	parts := strings.Split(strings.TrimSuffix(buf.String(), ","), ",")
+	if len(parts) != len(ids) {
+		t.Fatalf("EncodeAll() wrote %d records, want %d", len(parts), len(ids))
+	}
+	for i, p := range parts {
+		if MustParse(p) != ids[i] {
+			t.Errorf("record %d = %s, want %s", i, p, ids[i])
+		}
+	}
+}
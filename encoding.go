@@ -1,8 +1,11 @@
 package guuid
 
 import (
+	"encoding/base32"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
+	"strings"
 )
 
 // EncodeToHex encodes the UUID to a hexadecimal string without hyphens
@@ -26,8 +29,7 @@ func DecodeFromHex(s string) (UUID, error) {
 	if len(s) != 32 {
 		return uuid, ErrInvalidFormat
 	}
-	_, err := hex.Decode(uuid[:], []byte(s))
-	if err != nil {
+	if !decodeHex(uuid[:], s) {
 		return uuid, ErrInvalidFormat
 	}
 	return uuid, nil
@@ -61,6 +63,224 @@ func DecodeFromBase64Std(s string) (UUID, error) {
 	return uuid, nil
 }
 
+// base32Hex is the RFC 4648 section 7 "extended hex" alphabet without padding
+var base32Hex = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// EncodeToBase32Hex encodes the UUID to 26 characters of base32hex (RFC 4648 §7)
+// without padding. Because the alphabet is in ASCII order and the encoding is
+// big-endian, the lexicographic order of the strings matches the byte order of
+// the UUIDs, so UUIDv7 strings stay time-sorted (e.g. as S3 or DynamoDB keys).
+func (u UUID) EncodeToBase32Hex() string {
+	return base32Hex.EncodeToString(u[:])
+}
+
+// DecodeFromBase32Hex decodes a base32hex string to UUID. Lowercase input is accepted.
+func DecodeFromBase32Hex(s string) (UUID, error) {
+	var uuid UUID
+	if len(s) != base32Hex.EncodedLen(16) {
+		return uuid, ErrInvalidFormat
+	}
+	n, err := base32Hex.Decode(uuid[:], []byte(strings.ToUpper(s)))
+	if err != nil || n != 16 {
+		return Nil, ErrInvalidFormat
+	}
+	return uuid, nil
+}
+
+// crockfordAlphabet is Crockford's base32 alphabet followed by the five
+// extra check symbols used for the mod-37 check character
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ*~$=U"
+
+// crockfordDecode maps an input byte to its Crockford value, or 0xFF if invalid.
+// Decoding is case-insensitive and maps the easily confused I/L to 1 and O to 0.
+var crockfordDecode = func() [256]byte {
+	var t [256]byte
+	for i := range t {
+		t[i] = 0xFF
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		c := crockfordAlphabet[i]
+		t[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			t[c+'a'-'A'] = byte(i)
+		}
+	}
+	t['I'], t['i'], t['L'], t['l'] = 1, 1, 1, 1
+	t['O'], t['o'] = 0, 0
+	return t
+}()
+
+// crockfordCheck returns the UUID value modulo 37
+func crockfordCheck(u UUID) byte {
+	var r uint32
+	for _, b := range u {
+		r = (r<<8 | uint32(b)) % 37
+	}
+	return byte(r)
+}
+
+// EncodeToCrockfordCheck encodes the UUID as 26 Crockford base32 characters
+// followed by a mod-37 check symbol, for IDs that humans read aloud or type.
+// The check symbol detects any single-character error and any transposition
+// of adjacent characters.
+func (u UUID) EncodeToCrockfordCheck() string {
+	var buf [27]byte
+	hi := binary.BigEndian.Uint64(u[0:8])
+	lo := binary.BigEndian.Uint64(u[8:16])
+	for i := 25; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	buf[26] = crockfordAlphabet[crockfordCheck(u)]
+	return string(buf[:])
+}
+
+// DecodeFromCrockfordCheck decodes a string produced by EncodeToCrockfordCheck.
+// Hyphens and spaces are ignored, so grouped input such as "01H9-..." is accepted.
+// It returns ErrChecksumMismatch if the check symbol does not match.
+func DecodeFromCrockfordCheck(s string) (UUID, error) {
+	var uuid UUID
+	var hi, lo uint64
+	n := 0
+	var check byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '-' || c == ' ' {
+			continue
+		}
+		v := crockfordDecode[c]
+		switch {
+		case v == 0xFF || n > 26:
+			return uuid, ErrInvalidFormat
+		case n == 26:
+			check = v
+		case v >= 32, n == 0 && v > 7:
+			// Only check symbols may use values >= 32, and the leading
+			// character carries just 3 bits of the 128-bit value
+			return uuid, ErrInvalidFormat
+		default:
+			hi = hi<<5 | lo>>59
+			lo = lo<<5 | uint64(v)
+		}
+		n++
+	}
+	if n != 27 {
+		return uuid, ErrInvalidFormat
+	}
+	binary.BigEndian.PutUint64(uuid[0:8], hi)
+	binary.BigEndian.PutUint64(uuid[8:16], lo)
+	if crockfordCheck(uuid) != check {
+		return Nil, ErrChecksumMismatch
+	}
+	return uuid, nil
+}
+
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// EncodeToProquint encodes the UUID as eight pronounceable proquint words
+// separated by hyphens, e.g. "babab-...". Each 16-bit word becomes five
+// letters alternating consonants (4 bits) and vowels (2 bits).
+func (u UUID) EncodeToProquint() string {
+	var buf [8*6 - 1]byte
+	for w := 0; w < 8; w++ {
+		v := binary.BigEndian.Uint16(u[w*2:])
+		dst := buf[w*6:]
+		dst[0] = proquintConsonants[v>>12&0xF]
+		dst[1] = proquintVowels[v>>10&0x3]
+		dst[2] = proquintConsonants[v>>6&0xF]
+		dst[3] = proquintVowels[v>>4&0x3]
+		dst[4] = proquintConsonants[v&0xF]
+		if w < 7 {
+			dst[5] = '-'
+		}
+	}
+	return string(buf[:])
+}
+
+// DecodeFromProquint decodes a string produced by EncodeToProquint.
+// Decoding is case-insensitive.
+func DecodeFromProquint(s string) (UUID, error) {
+	var uuid UUID
+	if len(s) != 8*6-1 {
+		return uuid, ErrInvalidFormat
+	}
+	for w := 0; w < 8; w++ {
+		src := s[w*6:]
+		if w < 7 && src[5] != '-' {
+			return uuid, ErrInvalidFormat
+		}
+		var v uint16
+		for i := 0; i < 5; i++ {
+			c := src[i] | 0x20 // ASCII lowercase
+			var idx int
+			var width uint
+			if i%2 == 0 {
+				idx, width = strings.IndexByte(proquintConsonants, c), 4
+			} else {
+				idx, width = strings.IndexByte(proquintVowels, c), 2
+			}
+			if idx < 0 {
+				return Nil, ErrInvalidFormat
+			}
+			v = v<<width | uint16(idx)
+		}
+		binary.BigEndian.PutUint16(uuid[w*2:], v)
+	}
+	return uuid, nil
+}
+
+// base58Alphabet is the Bitcoin base58 alphabet, which omits 0, O, I and l
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Len is the number of base58 digits needed for 128 bits
+const base58Len = 22
+
+// EncodeToBase58 encodes the UUID as 22 base58 characters, left-padded with
+// '1' (zero) so the encoding has a fixed width and sorts like the UUID.
+func (u UUID) EncodeToBase58() string {
+	var buf [base58Len]byte
+	n := u // dividend, reduced in place
+	for i := base58Len - 1; i >= 0; i-- {
+		var rem uint
+		for j := range n {
+			acc := rem<<8 | uint(n[j])
+			n[j] = byte(acc / 58)
+			rem = acc % 58
+		}
+		buf[i] = base58Alphabet[rem]
+	}
+	return string(buf[:])
+}
+
+// DecodeFromBase58 decodes a base58 string of at most 22 characters, such as
+// one produced by EncodeToBase58
+func DecodeFromBase58(s string) (UUID, error) {
+	var uuid UUID
+	if len(s) == 0 || len(s) > base58Len {
+		return uuid, ErrInvalidFormat
+	}
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return Nil, ErrInvalidFormat
+		}
+		carry := uint(d)
+		for j := len(uuid) - 1; j >= 0; j-- {
+			acc := uint(uuid[j])*58 + carry
+			uuid[j] = byte(acc)
+			carry = acc >> 8
+		}
+		if carry != 0 {
+			return Nil, ErrInvalidFormat // exceeds 128 bits
+		}
+	}
+	return uuid, nil
+}
+
 // FromBytes creates a UUID from a byte slice
 func FromBytes(b []byte) (UUID, error) {
 	var uuid UUID
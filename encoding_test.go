@@ -1,6 +1,8 @@
 package guuid
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -236,3 +238,213 @@ func TestEncodingRoundTrips(t *testing.T) {
 		}
 	}
 }
+
+func TestUUID_EncodeDecodeCrockfordCheck(t *testing.T) {
+	gen := NewGenerator()
+	ids := []UUID{Nil, {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}}
+	for i := 0; i < 100; i++ {
+		ids = append(ids, Must(gen.New()))
+	}
+
+	for _, uuid := range ids {
+		encoded := uuid.EncodeToCrockfordCheck()
+		if len(encoded) != 27 {
+			t.Fatalf("EncodeToCrockfordCheck() length = %d, want 27", len(encoded))
+		}
+		decoded, err := DecodeFromCrockfordCheck(encoded)
+		if err != nil {
+			t.Fatalf("DecodeFromCrockfordCheck(%q) error = %v", encoded, err)
+		}
+		if decoded != uuid {
+			t.Errorf("Crockford round-trip = %v, want %v", decoded, uuid)
+		}
+	}
+}
+
+func TestDecodeFromCrockfordCheck_HumanInput(t *testing.T) {
+	uuid := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	encoded := uuid.EncodeToCrockfordCheck()
+
+	// Lowercase, grouping hyphens and confusable letters must all be accepted
+	human := strings.ToLower(encoded[:9]) + "-" + encoded[9:18] + " " + encoded[18:]
+	human = strings.ReplaceAll(human, "0", "o")
+	human = strings.ReplaceAll(human, "1", "l")
+	decoded, err := DecodeFromCrockfordCheck(human)
+	if err != nil {
+		t.Fatalf("DecodeFromCrockfordCheck(%q) error = %v", human, err)
+	}
+	if decoded != uuid {
+		t.Errorf("DecodeFromCrockfordCheck(%q) = %v, want %v", human, decoded, uuid)
+	}
+}
+
+func TestDecodeFromCrockfordCheck_Errors(t *testing.T) {
+	encoded := MustParse("01890a5d-ac96-774b-bcce-b302099a8057").EncodeToCrockfordCheck()
+
+	// Single substitutions and adjacent transpositions must be detected
+	for i := 1; i < 26; i++ {
+		b := []byte(encoded)
+		b[i] = crockfordAlphabet[(crockfordDecode[b[i]]+1)%32]
+		if _, err := DecodeFromCrockfordCheck(string(b)); !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("substitution at %d: error = %v, want %v", i, err, ErrChecksumMismatch)
+		}
+		b = []byte(encoded)
+		if b[i] == b[i+1] {
+			continue
+		}
+		b[i], b[i+1] = b[i+1], b[i]
+		if _, err := DecodeFromCrockfordCheck(string(b)); err == nil {
+			t.Errorf("transposition at %d not detected", i)
+		}
+	}
+
+	for _, s := range []string{"", encoded[:26], encoded + "0", "Z" + encoded[1:], encoded[:25] + "*" + encoded[26:]} {
+		if _, err := DecodeFromCrockfordCheck(s); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("DecodeFromCrockfordCheck(%q) error = %v, want %v", s, err, ErrInvalidFormat)
+		}
+	}
+}
+
+func TestUUID_EncodeToProquint(t *testing.T) {
+	// Word 0x7f00 (127.0 in the proquint spec example) encodes as "lusab"
+	uuid := UUID{0x7f, 0x00, 0x00, 0x01}
+	got := uuid.EncodeToProquint()
+	want := "lusab-babad-babab-babab-babab-babab-babab-babab"
+	if got != want {
+		t.Errorf("EncodeToProquint() = %v, want %v", got, want)
+	}
+}
+
+func TestUUID_EncodeDecodeProquint_RoundTrip(t *testing.T) {
+	gen := NewGenerator()
+	for i := 0; i < 100; i++ {
+		uuid := Must(gen.New())
+		encoded := uuid.EncodeToProquint()
+		decoded, err := DecodeFromProquint(strings.ToUpper(encoded))
+		if err != nil {
+			t.Fatalf("DecodeFromProquint(%q) error = %v", encoded, err)
+		}
+		if decoded != uuid {
+			t.Errorf("Proquint round-trip = %v, want %v", decoded, uuid)
+		}
+	}
+}
+
+func TestDecodeFromProquint_Invalid(t *testing.T) {
+	valid := UUID{0x7f, 0x00, 0x00, 0x01}.EncodeToProquint()
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"too short", valid[:40]},
+		{"bad separator", strings.Replace(valid, "-", "_", 1)},
+		{"vowel in consonant slot", "a" + valid[1:]},
+		{"consonant in vowel slot", "lbsab" + valid[5:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeFromProquint(tt.input); err == nil {
+				t.Errorf("DecodeFromProquint(%q) expected error", tt.input)
+			}
+		})
+	}
+}
+
+func TestUUID_EncodeDecodeBase32Hex(t *testing.T) {
+	uuid := UUID{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79}
+	encoded := uuid.EncodeToBase32Hex()
+	if len(encoded) != 26 {
+		t.Fatalf("EncodeToBase32Hex() length = %d, want 26", len(encoded))
+	}
+
+	for _, s := range []string{encoded, strings.ToLower(encoded)} {
+		decoded, err := DecodeFromBase32Hex(s)
+		if err != nil {
+			t.Fatalf("DecodeFromBase32Hex(%q) error = %v", s, err)
+		}
+		if decoded != uuid {
+			t.Errorf("DecodeFromBase32Hex(%q) = %v, want %v", s, decoded, uuid)
+		}
+	}
+
+	for _, s := range []string{"", encoded[:25], encoded + "0", "W" + encoded[1:]} {
+		if _, err := DecodeFromBase32Hex(s); err == nil {
+			t.Errorf("DecodeFromBase32Hex(%q) expected error", s)
+		}
+	}
+}
+
+func TestUUID_Base32Hex_PreservesOrder(t *testing.T) {
+	gen := NewGenerator()
+	ids := make([]UUID, 200)
+	for i := range ids {
+		if i%2 == 0 {
+			ids[i] = Must(gen.New())
+		} else {
+			ids[i] = Must(NewGeneratorWithReader(zeroReader{}).New())
+			ids[i][15] = byte(i)
+		}
+	}
+
+	for i := 1; i < len(ids); i++ {
+		a, b := ids[i-1], ids[i]
+		sa, sb := a.EncodeToBase32Hex(), b.EncodeToBase32Hex()
+		if cmp := strings.Compare(sa, sb); cmp != a.Compare(b) {
+			t.Errorf("order mismatch: %v vs %v compare %d, strings %q vs %q compare %d", a, b, a.Compare(b), sa, sb, cmp)
+		}
+	}
+}
+
+func TestUUID_EncodeToBase58(t *testing.T) {
+	tests := []struct {
+		name string
+		uuid UUID
+		want string
+	}{
+		{"nil", Nil, "1111111111111111111111"},
+		{"max", UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "YcVfxkQb6JRzqk5kF2tNLv"},
+		{"v4", MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), "XBz3jkFgmHZpHEmghHCsXn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.uuid.EncodeToBase58(); got != tt.want {
+				t.Errorf("EncodeToBase58() = %v, want %v", got, tt.want)
+			}
+			decoded, err := DecodeFromBase58(tt.want)
+			if err != nil {
+				t.Fatalf("DecodeFromBase58(%q) error = %v", tt.want, err)
+			}
+			if decoded != tt.uuid {
+				t.Errorf("DecodeFromBase58(%q) = %v, want %v", tt.want, decoded, tt.uuid)
+			}
+		})
+	}
+}
+
+func TestUUID_EncodeToBase58_Ordering(t *testing.T) {
+	gen := NewGenerator()
+	prev := Must(gen.New())
+	for i := 0; i < 1000; i++ {
+		next := Must(gen.New())
+		if prev.EncodeToBase58() >= next.EncodeToBase58() {
+			t.Fatalf("base58 encodings out of order at %d", i)
+		}
+		prev = next
+	}
+}
+
+func TestDecodeFromBase58_Invalid(t *testing.T) {
+	for _, s := range []string{"", "0000000000000000000000", "zzzzzzzzzzzzzzzzzzzzzz", "11111111111111111111111", "YcVfxkQb6JRzqk5kF2tNLw"} {
+		if _, err := DecodeFromBase58(s); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("DecodeFromBase58(%q) error = %v, want %v", s, err, ErrInvalidFormat)
+		}
+	}
+
+	// Shorter inputs are accepted as values with implied leading zeros
+	if got, err := DecodeFromBase58("2"); err != nil || got != (UUID{15: 1}) {
+		t.Errorf("DecodeFromBase58(\"2\") = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,204 @@
+package guuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// EntropyPolicy controls how a Generator reacts when its random source fails
+type EntropyPolicy int
+
+const (
+	// EntropyFailFast returns the read error to the caller immediately (default)
+	EntropyFailFast EntropyPolicy = iota
+
+	// EntropyRetry retries the read with exponential backoff before giving up
+	EntropyRetry
+
+	// EntropyFallback switches to an AES-256-CTR keystream seeded from
+	// crypto/rand when the generator is constructed. The fallback is only used
+	// for reads where the primary source fails.
+	EntropyFallback
+)
+
+const (
+	defaultRetryAttempts = 3
+	defaultRetryBackoff  = time.Millisecond
+)
+
+// WithEntropyPolicy sets the policy applied when the random source returns an error
+func WithEntropyPolicy(p EntropyPolicy) Option {
+	return func(g *Generator) {
+		g.entropyPolicy = p
+	}
+}
+
+// WithEntropyRetry enables EntropyRetry with the given number of retries.
+// The first retry waits for backoff and each following retry doubles the wait.
+// The generator lock is held while waiting, so concurrent callers block too.
+func WithEntropyRetry(attempts int, backoff time.Duration) Option {
+	return func(g *Generator) {
+		g.entropyPolicy = EntropyRetry
+		g.retryAttempts = attempts
+		g.retryBackoff = backoff
+	}
+}
+
+// WithEntropyMixing XORs rand_b with a SHA-256 digest of the timestamp,
+// counter, process ID and a per-generator instance number. A repeating or
+// compromised random source then cannot make two processes, or two
+// generators in one process, emit identical rand_b values. Mixing costs one
+// SHA-256 block per UUID.
+func WithEntropyMixing() Option {
+	return func(g *Generator) {
+		g.mixer = &entropyMixer{
+			pid:      uint64(os.Getpid()),
+			instance: mixerInstances.Add(1),
+		}
+	}
+}
+
+// mixerInstances numbers mixing generators within the process
+var mixerInstances atomic.Uint64
+
+// entropyMixer holds the per-generator inputs for WithEntropyMixing
+type entropyMixer struct {
+	pid      uint64
+	instance uint64
+}
+
+// mix XORs p with a digest of (timestamp, counter, pid, instance)
+func (m *entropyMixer) mix(p []byte, timestamp uint64, counter uint16) {
+	var in [26]byte
+	binary.BigEndian.PutUint64(in[0:8], timestamp)
+	binary.BigEndian.PutUint16(in[8:10], counter)
+	binary.BigEndian.PutUint64(in[10:18], m.pid)
+	binary.BigEndian.PutUint64(in[18:26], m.instance)
+	sum := sha256.Sum256(in[:])
+	for i := range p {
+		p[i] ^= sum[i]
+	}
+}
+
+// HealthCheck reads from the generator's primary random source and reports
+// whether it is currently usable. Fallback entropy is never consulted.
+func (g *Generator) HealthCheck() error {
+	var probe [16]byte
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.readPrimary(probe[:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrEntropyUnavailable, err)
+	}
+	return nil
+}
+
+// readRandom fills p from the random source, applying the entropy policy.
+// It must be called with g.mu held.
+func (g *Generator) readRandom(p []byte) error {
+	err := g.readPrimary(p)
+	if err == nil {
+		return nil
+	}
+
+	switch g.entropyPolicy {
+	case EntropyRetry:
+		backoff := g.retryBackoff
+		for i := 0; i < g.retryAttempts; i++ {
+			time.Sleep(backoff)
+			backoff *= 2
+			if err = g.readPrimary(p); err == nil {
+				return nil
+			}
+		}
+	case EntropyFallback:
+		if g.fallback != nil {
+			clear(p)
+			g.fallback.XORKeyStream(p, p)
+			return nil
+		}
+	}
+	return err
+}
+
+// entropyChunk is the size of reads issued under WithEntropyTimeout; it covers
+// every single read the generator makes
+const entropyChunk = 16
+
+// entropyRead is the outcome of a background read started by readPrimary
+type entropyRead struct {
+	buf []byte
+	err error
+}
+
+// WithEntropyTimeout bounds how long a single read from the random source may
+// block, as can happen in entropy-starved containers at boot. A read that
+// exceeds d fails with ErrEntropyTimeout, which the entropy policy then
+// handles like any other read error.
+//
+// Reads are moved to a background goroutine. A read that times out keeps
+// running and its result is consumed by the next call, so at most one read is
+// ever outstanding against the source. Each read allocates a small buffer.
+func WithEntropyTimeout(d time.Duration) Option {
+	return func(g *Generator) {
+		g.entropyTimeout = d
+	}
+}
+
+// readPrimary fills p from the primary random source, honoring the entropy
+// timeout. It must be called with g.mu held.
+func (g *Generator) readPrimary(p []byte) error {
+	if g.entropyTimeout <= 0 {
+		_, err := io.ReadFull(g.randReader, p)
+		return err
+	}
+
+	if g.pendingRead == nil {
+		ch := make(chan entropyRead, 1)
+		r := g.randReader
+		go func() {
+			buf := make([]byte, entropyChunk)
+			_, err := io.ReadFull(r, buf)
+			ch <- entropyRead{buf: buf, err: err}
+		}()
+		g.pendingRead = ch
+	}
+
+	timer := time.NewTimer(g.entropyTimeout)
+	defer timer.Stop()
+
+	select {
+	case res := <-g.pendingRead:
+		g.pendingRead = nil
+		if res.err != nil {
+			return res.err
+		}
+		copy(p, res.buf)
+		return nil
+	case <-timer.C:
+		return ErrEntropyTimeout
+	}
+}
+
+// newFallbackStream returns an AES-256-CTR keystream keyed from crypto/rand,
+// or nil if crypto/rand is unavailable.
+func newFallbackStream() cipher.Stream {
+	var seed [32 + aes.BlockSize]byte
+	if _, err := io.ReadFull(rand.Reader, seed[:]); err != nil {
+		return nil
+	}
+	block, err := aes.NewCipher(seed[:32])
+	if err != nil {
+		return nil
+	}
+	return cipher.NewCTR(block, seed[32:])
+}
@@ -0,0 +1,50 @@
+//go:build go1.22
+
+package guuid
+
+import (
+	"testing"
+)
+
+func TestGenerator_ChaCha8Entropy(t *testing.T) {
+	gen := NewGenerator(WithChaCha8Entropy())
+	if _, ok := gen.randReader.(*chacha8Reader); !ok {
+		t.Fatalf("WithChaCha8Entropy() reader = %T, want *chacha8Reader", gen.randReader)
+	}
+
+	seen := make(map[UUID]bool)
+	for i := 0; i < 1000; i++ {
+		uuid, err := gen.New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if uuid.Version() != VersionTimeSorted {
+			t.Errorf("New() version = %v, want %v", uuid.Version(), VersionTimeSorted)
+		}
+		if seen[uuid] {
+			t.Fatalf("Duplicate UUID generated: %v", uuid)
+		}
+		seen[uuid] = true
+	}
+}
+
+func TestChaCha8Reader_PartialWord(t *testing.T) {
+	gen := NewGenerator(WithChaCha8Entropy())
+	buf := make([]byte, 13)
+	n, err := gen.randReader.Read(buf)
+	if err != nil || n != len(buf) {
+		t.Errorf("Read() = %d, %v, want %d, nil", n, err, len(buf))
+	}
+}
+
+func BenchmarkGenerator_NewChaCha8(b *testing.B) {
+	gen := NewGenerator(WithChaCha8Entropy())
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, err := gen.New()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
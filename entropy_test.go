@@ -0,0 +1,152 @@
+package guuid
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+)
+
+// flakyReader fails the first n reads and then delegates to crypto/rand
+type flakyReader struct {
+	failures int
+}
+
+func (fr *flakyReader) Read(p []byte) (int, error) {
+	if fr.failures > 0 {
+		fr.failures--
+		return 0, errors.New("transient failure")
+	}
+	return rand.Read(p)
+}
+
+func TestGenerator_EntropyFailFast(t *testing.T) {
+	gen := NewGeneratorWithReader(&flakyReader{failures: 1})
+	if _, err := gen.New(); err == nil {
+		t.Error("New() expected error with fail-fast policy")
+	}
+	if _, err := gen.New(); err != nil {
+		t.Errorf("New() error after transient failure = %v", err)
+	}
+}
+
+func TestGenerator_EntropyRetry(t *testing.T) {
+	gen := NewGeneratorWithReader(&flakyReader{failures: 2}, WithEntropyRetry(3, time.Microsecond))
+	uuid, err := gen.New()
+	if err != nil {
+		t.Fatalf("New() error with retry policy = %v", err)
+	}
+	if uuid.Version() != VersionTimeSorted {
+		t.Errorf("New() version = %v, want %v", uuid.Version(), VersionTimeSorted)
+	}
+
+	gen = NewGeneratorWithReader(&flakyReader{failures: 10}, WithEntropyRetry(2, time.Microsecond))
+	if _, err := gen.New(); err == nil {
+		t.Error("New() expected error after retries are exhausted")
+	}
+}
+
+func TestGenerator_EntropyFallback(t *testing.T) {
+	gen := NewGeneratorWithReader(&brokenReader{}, WithEntropyPolicy(EntropyFallback))
+
+	seen := make(map[UUID]bool)
+	for i := 0; i < 100; i++ {
+		uuid, err := gen.New()
+		if err != nil {
+			t.Fatalf("New() error with fallback policy = %v", err)
+		}
+		if uuid.Variant() != VariantRFC4122 {
+			t.Errorf("New() variant = %v, want %v", uuid.Variant(), VariantRFC4122)
+		}
+		if seen[uuid] {
+			t.Fatalf("Duplicate UUID from fallback entropy: %v", uuid)
+		}
+		seen[uuid] = true
+	}
+}
+
+func TestGenerator_HealthCheck(t *testing.T) {
+	if err := NewGenerator().HealthCheck(); err != nil {
+		t.Errorf("HealthCheck() error = %v", err)
+	}
+
+	gen := NewGeneratorWithReader(&brokenReader{}, WithEntropyPolicy(EntropyFallback))
+	if err := gen.HealthCheck(); !errors.Is(err, ErrEntropyUnavailable) {
+		t.Errorf("HealthCheck() error = %v, want %v", err, ErrEntropyUnavailable)
+	}
+}
+
+// zeroReader is a degenerate random source that always yields zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestGenerator_EntropyMixing(t *testing.T) {
+	now := time.Now()
+
+	plain1 := Must(NewGeneratorWithReader(zeroReader{}).NewWithTime(now))
+	plain2 := Must(NewGeneratorWithReader(zeroReader{}).NewWithTime(now))
+	if plain1 != plain2 {
+		t.Fatalf("expected identical UUIDs from repeating source without mixing")
+	}
+
+	mixed1 := Must(NewGeneratorWithReader(zeroReader{}, WithEntropyMixing()).NewWithTime(now))
+	mixed2 := Must(NewGeneratorWithReader(zeroReader{}, WithEntropyMixing()).NewWithTime(now))
+	if mixed1 == mixed2 {
+		t.Errorf("WithEntropyMixing() produced identical UUIDs from repeating source: %v", mixed1)
+	}
+	if mixed1.Version() != VersionTimeSorted || mixed1.Variant() != VariantRFC4122 {
+		t.Errorf("WithEntropyMixing() broke version/variant bits: %v", mixed1)
+	}
+	if mixed1.Timestamp() != now.UnixMilli() {
+		t.Errorf("WithEntropyMixing() timestamp = %d, want %d", mixed1.Timestamp(), now.UnixMilli())
+	}
+}
+
+// blockingReader blocks every read until release is closed
+type blockingReader struct {
+	release chan struct{}
+}
+
+func (br *blockingReader) Read(p []byte) (int, error) {
+	<-br.release
+	return rand.Read(p)
+}
+
+func TestGenerator_EntropyTimeout(t *testing.T) {
+	r := &blockingReader{release: make(chan struct{})}
+	gen := NewGeneratorWithReader(r, WithEntropyTimeout(10*time.Millisecond))
+
+	start := time.Now()
+	if _, err := gen.New(); !errors.Is(err, ErrEntropyTimeout) {
+		t.Fatalf("New() error = %v, want %v", err, ErrEntropyTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("New() blocked for %v despite timeout", elapsed)
+	}
+	if err := gen.HealthCheck(); !errors.Is(err, ErrEntropyTimeout) {
+		t.Errorf("HealthCheck() error = %v, want %v", err, ErrEntropyTimeout)
+	}
+
+	close(r.release)
+	uuid, err := gen.New()
+	if err != nil {
+		t.Fatalf("New() error after source recovered = %v", err)
+	}
+	if uuid.Version() != VersionTimeSorted {
+		t.Errorf("New() version = %v, want %v", uuid.Version(), VersionTimeSorted)
+	}
+}
+
+func TestGenerator_EntropyTimeoutFallback(t *testing.T) {
+	r := &blockingReader{release: make(chan struct{})}
+	defer close(r.release)
+	gen := NewGeneratorWithReader(r, WithEntropyTimeout(time.Millisecond), WithEntropyPolicy(EntropyFallback))
+
+	if _, err := gen.New(); err != nil {
+		t.Errorf("New() error with timeout and fallback = %v", err)
+	}
+}
@@ -14,4 +14,34 @@ var (
 
 	// ErrInvalidVariant indicates that the UUID variant is not RFC 4122
 	ErrInvalidVariant = errors.New("guuid: invalid UUID variant (expected RFC 4122)")
+
+	// ErrChecksumMismatch indicates that a checksummed encoding failed validation
+	ErrChecksumMismatch = errors.New("guuid: checksum mismatch")
+
+	// ErrTimestampOutOfRange indicates that a UUIDv7 timestamp is implausibly far from the current time
+	ErrTimestampOutOfRange = errors.New("guuid: UUID timestamp out of allowed range")
+
+	// ErrNotSupported indicates that a feature is unavailable on this platform
+	ErrNotSupported = errors.New("guuid: not supported on this platform")
+
+	// ErrEntropyUnavailable indicates that the random source could not be read
+	ErrEntropyUnavailable = errors.New("guuid: entropy source unavailable")
+
+	// ErrEntropyTimeout indicates that reading from the random source exceeded the configured deadline
+	ErrEntropyTimeout = errors.New("guuid: timed out reading entropy source")
+
+	// ErrUnknownProvider indicates that no ID provider is registered under the requested name
+	ErrUnknownProvider = errors.New("guuid: unknown ID provider")
+
+	// ErrInvalidConfig indicates that a generator configuration has out-of-range values
+	ErrInvalidConfig = errors.New("guuid: invalid generator configuration")
+
+	// ErrSketchMismatch indicates that two sketches with different parameters cannot be merged
+	ErrSketchMismatch = errors.New("guuid: cannot merge sketches with different parameters")
+
+	// ErrOutboxExhausted indicates that an outbox transaction has used every sub-counter value
+	ErrOutboxExhausted = errors.New("guuid: outbox transaction sub-counter exhausted")
+
+	// ErrClockSkew indicates that the local clock deviates too far from the reference clock
+	ErrClockSkew = errors.New("guuid: local clock skewed from reference")
 )
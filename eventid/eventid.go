@@ -0,0 +1,124 @@
+// Package eventid pairs an aggregate's UUIDv7 with an event version and
+// encodes both into a single key that sorts by aggregate, then by version, as
+// event-sourced stores need for appending to and replaying a stream:
+//
+//	key := eventid.New(orderID, 42).Key()
+//	...
+//	id, err := eventid.FromKey(key[:])
+//
+// The binary key is the 16 UUID bytes followed by the version as a big-endian
+// uint64. The text form is the canonical UUID, a '/' and the version as 16
+// lowercase hex digits, so it sorts the same way as the binary key.
+package eventid
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// KeySize is the length of a binary key
+const KeySize = 24
+
+// textSize is the length of the text form
+const textSize = 36 + 1 + 16
+
+// ErrInvalidKey indicates a key or string that is not an encoded ID
+var ErrInvalidKey = errors.New("eventid: invalid event key")
+
+// ID identifies one event: the aggregate it belongs to and its version in
+// the aggregate's stream
+type ID struct {
+	Aggregate guuid.UUID
+	Version   uint64
+}
+
+// New returns the ID of version of aggregate
+func New(aggregate guuid.UUID, version uint64) ID {
+	return ID{Aggregate: aggregate, Version: version}
+}
+
+// Next returns the ID of the following event in the same stream
+func (id ID) Next() ID {
+	return ID{Aggregate: id.Aggregate, Version: id.Version + 1}
+}
+
+// Key returns the binary key of id
+func (id ID) Key() [KeySize]byte {
+	var k [KeySize]byte
+	copy(k[:16], id.Aggregate[:])
+	binary.BigEndian.PutUint64(k[16:], id.Version)
+	return k
+}
+
+// FromKey decodes a binary key produced by ID.Key
+func FromKey(b []byte) (ID, error) {
+	if len(b) != KeySize {
+		return ID{}, ErrInvalidKey
+	}
+	return ID{Aggregate: guuid.UUID(b[:16]), Version: binary.BigEndian.Uint64(b[16:])}, nil
+}
+
+// String returns the text form, e.g.
+// "018f3e5a-7b2c-7abc-bfff-112233445566/000000000000002a"
+func (id ID) String() string {
+	b, _ := id.MarshalText()
+	return string(b)
+}
+
+// Parse decodes the text form produced by ID.String
+func Parse(s string) (ID, error) {
+	if len(s) != textSize || s[36] != '/' {
+		return ID{}, ErrInvalidKey
+	}
+	agg, err := guuid.Parse(s[:36])
+	if err != nil {
+		return ID{}, ErrInvalidKey
+	}
+	for i := 37; i < len(s); i++ {
+		if c := s[i]; !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return ID{}, ErrInvalidKey
+		}
+	}
+	v, err := strconv.ParseUint(s[37:], 16, 64)
+	if err != nil {
+		return ID{}, ErrInvalidKey
+	}
+	return ID{Aggregate: agg, Version: v}, nil
+}
+
+// Compare returns -1, 0 or +1 as id sorts before, equal to or after other
+func (id ID) Compare(other ID) int {
+	if c := id.Aggregate.Compare(other.Aggregate); c != 0 {
+		return c
+	}
+	switch {
+	case id.Version < other.Version:
+		return -1
+	case id.Version > other.Version:
+		return 1
+	}
+	return 0
+}
+
+// MarshalText implements the encoding.TextMarshaler interface
+func (id ID) MarshalText() ([]byte, error) {
+	b, _ := id.Aggregate.AppendText(make([]byte, 0, textSize))
+	b = append(b, '/')
+	for shift := 60; shift >= 0; shift -= 4 {
+		b = append(b, "0123456789abcdef"[id.Version>>uint(shift)&0xf])
+	}
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface
+func (id *ID) UnmarshalText(data []byte) error {
+	parsed, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
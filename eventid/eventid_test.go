@@ -0,0 +1,87 @@
+package eventid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestRoundTrip(t *testing.T) {
+	agg := guuid.MustParse("018f3e5a-7b2c-7abc-bfff-112233445566")
+	for _, v := range []uint64{0, 1, 42, 1 << 40, ^uint64(0)} {
+		id := New(agg, v)
+
+		key := id.Key()
+		if got, err := FromKey(key[:]); err != nil || got != id {
+			t.Errorf("FromKey(Key()) = %v, %v, want %v", got, err, id)
+		}
+		if got, err := Parse(id.String()); err != nil || got != id {
+			t.Errorf("Parse(%q) = %v, %v, want %v", id.String(), got, err, id)
+		}
+	}
+	if got, want := New(agg, 42).String(), "018f3e5a-7b2c-7abc-bfff-112233445566/000000000000002a"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestOrdering(t *testing.T) {
+	a := guuid.MustParse("018f3e5a-7b2c-7abc-bfff-112233445566")
+	b := guuid.MustParse("018f3e5a-7b2d-7000-8000-000000000000")
+	ids := []ID{New(a, 0), New(a, 1), New(a, 255), New(a, 256), New(a, 1<<40), New(b, 0), New(b, 7)}
+
+	for i := 1; i < len(ids); i++ {
+		prev, cur := ids[i-1], ids[i]
+		if prev.Compare(cur) >= 0 || cur.Compare(prev) <= 0 {
+			t.Errorf("Compare(%v, %v) not ordered", prev, cur)
+		}
+		pk, ck := prev.Key(), cur.Key()
+		if bytes.Compare(pk[:], ck[:]) >= 0 {
+			t.Errorf("Key(%v) does not sort before Key(%v)", prev, cur)
+		}
+		if prev.String() >= cur.String() {
+			t.Errorf("String(%v) does not sort before String(%v)", prev, cur)
+		}
+	}
+	if !slices.IsSortedFunc(ids, ID.Compare) {
+		t.Error("IDs not sorted by Compare")
+	}
+	if got := New(a, 1).Next(); got != New(a, 2) {
+		t.Errorf("Next() = %v, want %v", got, New(a, 2))
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	valid := "018f3e5a-7b2c-7abc-bfff-112233445566/000000000000002a"
+	for _, s := range []string{
+		"",
+		valid[:52],
+		strings.Replace(valid, "/", ":", 1),
+		valid[:37] + "000000000000002A",
+		valid[:37] + "+00000000000002a",
+		"018f3e5a-7b2c-7abc-bfff-11223344556g/000000000000002a",
+	} {
+		if _, err := Parse(s); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidKey", s, err)
+		}
+	}
+	if _, err := FromKey(make([]byte, 16)); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("FromKey(16 bytes) error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	in := struct{ ID ID }{New(guuid.MustParse("018f3e5a-7b2c-7abc-bfff-112233445566"), 3)}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var out struct{ ID ID }
+	if err := json.Unmarshal(data, &out); err != nil || out != in {
+		t.Errorf("json round trip = %+v, %v, want %+v", out, err, in)
+	}
+}
@@ -0,0 +1,84 @@
+package guuid
+
+import "fmt"
+
+// Factory generates UUIDs of several versions from one object, with New
+// returning the configured default version. It lets libraries accept a single
+// injectable ID dependency instead of one per version. Random and time-based
+// UUIDs draw on the Factory's Generator, so its entropy options, hooks, rate
+// limit and Stats apply to NewV4 as well as NewV7; its monotonicity options
+// apply only to NewV7.
+//
+// A Factory is safe for concurrent use.
+type Factory struct {
+	version Version
+	gen     *Generator // nil means the package default generator
+}
+
+var _ Source = (*Factory)(nil)
+
+// NewFactory returns a Factory whose New method generates UUIDs of the given
+// version, which must be VersionRandom or VersionTimeSorted. If gen is nil the
+// Factory uses the package default generator, following SetDefault.
+func NewFactory(version Version, gen *Generator) (*Factory, error) {
+	if version != VersionRandom && version != VersionTimeSorted {
+		return nil, fmt.Errorf("%w: factory default %v", ErrInvalidVersion, version)
+	}
+	return &Factory{version: version, gen: gen}, nil
+}
+
+// Version returns the version generated by New
+func (f *Factory) Version() Version {
+	return f.version
+}
+
+// Generator returns the generator backing the Factory
+func (f *Factory) Generator() *Generator {
+	if f.gen == nil {
+		return Default()
+	}
+	return f.gen
+}
+
+// New generates a UUID of the Factory's default version
+func (f *Factory) New() (UUID, error) {
+	if f.version == VersionRandom {
+		return f.NewV4()
+	}
+	return f.NewV7()
+}
+
+// NewV4 generates a random UUIDv4
+func (f *Factory) NewV4() (UUID, error) {
+	return f.Generator().newV4()
+}
+
+// NewV5 returns the name-based UUIDv5 for name in namespace ns
+func (f *Factory) NewV5(ns UUID, name []byte) UUID {
+	return NewV5(ns, name)
+}
+
+// NewV7 generates a time-sorted UUIDv7
+func (f *Factory) NewV7() (UUID, error) {
+	return f.Generator().New()
+}
+
+// newV4 generates a UUIDv4 from the generator's random source. Like New, it
+// runs the generator's hooks and rate limit and is counted in Stats.
+func (g *Generator) newV4() (UUID, error) {
+	return g.issue(g.randomV4)
+}
+
+// randomV4 fills a UUIDv4 from the generator's random source
+func (g *Generator) randomV4() (UUID, error) {
+	var uuid UUID
+	g.mu.Lock()
+	err := g.readRandom(uuid[:])
+	g.mu.Unlock()
+	if err != nil {
+		return Nil, err
+	}
+	uuid.SetVersion(VersionRandom)
+	uuid.SetVariant(VariantRFC4122)
+	return uuid, nil
+}
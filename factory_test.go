@@ -0,0 +1,80 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewFactory(t *testing.T) {
+	tests := []struct {
+		version Version
+		wantErr bool
+	}{
+		{VersionRandom, false},
+		{VersionTimeSorted, false},
+		{VersionNameBasedSHA1, true},
+		{Version(0), true},
+	}
+	for _, tt := range tests {
+		f, err := NewFactory(tt.version, nil)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewFactory(%v) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			if !errors.Is(err, ErrInvalidVersion) {
+				t.Errorf("NewFactory(%v) error = %v, want ErrInvalidVersion", tt.version, err)
+			}
+			continue
+		}
+		if got := Must(f.New()).Version(); got != tt.version {
+			t.Errorf("New() version = %v, want %v", got, tt.version)
+		}
+	}
+}
+
+func TestFactory_Versions(t *testing.T) {
+	gen := NewGenerator()
+	f, err := NewFactory(VersionTimeSorted, gen)
+	if err != nil {
+		t.Fatalf("NewFactory() error = %v", err)
+	}
+	if f.Generator() != gen {
+		t.Error("Generator() did not return the configured generator")
+	}
+
+	v4 := Must(f.NewV4())
+	if v4.Version() != VersionRandom || v4.Variant() != VariantRFC4122 {
+		t.Errorf("NewV4() = %v, want RFC 4122 v4", v4)
+	}
+	if got, want := f.NewV5(NamespaceDNS, []byte("www.example.com")), NewV5(NamespaceDNS, []byte("www.example.com")); got != want {
+		t.Errorf("NewV5() = %v, want %v", got, want)
+	}
+	if got := Must(f.NewV7()).Version(); got != VersionTimeSorted {
+		t.Errorf("NewV7() version = %v", got)
+	}
+	if got := gen.Stats().Generated; got != 2 {
+		t.Errorf("generator Stats().Generated = %d, want 2", got)
+	}
+
+	broken, _ := NewFactory(VersionRandom, NewGeneratorWithReader(&brokenReader{}))
+	if _, err := broken.New(); err == nil {
+		t.Error("New() expected error with broken reader")
+	}
+}
+
+func TestFactory_NewV4Hooks(t *testing.T) {
+	var seen UUID
+	gen := NewGenerator(WithHook(func(u UUID, err error) { seen = u }))
+	f, err := NewFactory(VersionRandom, gen)
+	if err != nil {
+		t.Fatalf("NewFactory() error = %v", err)
+	}
+	u := Must(f.New())
+	if seen != u {
+		t.Errorf("post hook saw %v, want %v", seen, u)
+	}
+	if s := gen.Stats(); s.Generated != 1 || s.Errors != 0 {
+		t.Errorf("Stats() = %+v, want one generated", s)
+	}
+}
@@ -0,0 +1,38 @@
+package guuid
+
+// Field names used by Fields and KeysAndValues
+const (
+	FieldID      = "id"
+	FieldVersion = "version"
+	FieldTime    = "time"
+)
+
+// Fields returns u as a map for structured logging: the canonical string
+// under "id", the version number under "version" and, for UUIDv7, the
+// embedded time (UTC) under "time". Indexing the time field lets log
+// pipelines filter on creation time without parsing IDs downstream.
+//
+// The map can be passed to zap.Any or logged as a single object.
+func (u UUID) Fields() map[string]any {
+	m := map[string]any{
+		FieldID:      u.String(),
+		FieldVersion: int(u.Version()),
+	}
+	if u.Version() == VersionTimeSorted {
+		m[FieldTime] = u.Time().UTC()
+	}
+	return m
+}
+
+// KeysAndValues returns the same fields as Fields as an alternating key/value
+// list, in a fixed order, for loggers such as logr and zap's SugaredLogger:
+//
+//	logger.Info("order created", id.KeysAndValues()...)
+//	sugar.Infow("order created", id.KeysAndValues()...)
+func (u UUID) KeysAndValues() []any {
+	kv := []any{FieldID, u.String(), FieldVersion, int(u.Version())}
+	if u.Version() == VersionTimeSorted {
+		kv = append(kv, FieldTime, u.Time().UTC())
+	}
+	return kv
+}
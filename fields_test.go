@@ -0,0 +1,42 @@
+package guuid
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUUID_Fields(t *testing.T) {
+	v7 := MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	v4 := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	ts := time.UnixMilli(0x017f22e279b0).UTC()
+
+	tests := []struct {
+		name string
+		uuid UUID
+		want map[string]any
+	}{
+		{"v7", v7, map[string]any{"id": v7.String(), "version": 7, "time": ts}},
+		{"v4", v4, map[string]any{"id": v4.String(), "version": 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.uuid.Fields(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUUID_KeysAndValues(t *testing.T) {
+	v7 := MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	want := []any{"id", v7.String(), "version", 7, "time", time.UnixMilli(0x017f22e279b0).UTC()}
+	if got := v7.KeysAndValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysAndValues() = %v, want %v", got, want)
+	}
+
+	if got := Nil.KeysAndValues(); len(got) != 4 {
+		t.Errorf("Nil.KeysAndValues() = %v, want 4 elements", got)
+	}
+}
@@ -3,10 +3,3 @@ module github.com/Lzww0608/guuid
 go 1.21.0
 
 toolchain go1.23.4
-
-require github.com/go-sql-driver/mysql v1.9.3
-
-require (
-	filippo.io/edwards25519 v1.1.0 // indirect
-	github.com/go-zookeeper/zk v1.0.4 // indirect
-)
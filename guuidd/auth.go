@@ -0,0 +1,66 @@
+package guuidd
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// clientKey is the context key for the authenticated client name
+type clientKey struct{}
+
+// WithAPIKeys requires every request to present one of keys, mapped to the
+// client name it identifies. Keys are accepted in an "Authorization: Bearer"
+// or "X-API-Key" header. Without this option the service is open.
+func WithAPIKeys(keys map[string]string) Option {
+	return func(s *Server) {
+		s.apiKeys = make(map[string]string, len(keys))
+		for k, v := range keys {
+			s.apiKeys[k] = v
+		}
+	}
+}
+
+// ClientFromContext returns the client name authenticated for a request, or
+// "" when API keys are not configured.
+func ClientFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(clientKey{}).(string)
+	return name
+}
+
+// authenticate resolves the request's API key to a client name
+func (s *Server) authenticate(r *http.Request) (string, bool) {
+	key := r.Header.Get("X-API-Key")
+	if auth := r.Header.Get("Authorization"); key == "" && auth != "" {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			key = token
+		}
+	}
+	if key == "" {
+		return "", false
+	}
+
+	// Compare against every key so the response time does not reveal a prefix match
+	name, found := "", false
+	for k, v := range s.apiKeys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			name, found = v, true
+		}
+	}
+	return name, found
+}
+
+// quotaKey returns the identity quotas are tracked under: the client name
+// when authenticated, otherwise the remote host.
+func quotaKey(r *http.Request) string {
+	if name := ClientFromContext(r.Context()); name != "" {
+		return name
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,51 @@
+package guuidd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServer_APIKeys(t *testing.T) {
+	srv := New(WithAPIKeys(map[string]string{"secret-a": "team-a"}))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"missing", "", "", http.StatusUnauthorized},
+		{"wrong key", "X-API-Key", "nope", http.StatusUnauthorized},
+		{"x-api-key", "X-API-Key", "secret-a", http.StatusOK},
+		{"bearer", "Authorization", "Bearer secret-a", http.StatusOK},
+		{"basic scheme", "Authorization", "Basic secret-a", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/uuid", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_Authenticate(t *testing.T) {
+	srv := &Server{apiKeys: map[string]string{"k": "team-k"}}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-API-Key", "k")
+	name, ok := srv.authenticate(req)
+	if !ok || name != "team-k" {
+		t.Errorf("authenticate() = %q, %v, want team-k, true", name, ok)
+	}
+	if got := ClientFromContext(req.Context()); got != "" {
+		t.Errorf("ClientFromContext() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,295 @@
+// Package client is a Go client for the guuidd HTTP service.
+//
+// It fetches IDs in batches and serves them from a local buffer, prefetching
+// the next batch in the background once the current one runs low (the double
+// buffer used by others/leafSegment). If the service cannot be reached or
+// answers with a 5xx status and no batch is buffered, it falls back to a local
+// Generator so callers keep getting IDs through an outage. Rejections such as
+// a bad API key, an exhausted quota or an invalid batch size are returned to
+// the caller as a *StatusError instead:
+//
+//	c := client.New("http://ids.internal:8080", client.WithAPIKey(key))
+//	id, err := c.New()
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// DefaultBatchSize is the number of IDs fetched per request
+const DefaultBatchSize = 256
+
+// Default delays before contacting the service again after a failed fetch
+const (
+	DefaultRetryBase = 100 * time.Millisecond
+	DefaultRetryMax  = 30 * time.Second
+)
+
+// StatusError is returned when the service rejects a request with a 4xx
+// status, which the fallback generator does not cover
+type StatusError struct {
+	StatusCode int
+	Status     string        // e.g. "401 Unauthorized"
+	RetryAfter time.Duration // from the Retry-After header of a 429, or 0
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return "guuidd: " + e.Status
+}
+
+// Client hands out IDs from the guuidd service. It is safe for concurrent use.
+//
+// IDs from one batch are ordered, but batches held by different clients
+// interleave, and fallback IDs are only ordered against the local generator.
+type Client struct {
+	baseURL  string
+	apiKey   string
+	hc       *http.Client
+	batch    int
+	fallback *guuid.Generator
+
+	retryBase time.Duration
+	retryMax  time.Duration
+
+	mu       sync.Mutex
+	current  []guuid.UUID  // batch being served
+	next     []guuid.UUID  // prefetched batch, nil until ready
+	loading  bool          // a prefetch is in flight
+	fetching chan struct{} // closed when the in-flight fetch for an empty buffer ends
+	failures int           // consecutive failed fetches
+	retryAt  time.Time     // no fetch before this time after a failure
+	lastErr  error         // error of the last failed fetch
+
+	fallbacks atomic.Uint64
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithAPIKey sets the key sent as a bearer token
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests (default: 5s timeout)
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.hc = hc
+	}
+}
+
+// WithBatchSize sets how many IDs are fetched per request
+func WithBatchSize(n int) Option {
+	return func(c *Client) {
+		c.batch = n
+	}
+}
+
+// WithFallback sets the generator used when the service is unreachable or
+// failing with 5xx statuses (default guuid.Default()). Passing nil disables
+// the fallback, so New returns the request error instead.
+func WithFallback(gen *guuid.Generator) Option {
+	return func(c *Client) {
+		c.fallback = gen
+	}
+}
+
+// WithRetryBackoff sets the delay before contacting the service again after a
+// failed fetch: base after the first failure, doubling with each further one
+// up to max, or as long as the Retry-After header of a 429 asks. Until then
+// New serves fallback IDs after an outage, or the last error, without waiting
+// on the network.
+func WithRetryBackoff(base, max time.Duration) Option {
+	return func(c *Client) {
+		c.retryBase, c.retryMax = base, max
+	}
+}
+
+// New returns a Client for the service at baseURL
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		hc:       &http.Client{Timeout: 5 * time.Second},
+		batch:    DefaultBatchSize,
+		fallback: guuid.Default(),
+
+		retryBase: DefaultRetryBase,
+		retryMax:  DefaultRetryMax,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.batch < 1 {
+		c.batch = DefaultBatchSize
+	}
+	return c
+}
+
+// New returns the next ID from the buffer, fetching or falling back as needed.
+// When the buffer is empty, one caller fetches the next batch without holding
+// the lock and concurrent callers wait for that fetch. After a failure, calls
+// fall back, or return a rejection again, until the WithRetryBackoff delay has
+// passed.
+func (c *Client) New() (guuid.UUID, error) {
+	for {
+		c.mu.Lock()
+		if len(c.current) == 0 && c.next != nil {
+			c.current, c.next = c.next, nil
+		}
+		if len(c.current) > 0 {
+			id := c.current[0]
+			c.current = c.current[1:]
+			c.maybePrefetch()
+			c.mu.Unlock()
+			return id, nil
+		}
+		if time.Now().Before(c.retryAt) {
+			err := c.lastErr
+			c.mu.Unlock()
+			return c.fail(err)
+		}
+		if wait := c.fetching; wait != nil {
+			c.mu.Unlock()
+			<-wait
+			continue
+		}
+		done := make(chan struct{})
+		c.fetching = done
+		c.mu.Unlock()
+
+		ids, err := c.fetch(context.Background(), c.batch)
+
+		c.mu.Lock()
+		c.record(err)
+		if err == nil {
+			c.current = append(c.current, ids...)
+		}
+		c.fetching = nil
+		close(done)
+		c.mu.Unlock()
+		if err != nil {
+			return c.fail(err)
+		}
+	}
+}
+
+// fail handles a failed fetch: an outage is covered by the fallback
+// generator, if any, and a rejection is returned as is
+func (c *Client) fail(err error) (guuid.UUID, error) {
+	var se *StatusError
+	if c.fallback == nil || errors.As(err, &se) {
+		return guuid.Nil, err
+	}
+	c.fallbacks.Add(1)
+	return c.fallback.New()
+}
+
+// record updates the retry backoff after a fetch. It must be called with c.mu held.
+func (c *Client) record(err error) {
+	if err == nil {
+		c.failures, c.retryAt, c.lastErr = 0, time.Time{}, nil
+		return
+	}
+	delay := c.retryMax
+	if c.failures < 30 && c.retryBase<<c.failures < delay {
+		delay = c.retryBase << c.failures
+	}
+	var se *StatusError
+	if errors.As(err, &se) && se.RetryAfter > delay {
+		delay = se.RetryAfter
+	}
+	c.failures++
+	c.retryAt, c.lastErr = time.Now().Add(delay), err
+}
+
+// Fallbacks returns how many IDs were served by the fallback generator
+func (c *Client) Fallbacks() uint64 {
+	return c.fallbacks.Load()
+}
+
+// maybePrefetch starts loading the next batch once 20% of the current one is
+// left. It must be called with c.mu held.
+func (c *Client) maybePrefetch() {
+	if c.next != nil || c.loading || len(c.current) > c.batch/5 || time.Now().Before(c.retryAt) {
+		return
+	}
+	c.loading = true
+	go func() {
+		ids, err := c.fetch(context.Background(), c.batch)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.loading = false
+		c.record(err)
+		if err == nil {
+			c.next = ids
+		}
+	}()
+}
+
+// fetch requests n IDs from the batch endpoint
+func (c *Client) fetch(ctx context.Context, n int) ([]guuid.UUID, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.baseURL+"/v1/uuid/batch?n="+strconv.Itoa(n), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			RetryAfter: retryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("guuidd: %s", resp.Status)
+	}
+
+	var body struct {
+		IDs []guuid.UUID `json:"ids"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("guuidd: decoding batch: %w", err)
+	}
+	if len(body.IDs) == 0 {
+		return nil, fmt.Errorf("guuidd: empty batch")
+	}
+	return body.IDs, nil
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+	"github.com/Lzww0608/guuid/guuidd"
+)
+
+// countingServer wraps a guuidd server and counts batch requests
+func countingServer(t *testing.T, opts ...guuidd.Option) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var requests atomic.Int64
+	srv := guuidd.New(opts...)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		srv.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &requests
+}
+
+func TestClient_New(t *testing.T) {
+	ts, requests := countingServer(t)
+	c := New(ts.URL, WithBatchSize(10), WithFallback(nil))
+
+	seen := make(map[guuid.UUID]bool)
+	for i := 0; i < 100; i++ {
+		id, err := c.New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if seen[id] {
+			t.Fatalf("New() returned duplicate %v", id)
+		}
+		seen[id] = true
+	}
+	if n := requests.Load(); n < 10 || n > 12 {
+		t.Errorf("requests = %d, want about 10", n)
+	}
+	if c.Fallbacks() != 0 {
+		t.Errorf("Fallbacks() = %d, want 0", c.Fallbacks())
+	}
+}
+
+func TestClient_Prefetch(t *testing.T) {
+	ts, requests := countingServer(t)
+	c := New(ts.URL, WithBatchSize(10), WithFallback(nil))
+
+	// Draw down to the prefetch threshold, then wait for the next batch
+	for i := 0; i < 8; i++ {
+		if _, err := c.New(); err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for requests.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("requests after threshold = %d, want 2", n)
+	}
+}
+
+func TestClient_Fallback(t *testing.T) {
+	ts, _ := countingServer(t)
+	ts.Close()
+
+	c := New(ts.URL, WithFallback(guuid.NewGenerator()))
+	id, err := c.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if id.Version() != guuid.VersionTimeSorted {
+		t.Errorf("fallback version = %v, want %v", id.Version(), guuid.VersionTimeSorted)
+	}
+	if c.Fallbacks() != 1 {
+		t.Errorf("Fallbacks() = %d, want 1", c.Fallbacks())
+	}
+
+	c = New(ts.URL, WithFallback(nil))
+	if _, err := c.New(); err == nil {
+		t.Error("New() without fallback expected error")
+	}
+}
+
+func TestClient_APIKey(t *testing.T) {
+	ts, _ := countingServer(t, guuidd.WithAPIKeys(map[string]string{"k": "team"}))
+
+	if _, err := New(ts.URL, WithAPIKey("k"), WithFallback(nil)).New(); err != nil {
+		t.Errorf("New() with key error = %v", err)
+	}
+	if _, err := New(ts.URL, WithAPIKey("bad"), WithFallback(nil)).New(); err == nil {
+		t.Error("New() with bad key expected error")
+	}
+}
+
+func TestClient_OutageBackoff(t *testing.T) {
+	var requests atomic.Int64
+	var down atomic.Bool
+	down.Store(true)
+	srv := guuidd.New()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if down.Load() {
+			time.Sleep(50 * time.Millisecond)
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	const backoff = 100 * time.Millisecond
+	c := New(ts.URL, WithBatchSize(10), WithFallback(guuid.NewGenerator()), WithRetryBackoff(backoff, time.Second))
+
+	// Concurrent callers share one failing fetch
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.New(); err != nil {
+				t.Errorf("New() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests during outage = %d, want 1", n)
+	}
+
+	// Within the backoff, calls fall back without contacting the service
+	for i := 0; i < 100; i++ {
+		c.New()
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests within backoff = %d, want 1", n)
+	}
+	if c.Fallbacks() != 120 {
+		t.Errorf("Fallbacks() = %d, want 120", c.Fallbacks())
+	}
+
+	// Once the backoff has passed the service is tried again
+	down.Store(false)
+	time.Sleep(backoff)
+	if _, err := c.New(); err != nil {
+		t.Fatalf("New() after recovery error = %v", err)
+	}
+	if n := requests.Load(); n != 2 || c.Fallbacks() != 120 {
+		t.Errorf("after recovery: requests = %d, fallbacks = %d, want 2 and 120", n, c.Fallbacks())
+	}
+}
+
+func TestClient_OutageWithoutFallback(t *testing.T) {
+	ts, _ := countingServer(t)
+	ts.Close()
+
+	c := New(ts.URL, WithFallback(nil), WithRetryBackoff(time.Hour, time.Hour))
+	_, first := c.New()
+	_, second := c.New()
+	if first == nil || second == nil || second != first {
+		t.Errorf("New() errors = %v, %v, want the fetch error repeated during backoff", first, second)
+	}
+}
+
+func TestClient_RejectedNotFallback(t *testing.T) {
+	ts, requests := countingServer(t, guuidd.WithAPIKeys(map[string]string{"k": "team"}))
+	c := New(ts.URL, WithAPIKey("revoked"), WithFallback(guuid.NewGenerator()))
+
+	_, err := c.New()
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("New() with bad key error = %v, want 401 StatusError", err)
+	}
+	if _, again := c.New(); again != err || requests.Load() != 1 {
+		t.Errorf("New() within backoff = %v after %d requests, want the same error and 1 request", again, requests.Load())
+	}
+	if c.Fallbacks() != 0 {
+		t.Errorf("Fallbacks() = %d, want 0 for a rejected key", c.Fallbacks())
+	}
+}
+
+func TestClient_QuotaRetryAfter(t *testing.T) {
+	ts, requests := countingServer(t, guuidd.WithQuota(guuidd.Quota{Rate: 1, Burst: 10}))
+	c := New(ts.URL, WithBatchSize(10), WithFallback(guuid.NewGenerator()))
+
+	for i := 0; i < 10; i++ {
+		if _, err := c.New(); err != nil {
+			t.Fatalf("New() #%d error = %v", i, err)
+		}
+	}
+	_, err := c.New()
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusTooManyRequests || se.RetryAfter <= 0 {
+		t.Fatalf("New() over quota error = %v, want 429 StatusError with RetryAfter", err)
+	}
+
+	// No requests until Retry-After has passed, and no local IDs meanwhile
+	n := requests.Load()
+	for i := 0; i < 5; i++ {
+		if _, err := c.New(); !errors.As(err, &se) {
+			t.Errorf("New() within Retry-After error = %v, want StatusError", err)
+		}
+	}
+	if requests.Load() != n || c.Fallbacks() != 0 {
+		t.Errorf("within Retry-After: %d new requests, %d fallbacks, want 0 each", requests.Load()-n, c.Fallbacks())
+	}
+}
@@ -0,0 +1,53 @@
+package guuidd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// errDraining is reported by /readyz once Drain has been called
+var errDraining = errors.New("server is draining")
+
+// Drain marks the server as shutting down: /readyz starts failing so load
+// balancers stop routing new requests here, and open streams end after their
+// current ID so that http.Server.Shutdown is not held up by them. Requests for
+// single IDs and batches are still served until the listener closes.
+func (s *Server) Drain() {
+	s.drainOnce.Do(func() { close(s.drained) })
+}
+
+// Draining reports whether Drain has been called
+func (s *Server) Draining() bool {
+	select {
+	case <-s.drained:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkDrain is the readiness check that fails while draining
+func (s *Server) checkDrain(context.Context) error {
+	if s.Draining() {
+		return errDraining
+	}
+	return nil
+}
+
+// Shutdown gracefully stops hs, which serves s: it drains s, waits delay for
+// load balancers to notice the failing readiness probe, then lets in-flight
+// requests finish for up to grace before closing the remaining connections.
+func Shutdown(hs *http.Server, s *Server, delay, grace time.Duration) error {
+	s.Drain()
+	time.Sleep(delay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if err := hs.Shutdown(ctx); err != nil {
+		hs.Close()
+		return err
+	}
+	return nil
+}
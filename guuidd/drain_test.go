@@ -0,0 +1,61 @@
+package guuidd
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestServer_Drain(t *testing.T) {
+	srv := New()
+	if rec := get(t, srv, "/readyz", ""); rec.Code != http.StatusOK {
+		t.Fatalf("/readyz status = %d before Drain, want %d", rec.Code, http.StatusOK)
+	}
+
+	srv.Drain()
+	srv.Drain() // idempotent
+	if !srv.Draining() {
+		t.Error("Draining() = false after Drain")
+	}
+	if rec := get(t, srv, "/readyz", ""); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz status = %d while draining, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if rec := get(t, srv, "/healthz", ""); rec.Code != http.StatusOK {
+		t.Errorf("/healthz status = %d while draining, want %d", rec.Code, http.StatusOK)
+	}
+	if rec := get(t, srv, "/v1/uuid", ""); rec.Code != http.StatusOK {
+		t.Errorf("/v1/uuid status = %d while draining, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestShutdown_EndsStreams(t *testing.T) {
+	srv := New()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs := &http.Server{Handler: srv}
+	go hs.Serve(l)
+
+	resp, err := http.Get("http://" + l.Addr().String() + "/v1/uuid/stream?interval=10ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	sc := bufio.NewScanner(resp.Body)
+	if !sc.Scan() {
+		t.Fatal("stream sent nothing")
+	}
+
+	start := time.Now()
+	if err := Shutdown(hs, srv, 0, 5*time.Second); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Shutdown() took %v, want open streams to end promptly", elapsed)
+	}
+	for sc.Scan() {
+	}
+}
@@ -0,0 +1,43 @@
+package guuidd
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Output formats accepted in the format query parameter
+const (
+	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+	formatSSE    = "sse"
+)
+
+// mediaTypes maps Accept media types to output formats
+var mediaTypes = map[string]string{
+	"application/json":     formatJSON,
+	"text/csv":             formatCSV,
+	"application/x-ndjson": formatNDJSON,
+	"application/ndjson":   formatNDJSON,
+	"text/event-stream":    formatSSE,
+}
+
+// negotiate picks the output format from the format parameter or the Accept
+// header, restricted to allowed. It returns "" if an explicit request names an
+// unsupported format.
+func negotiate(r *http.Request, def string, allowed ...string) string {
+	if f := r.URL.Query().Get("format"); f != "" {
+		if slices.Contains(allowed, f) {
+			return f
+		}
+		return ""
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mt, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if f, found := mediaTypes[mt]; found && slices.Contains(allowed, f) {
+			return f
+		}
+	}
+	return def
+}
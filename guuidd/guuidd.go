@@ -0,0 +1,335 @@
+// Package guuidd implements an HTTP service that hands out UUIDv7s from a
+// single generator, for consumers that cannot embed the library.
+//
+// Endpoints:
+//
+//	GET /v1/uuid                 one ID as {"id": "..."}
+//	GET /v1/uuid/batch?n=100     n IDs; format=json (default), csv or ndjson
+//	GET /v1/uuid/stream          IDs pushed continuously; format=sse (default) or ndjson
+//	GET /healthz                 liveness: entropy source
+//	GET /readyz                  readiness: liveness, clock sanity and WithReadinessCheck checks
+//
+// The stream endpoint accepts n (stop after n IDs) and interval (a
+// time.ParseDuration delay between IDs). Streams end after DefaultMaxStream IDs
+// when n is absent or 0, and are never sent faster than one ID per
+// DefaultStreamInterval; WithStreamLimits changes both. The format may also be
+// selected with an Accept header of application/json, text/csv,
+// application/x-ndjson or text/event-stream.
+//
+// WithAPIKeys requires callers to authenticate, and WithQuota and
+// WithClientQuota cap how many IDs each client may draw per second. Over-quota
+// requests get 429 Too Many Requests; streams are slowed to the quota rate.
+// A batch larger than the client's quota burst gets 400 Bad Request, like one
+// larger than WithMaxBatch, since waiting would never let it through.
+// The health endpoints never require an API key, so they can back Kubernetes
+// probes; they answer 200 with a JSON summary, or 503 naming the failed checks.
+// Drain and Shutdown take the server out of rotation before it stops.
+package guuidd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// DefaultMaxBatch is the default upper bound on n for the batch endpoint
+const DefaultMaxBatch = 10000
+
+// DefaultMaxStream is the default upper bound on n for the stream endpoint
+const DefaultMaxStream = 100000
+
+// DefaultStreamInterval is the default minimum delay between streamed IDs
+const DefaultStreamInterval = time.Millisecond
+
+// Server is an http.Handler serving the ID endpoints
+type Server struct {
+	gen      *guuid.Generator
+	maxBatch int
+	mux      *http.ServeMux
+
+	maxStream      int           // 0 for unlimited
+	streamInterval time.Duration // minimum delay between streamed IDs
+
+	apiKeys map[string]string // API key -> client name; nil disables auth
+	quotas  quotas
+
+	readiness    []namedCheck
+	maxClockLead time.Duration
+
+	drainOnce sync.Once
+	drained   chan struct{} // closed by Drain
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithGenerator sets the generator IDs are drawn from (default guuid.Default())
+func WithGenerator(gen *guuid.Generator) Option {
+	return func(s *Server) {
+		s.gen = gen
+	}
+}
+
+// WithMaxBatch sets the largest n accepted by the batch endpoint
+func WithMaxBatch(n int) Option {
+	return func(s *Server) {
+		s.maxBatch = n
+	}
+}
+
+// WithStreamLimits sets the largest n accepted by the stream endpoint, which
+// is also the length of a stream that does not give n, and the minimum delay
+// between streamed IDs; shorter requested intervals are raised to it. A
+// maxIDs or minInterval of 0 removes that limit.
+func WithStreamLimits(maxIDs int, minInterval time.Duration) Option {
+	return func(s *Server) {
+		s.maxStream = maxIDs
+		s.streamInterval = minInterval
+	}
+}
+
+// New returns a Server configured by opts
+func New(opts ...Option) *Server {
+	s := &Server{
+		maxBatch:       DefaultMaxBatch,
+		maxStream:      DefaultMaxStream,
+		streamInterval: DefaultStreamInterval,
+		maxClockLead:   DefaultMaxClockLead,
+		drained:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.gen == nil {
+		s.gen = guuid.Default()
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/v1/uuid", s.handleOne)
+	s.mux.HandleFunc("/v1/uuid/batch", s.handleBatch)
+	s.mux.HandleFunc("/v1/uuid/stream", s.handleStream)
+	s.mux.HandleFunc("/healthz", s.handleHealthz)
+	s.mux.HandleFunc("/readyz", s.handleReadyz)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.apiKeys != nil && !isProbe(r) {
+		client, ok := s.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="guuidd"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		r = r.WithContext(context.WithValue(r.Context(), clientKey{}, client))
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// isProbe reports whether r targets a health endpoint
+func isProbe(r *http.Request) bool {
+	return r.URL.Path == "/healthz" || r.URL.Path == "/readyz"
+}
+
+// idResponse is the JSON body for a single ID
+type idResponse struct {
+	ID guuid.UUID `json:"id"`
+}
+
+// batchResponse is the JSON body for a batch of IDs
+type batchResponse struct {
+	IDs []guuid.UUID `json:"ids"`
+}
+
+// handleOne serves GET /v1/uuid
+func (s *Server) handleOne(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) || !s.charge(w, r, 1) {
+		return
+	}
+	id, err := s.gen.New()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, idResponse{ID: id})
+}
+
+// handleBatch serves GET /v1/uuid/batch
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	n, err := strconv.Atoi(r.URL.Query().Get("n"))
+	if err != nil || n < 1 || n > s.maxBatch {
+		http.Error(w, "n must be between 1 and "+strconv.Itoa(s.maxBatch), http.StatusBadRequest)
+		return
+	}
+	format := negotiate(r, formatJSON, formatJSON, formatCSV, formatNDJSON)
+	if format == "" {
+		http.Error(w, "unsupported format", http.StatusNotAcceptable)
+		return
+	}
+	if !s.charge(w, r, n) {
+		return
+	}
+
+	ids := make([]guuid.UUID, n)
+	for i := range ids {
+		if ids[i], err = s.gen.New(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	switch format {
+	case formatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+		enc := guuid.NewEncoder(w)
+		w.Write([]byte("id\n"))
+		enc.EncodeAll(ids)
+		enc.Flush()
+	case formatNDJSON:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+		for _, id := range ids {
+			if enc.Encode(idResponse{ID: id}) != nil {
+				return
+			}
+		}
+	default:
+		writeJSON(w, batchResponse{IDs: ids})
+	}
+}
+
+// handleStream serves GET /v1/uuid/stream until the client disconnects or n IDs are sent
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	q := r.URL.Query()
+	limit := 0
+	if v := q.Get("n"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 || (s.maxStream > 0 && limit > s.maxStream) {
+			http.Error(w, "invalid n", http.StatusBadRequest)
+			return
+		}
+	}
+	if limit == 0 {
+		limit = s.maxStream
+	}
+	var interval time.Duration
+	if v := q.Get("interval"); v != "" {
+		var err error
+		if interval, err = time.ParseDuration(v); err != nil || interval < 0 {
+			http.Error(w, "invalid interval", http.StatusBadRequest)
+			return
+		}
+	}
+	if interval < s.streamInterval {
+		interval = s.streamInterval
+	}
+	format := negotiate(r, formatSSE, formatSSE, formatNDJSON)
+	if format == "" {
+		http.Error(w, "unsupported format", http.StatusNotAcceptable)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	if format == formatSSE {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+
+	var ticker *time.Ticker
+	if interval > 0 {
+		ticker = time.NewTicker(interval)
+		defer ticker.Stop()
+	}
+
+	ctx := r.Context()
+	for sent := 0; limit == 0 || sent < limit; sent++ {
+		if ticker != nil && sent > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.drained:
+				return
+			case <-ticker.C:
+			}
+		} else if ctx.Err() != nil || s.Draining() {
+			return
+		}
+		if !s.pace(ctx, r) {
+			return
+		}
+
+		id, err := s.gen.New()
+		if err != nil {
+			return
+		}
+		var line string
+		if format == formatSSE {
+			line = "id: " + strconv.Itoa(sent) + "\ndata: " + id.String() + "\n\n"
+		} else {
+			line = `{"id":"` + id.String() + "\"}\n"
+		}
+		if _, err := w.Write([]byte(line)); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// pace blocks until the client's quota allows one more streamed ID. It
+// returns false if the request is cancelled or the quota can never refill.
+func (s *Server) pace(ctx context.Context, r *http.Request) bool {
+	if !s.quotas.enabled() {
+		return true
+	}
+	client := quotaKey(r)
+	for {
+		ok, wait := s.quotas.take(client, 1, time.Now())
+		if ok {
+			return true
+		}
+		if wait < 0 {
+			return false
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+}
+
+// allowGet rejects non-GET requests with 405
+func allowGet(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", "GET, HEAD")
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	return false
+}
+
+// writeJSON writes v as a JSON response body
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
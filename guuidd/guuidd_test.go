@@ -0,0 +1,187 @@
+package guuidd
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func get(t *testing.T, h http.Handler, target, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_One(t *testing.T) {
+	rec := get(t, New(), "/v1/uuid", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var body idResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if body.ID.Version() != guuid.VersionTimeSorted {
+		t.Errorf("id version = %v, want %v", body.ID.Version(), guuid.VersionTimeSorted)
+	}
+}
+
+func TestServer_Batch(t *testing.T) {
+	srv := New(WithMaxBatch(100))
+
+	tests := []struct {
+		name     string
+		target   string
+		accept   string
+		wantType string
+		parse    func(string) []string
+	}{
+		{"json", "/v1/uuid/batch?n=5", "", "application/json", func(s string) []string {
+			var b struct{ IDs []string }
+			json.Unmarshal([]byte(s), &b)
+			return b.IDs
+		}},
+		{"csv", "/v1/uuid/batch?n=5&format=csv", "", "text/csv", func(s string) []string {
+			lines := strings.Split(strings.TrimSpace(s), "\n")
+			if lines[0] != "id" {
+				return nil
+			}
+			return lines[1:]
+		}},
+		{"ndjson via accept", "/v1/uuid/batch?n=5", "application/x-ndjson", "application/x-ndjson", func(s string) []string {
+			var ids []string
+			for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+				var b struct{ ID string }
+				json.Unmarshal([]byte(line), &b)
+				ids = append(ids, b.ID)
+			}
+			return ids
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(t, srv, tt.target, tt.accept)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+			ids := tt.parse(rec.Body.String())
+			if len(ids) != 5 {
+				t.Fatalf("got %d IDs, want 5", len(ids))
+			}
+			for _, s := range ids {
+				if _, err := guuid.Parse(s); err != nil {
+					t.Errorf("Parse(%q) error = %v", s, err)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_BatchErrors(t *testing.T) {
+	srv := New(WithMaxBatch(10))
+
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"/v1/uuid/batch", http.StatusBadRequest},
+		{"/v1/uuid/batch?n=0", http.StatusBadRequest},
+		{"/v1/uuid/batch?n=11", http.StatusBadRequest},
+		{"/v1/uuid/batch?n=1&format=xml", http.StatusNotAcceptable},
+		{"/v1/uuid/stream?interval=soon", http.StatusBadRequest},
+		{"/v1/uuid/stream?n=-1", http.StatusBadRequest},
+		{"/v1/uuid/stream?n=100001", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		if rec := get(t, srv, tt.target, ""); rec.Code != tt.want {
+			t.Errorf("GET %s status = %d, want %d", tt.target, rec.Code, tt.want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/uuid", nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /v1/uuid status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestServer_Stream(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/v1/uuid/stream?n=3&interval=1ms")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+
+	var ids []guuid.UUID
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		if data, ok := strings.CutPrefix(sc.Text(), "data: "); ok {
+			ids = append(ids, guuid.MustParse(data))
+		}
+	}
+	if len(ids) != 3 {
+		t.Fatalf("stream sent %d IDs, want 3", len(ids))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i].Compare(ids[i-1]) <= 0 {
+			t.Errorf("stream IDs not ordered at %d", i)
+		}
+	}
+}
+
+func TestServer_StreamLimits(t *testing.T) {
+	const minInterval = 20 * time.Millisecond
+	ts := httptest.NewServer(New(WithStreamLimits(3, minInterval)))
+	defer ts.Close()
+
+	// Without n the stream stops at the cap, paced by the minimum interval
+	start := time.Now()
+	resp, err := http.Get(ts.URL + "/v1/uuid/stream?format=ndjson&interval=0s")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+	lines := 0
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		lines++
+	}
+	if lines != 3 {
+		t.Errorf("stream sent %d IDs, want 3", lines)
+	}
+	if elapsed := time.Since(start); elapsed < 2*minInterval {
+		t.Errorf("stream took %v, want at least %v", elapsed, 2*minInterval)
+	}
+
+	over, err := http.Get(ts.URL + "/v1/uuid/stream?n=4")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	over.Body.Close()
+	if over.StatusCode != http.StatusBadRequest {
+		t.Errorf("n above the cap status = %d, want %d", over.StatusCode, http.StatusBadRequest)
+	}
+}
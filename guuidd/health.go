@@ -0,0 +1,113 @@
+package guuidd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxClockLead is the default largest lead of the generator over the
+// wall clock before the service reports itself not ready
+const DefaultMaxClockLead = time.Second
+
+// minClock is the earliest plausible wall-clock time; anything earlier means
+// the host clock was never set
+var minClock = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Check reports whether a dependency is usable; it should honor ctx
+type Check func(ctx context.Context) error
+
+// namedCheck is a readiness check registered with WithReadinessCheck
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// WithReadinessCheck adds a check to /readyz, e.g. connectivity to a
+// coordination backend. Checks run in registration order.
+func WithReadinessCheck(name string, check Check) Option {
+	return func(s *Server) {
+		s.readiness = append(s.readiness, namedCheck{name, check})
+	}
+}
+
+// WithMaxClockLead sets how far the generator may run ahead of the wall clock,
+// through counter overflow or a backwards clock step, before /readyz fails.
+// The generator catches up on its own, so a lead never fails /healthz.
+func WithMaxClockLead(d time.Duration) Option {
+	return func(s *Server) {
+		s.maxClockLead = d
+	}
+}
+
+// healthResponse is the JSON body of /healthz and /readyz
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// handleHealthz serves GET /healthz, the liveness probe: entropy only
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if allowGet(w, r) {
+		s.writeHealth(w, s.liveChecks())
+	}
+}
+
+// handleReadyz serves GET /readyz, the readiness probe: the liveness checks,
+// the clock, every WithReadinessCheck and whether the server is draining
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !allowGet(w, r) {
+		return
+	}
+	results := append(s.liveChecks(),
+		checkResult{"clock", s.checkClock(time.Now())},
+		checkResult{"drain", s.checkDrain(r.Context())})
+	for _, c := range s.readiness {
+		results = append(results, checkResult{c.name, c.check(r.Context())})
+	}
+	s.writeHealth(w, results)
+}
+
+// checkResult is the outcome of one check
+type checkResult struct {
+	name string
+	err  error
+}
+
+// liveChecks verifies the generator's entropy source, the one failure a
+// restart can fix. A bad or lagging clock only makes the server unready, so
+// that it is taken out of rotation rather than restarted in a loop.
+func (s *Server) liveChecks() []checkResult {
+	return []checkResult{{"entropy", s.gen.HealthCheck()}}
+}
+
+// checkClock fails if the wall clock is implausible or the generator runs
+// too far ahead of it
+func (s *Server) checkClock(now time.Time) error {
+	if now.Before(minClock) {
+		return fmt.Errorf("wall clock %s is before %s", now.Format(time.RFC3339), minClock.Format(time.RFC3339))
+	}
+	if lead := s.gen.Lead(); lead > s.maxClockLead {
+		return fmt.Errorf("generator runs %v ahead of the wall clock", lead)
+	}
+	return nil
+}
+
+// writeHealth writes results as a healthResponse, with status 503 if any failed
+func (s *Server) writeHealth(w http.ResponseWriter, results []checkResult) {
+	resp := healthResponse{Status: "ok", Checks: make(map[string]string, len(results))}
+	for _, res := range results {
+		if res.err != nil {
+			resp.Status = "fail"
+			resp.Checks[res.name] = res.err.Error()
+		} else {
+			resp.Checks[res.name] = "ok"
+		}
+	}
+	if resp.Status != "ok" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	writeJSON(w, resp)
+}
@@ -0,0 +1,72 @@
+package guuidd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// failReader is an entropy source that always fails
+type failReader struct{}
+
+func (failReader) Read([]byte) (int, error) { return 0, errors.New("no entropy") }
+
+func TestServer_Health(t *testing.T) {
+	backendErr := errors.New("backend unreachable")
+	var backendDown bool
+	backend := func(context.Context) error {
+		if backendDown {
+			return backendErr
+		}
+		return nil
+	}
+	ahead := guuid.NewGenerator()
+	guuid.Must(ahead.NewWithTime(time.Now().Add(time.Minute)))
+
+	tests := []struct {
+		name       string
+		srv        *Server
+		down       bool
+		target     string
+		wantStatus int
+		wantFailed string
+	}{
+		{"healthy liveness", New(WithReadinessCheck("backend", backend)), false, "/healthz", http.StatusOK, ""},
+		{"healthy readiness", New(WithReadinessCheck("backend", backend)), false, "/readyz", http.StatusOK, ""},
+		{"backend down liveness", New(WithReadinessCheck("backend", backend)), true, "/healthz", http.StatusOK, ""},
+		{"backend down readiness", New(WithReadinessCheck("backend", backend)), true, "/readyz", http.StatusServiceUnavailable, "backend"},
+		{"entropy", New(WithGenerator(guuid.NewGeneratorWithReader(failReader{}))), false, "/healthz", http.StatusServiceUnavailable, "entropy"},
+		{"clock lead", New(WithGenerator(ahead)), false, "/readyz", http.StatusServiceUnavailable, "clock"},
+		{"clock lead liveness", New(WithGenerator(ahead)), false, "/healthz", http.StatusOK, ""},
+		{"auth bypass", New(WithAPIKeys(map[string]string{"k": "c"})), false, "/healthz", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		backendDown = tt.down
+		rec := get(t, tt.srv, tt.target, "")
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.wantStatus)
+		}
+		var body healthResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: Unmarshal() error = %v", tt.name, err)
+		}
+		if tt.wantFailed != "" && body.Checks[tt.wantFailed] == "ok" {
+			t.Errorf("%s: checks = %v, want %s failed", tt.name, body.Checks, tt.wantFailed)
+		}
+	}
+}
+
+func TestServer_CheckClock(t *testing.T) {
+	srv := New()
+	if err := srv.checkClock(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("checkClock() accepted a 1970 wall clock")
+	}
+	if err := srv.checkClock(time.Now()); err != nil {
+		t.Errorf("checkClock() error = %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package guuidd
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Quota limits how many IDs a client may draw: Rate IDs per second sustained,
+// with bursts of up to Burst IDs. A Burst of zero or less allows one second
+// of Rate, and at least one ID.
+//
+// Burst also caps a single request: like n above WithMaxBatch, a batch larger
+// than the client's Burst can never be served and is rejected with 400 Bad
+// Request rather than 429.
+type Quota struct {
+	Rate  float64
+	Burst int
+}
+
+// normalized returns q with the default Burst applied
+func (q Quota) normalized() Quota {
+	if q.Burst <= 0 {
+		q.Burst = max(int(q.Rate), 1)
+	}
+	return q
+}
+
+// WithQuota applies q to every client that has no quota of its own
+func WithQuota(q Quota) Option {
+	return func(s *Server) {
+		q = q.normalized()
+		s.quotas.def = &q
+	}
+}
+
+// WithClientQuota applies q to the named client, overriding WithQuota
+func WithClientQuota(client string, q Quota) Option {
+	return func(s *Server) {
+		if s.quotas.perClient == nil {
+			s.quotas.perClient = make(map[string]Quota)
+		}
+		s.quotas.perClient[client] = q.normalized()
+	}
+}
+
+// quotaSweepInterval is how often buckets of idle clients are dropped
+const quotaSweepInterval = time.Minute
+
+// quotas tracks one token bucket per client
+type quotas struct {
+	def       *Quota
+	perClient map[string]Quota
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time // last sweep of idle buckets
+}
+
+// enabled reports whether any quota is configured
+func (q *quotas) enabled() bool {
+	return q.def != nil || len(q.perClient) > 0
+}
+
+// quota returns the quota that applies to client, if any
+func (q *quotas) quota(client string) (Quota, bool) {
+	if quota, ok := q.perClient[client]; ok {
+		return quota, true
+	}
+	if q.def == nil {
+		return Quota{}, false
+	}
+	return *q.def, true
+}
+
+// take tries to withdraw n IDs for client. When the bucket is short it
+// returns false and how long until n IDs become available.
+func (q *quotas) take(client string, n int, now time.Time) (bool, time.Duration) {
+	quota, ok := q.quota(client)
+	if !ok {
+		return true, 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.buckets == nil {
+		q.buckets = make(map[string]*bucket)
+	}
+	if now.Sub(q.swept) >= quotaSweepInterval {
+		q.sweep(now)
+	}
+	b := q.buckets[client]
+	if b == nil {
+		b = &bucket{tokens: float64(quota.Burst), last: now}
+		q.buckets[client] = b
+	}
+	return b.take(quota, n, now)
+}
+
+// sweep drops the buckets that have refilled completely, which behave like
+// the fresh bucket a returning client gets. It must be called with q.mu held.
+func (q *quotas) sweep(now time.Time) {
+	q.swept = now
+	for client, b := range q.buckets {
+		quota, ok := q.quota(client)
+		if !ok || b.full(quota, now) {
+			delete(q.buckets, client)
+		}
+	}
+}
+
+// bucket is a token bucket refilled continuously at the quota rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// full reports whether the bucket would be at its Burst by now
+func (b *bucket) full(q Quota, now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*q.Rate >= float64(q.Burst)
+}
+
+// take withdraws n tokens if available
+func (b *bucket) take(q Quota, n int, now time.Time) (bool, time.Duration) {
+	b.tokens = math.Min(float64(q.Burst), b.tokens+now.Sub(b.last).Seconds()*q.Rate)
+	b.last = now
+	if float64(n) <= b.tokens {
+		b.tokens -= float64(n)
+		return true, 0
+	}
+	if n > q.Burst || q.Rate <= 0 {
+		return false, -1
+	}
+	wait := time.Duration((float64(n) - b.tokens) / q.Rate * float64(time.Second))
+	return false, wait
+}
+
+// charge withdraws n IDs from the request's quota, writing a 429 response and
+// returning false when the client is over its limit, or a 400 response if n
+// exceeds the client's burst and so can never be served.
+func (s *Server) charge(w http.ResponseWriter, r *http.Request, n int) bool {
+	if !s.quotas.enabled() {
+		return true
+	}
+	client := quotaKey(r)
+	if quota, ok := s.quotas.quota(client); ok && n > quota.Burst {
+		http.Error(w, "n must not exceed the quota burst of "+strconv.Itoa(quota.Burst), http.StatusBadRequest)
+		return false
+	}
+	ok, wait := s.quotas.take(client, n, time.Now())
+	if ok {
+		return true
+	}
+	if wait >= 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	}
+	http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	return false
+}
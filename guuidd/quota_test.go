@@ -0,0 +1,94 @@
+package guuidd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBucket_Take(t *testing.T) {
+	q := Quota{Rate: 10, Burst: 5}
+	now := time.Unix(0, 0)
+	b := &bucket{tokens: 5, last: now}
+
+	if ok, _ := b.take(q, 5, now); !ok {
+		t.Fatal("take(5) on full bucket = false, want true")
+	}
+	ok, wait := b.take(q, 1, now)
+	if ok || wait != 100*time.Millisecond {
+		t.Errorf("take(1) on empty bucket = %v, %v, want false, 100ms", ok, wait)
+	}
+	if ok, _ := b.take(q, 1, now.Add(100*time.Millisecond)); !ok {
+		t.Error("take(1) after refill = false, want true")
+	}
+	if ok, wait := b.take(q, 6, now.Add(time.Hour)); ok || wait >= 0 {
+		t.Errorf("take(6) above burst = %v, %v, want false, negative wait", ok, wait)
+	}
+}
+
+func TestServer_Quota(t *testing.T) {
+	srv := New(
+		WithAPIKeys(map[string]string{"a": "team-a", "b": "team-b"}),
+		WithQuota(Quota{Rate: 0.001, Burst: 10}),
+		WithClientQuota("team-b", Quota{Rate: 0.001, Burst: 100}),
+	)
+
+	request := func(key, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := request("a", "/v1/uuid/batch?n=10"); rec.Code != http.StatusOK {
+		t.Fatalf("first batch status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	rec := request("a", "/v1/uuid")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("over-quota status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("over-quota response missing Retry-After")
+	}
+
+	// team-b has its own, larger bucket
+	if rec := request("b", "/v1/uuid/batch?n=50"); rec.Code != http.StatusOK {
+		t.Errorf("team-b batch status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestServer_QuotaDefaultBurst(t *testing.T) {
+	srv := New(WithQuota(Quota{Rate: 10}))
+	for i := 0; i < 3; i++ {
+		if rec := get(t, srv, "/v1/uuid", ""); rec.Code != http.StatusOK {
+			t.Fatalf("GET /v1/uuid #%d status = %d, want %d", i, rec.Code, http.StatusOK)
+		}
+	}
+	if rec := get(t, srv, "/v1/uuid/batch?n=10", ""); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("batch of the default burst status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if rec := get(t, srv, "/v1/uuid/batch?n=11", ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("batch above the burst status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestQuotas_Sweep(t *testing.T) {
+	q := quotas{def: &Quota{Rate: 10, Burst: 10}}
+	now := time.Unix(0, 0)
+	q.swept = now
+	q.take("idle", 10, now)
+	q.take("busy", 10, now)
+
+	// After a sweep interval idle has refilled, while busy keeps drawing
+	later := now.Add(quotaSweepInterval)
+	q.buckets["busy"].tokens, q.buckets["busy"].last = 0, later
+	q.take("other", 1, later)
+	if _, ok := q.buckets["idle"]; ok {
+		t.Error("sweep kept the bucket of an idle client")
+	}
+	if _, ok := q.buckets["busy"]; !ok {
+		t.Error("sweep dropped the bucket of a busy client")
+	}
+}
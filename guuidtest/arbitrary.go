@@ -0,0 +1,154 @@
+// Package guuidtest provides helpers for testing code that handles guuid UUIDs.
+package guuidtest
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// Source supplies random 64-bit values. *math/rand.Rand satisfies it; with
+// pgregory.net/rapid, adapt a draw function:
+//
+//	type rapidSource struct{ t *rapid.T }
+//	func (s rapidSource) Uint64() uint64 { return rapid.Uint64().Draw(s.t, "u64") }
+//
+//	id := rapid.Custom(func(t *rapid.T) guuid.UUID {
+//	    return guuidtest.Arbitrary(rapidSource{t})
+//	})
+type Source interface {
+	Uint64() uint64
+}
+
+// Max is the UUID with all bits set.
+//
+// Deprecated: Use guuid.MaxUUID.
+var Max = guuid.MaxUUID
+
+// EdgeCases are UUIDs at layout boundaries that handling code should survive
+var EdgeCases = []guuid.UUID{
+	guuid.Nil,
+	guuid.MaxUUID,
+	{6: 0x70, 8: 0x80}, // smallest v7
+	{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 0xff, 0xbf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, // largest v7
+	{6: 0x40, 8: 0x7f}, // NCS variant boundary
+	{6: 0x40, 8: 0xc0}, // Microsoft variant
+	{6: 0xf0, 8: 0xe0}, // undefined version, future variant
+}
+
+// Arbitrary returns 16 random bytes, returning an edge case one time in eight
+func Arbitrary(src Source) guuid.UUID {
+	r := src.Uint64()
+	if r%8 == 0 {
+		return EdgeCases[(r/8)%uint64(len(EdgeCases))]
+	}
+	var u guuid.UUID
+	binary.BigEndian.PutUint64(u[0:8], src.Uint64())
+	binary.BigEndian.PutUint64(u[8:16], src.Uint64())
+	return u
+}
+
+// ArbitraryV7 returns a well-formed UUIDv7 with a random timestamp and random fields
+func ArbitraryV7(src Source) guuid.UUID {
+	var rb [8]byte
+	binary.BigEndian.PutUint64(rb[:], src.Uint64())
+	r := src.Uint64()
+	u, _ := guuid.NewV7FromParts(int64(r>>16), uint16(r)&0x0fff, rb)
+	return u
+}
+
+// ArbitraryString returns a valid string form of an arbitrary UUID in one of
+// the formats accepted by guuid.Parse, in random letter case
+func ArbitraryString(src Source) string {
+	u := Arbitrary(src)
+	s := u.String()
+	r := src.Uint64()
+	if r&1 == 1 {
+		s = strings.ToUpper(s)
+	}
+	switch (r >> 1) % 4 {
+	case 1:
+		return "urn:uuid:" + s
+	case 2:
+		return "{" + s + "}"
+	case 3:
+		return strings.ReplaceAll(s, "-", "")
+	default:
+		return s
+	}
+}
+
+// ArbitraryInvalidString returns a string that guuid.Parse must reject
+func ArbitraryInvalidString(src Source) string {
+	s := Arbitrary(src).String()
+	r := src.Uint64()
+	pos := int((r >> 8) % uint64(len(s)))
+	switch r % 6 {
+	case 0: // truncated
+		return s[:pos]
+	case 1: // extended
+		return s + s[:1+pos%8]
+	case 2: // non-hex digit
+		if s[pos] == '-' {
+			pos++
+		}
+		return s[:pos] + "g" + s[pos+1:]
+	case 3: // misplaced hyphen
+		return s[:8] + s[9:13] + "-" + s[13:]
+	case 4: // wrong separator
+		return strings.ReplaceAll(s, "-", "_")
+	default:
+		return ""
+	}
+}
+
+// ArbitraryBytes returns a byte slice that is 16 bytes long three times in
+// four and an invalid length otherwise
+func ArbitraryBytes(src Source) []byte {
+	u := Arbitrary(src)
+	r := src.Uint64()
+	if r%4 != 0 {
+		return u[:]
+	}
+	n := int((r >> 2) % 33)
+	if n == 16 {
+		n = 17
+	}
+	b := make([]byte, n)
+	copy(b, u[:])
+	return b
+}
+
+// UUID is an arbitrary guuid.UUID that implements testing/quick.Generator
+type UUID struct{ guuid.UUID }
+
+// Generate implements quick.Generator
+func (UUID) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(UUID{Arbitrary(r)})
+}
+
+// V7 is an arbitrary well-formed UUIDv7 that implements testing/quick.Generator
+type V7 struct{ guuid.UUID }
+
+// Generate implements quick.Generator
+func (V7) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(V7{ArbitraryV7(r)})
+}
+
+// String is an arbitrary valid or invalid UUID string that implements
+// testing/quick.Generator. Valid reports which kind was generated.
+type String struct {
+	S     string
+	Valid bool
+}
+
+// Generate implements quick.Generator
+func (String) Generate(r *rand.Rand, _ int) reflect.Value {
+	if r.Intn(2) == 0 {
+		return reflect.ValueOf(String{S: ArbitraryInvalidString(r)})
+	}
+	return reflect.ValueOf(String{S: ArbitraryString(r), Valid: true})
+}
@@ -0,0 +1,58 @@
+package guuidtest
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestQuick_UUIDRoundTrip(t *testing.T) {
+	f := func(u UUID) bool {
+		parsed, err := guuid.Parse(u.String())
+		return err == nil && parsed == u.UUID
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuick_V7WellFormed(t *testing.T) {
+	f := func(u V7) bool {
+		return u.Version() == guuid.VersionTimeSorted && u.Variant() == guuid.VariantRFC4122
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuick_StringValidity(t *testing.T) {
+	f := func(s String) bool {
+		_, err := guuid.Parse(s.S)
+		return (err == nil) == s.Valid
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestArbitraryBytes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	valid, invalid := 0, 0
+	for i := 0; i < 1000; i++ {
+		b := ArbitraryBytes(r)
+		_, err := guuid.FromBytes(b)
+		if (err == nil) != (len(b) == 16) {
+			t.Fatalf("FromBytes(len %d) error = %v", len(b), err)
+		}
+		if len(b) == 16 {
+			valid++
+		} else {
+			invalid++
+		}
+	}
+	if valid == 0 || invalid == 0 {
+		t.Errorf("ArbitraryBytes() produced %d valid and %d invalid slices, want both", valid, invalid)
+	}
+}
@@ -0,0 +1,53 @@
+package guuidtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestFrozenGenerator(t *testing.T) {
+	at := time.UnixMilli(1700000000000)
+	gen := NewFrozenGenerator(at)
+
+	var prev guuid.UUID
+	for i := 0; i < 5000; i++ {
+		id := guuid.Must(gen.New())
+		if i > 0 && id.Compare(prev) <= 0 {
+			t.Fatalf("New() #%d = %v, not after %v", i, id, prev)
+		}
+		prev = id
+	}
+	if got := prev.Timestamp(); got <= at.UnixMilli() {
+		t.Errorf("Timestamp() after counter overflow = %d, want > %d", got, at.UnixMilli())
+	}
+	if !gen.Now().Equal(at) {
+		t.Errorf("Now() = %v, want %v", gen.Now(), at)
+	}
+}
+
+func TestStepGenerator(t *testing.T) {
+	start := time.UnixMilli(1700000000000)
+	gen := NewStepGenerator(start, time.Second)
+
+	for i := int64(0); i < 3; i++ {
+		if got, want := guuid.Must(gen.New()).Timestamp(), start.UnixMilli()+i*1000; got != want {
+			t.Errorf("Timestamp() #%d = %d, want %d", i, got, want)
+		}
+	}
+	gen.Advance(time.Minute)
+	if got, want := guuid.Must(gen.New()).Timestamp(), start.UnixMilli()+63000; got != want {
+		t.Errorf("Timestamp() after Advance = %d, want %d", got, want)
+	}
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	start := time.UnixMilli(1700000000000)
+	a, b := NewStepGenerator(start, time.Millisecond), NewStepGenerator(start, time.Millisecond)
+	for i := 0; i < 10; i++ {
+		if x, y := guuid.Must(a.New()), guuid.Must(b.New()); x != y {
+			t.Fatalf("New() #%d differs: %v != %v", i, x, y)
+		}
+	}
+}
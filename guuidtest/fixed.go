@@ -0,0 +1,24 @@
+package guuidtest
+
+import "github.com/Lzww0608/guuid"
+
+// fixedSource returns the same UUID from every call to New
+type fixedSource guuid.UUID
+
+// New returns the fixed UUID
+func (f fixedSource) New() (guuid.UUID, error) {
+	return guuid.UUID(f), nil
+}
+
+// FixedGenerator returns a Source whose New always returns u, to force a
+// specific ID in tests. It does no work, so it also suits benchmarks of code
+// that draws IDs from a Source.
+func FixedGenerator(u guuid.UUID) guuid.Source {
+	return fixedSource(u)
+}
+
+// NoopGenerator returns a Source whose New always returns guuid.Nil, for
+// benchmarking callers without the cost of UUID generation
+func NoopGenerator() guuid.Source {
+	return fixedSource(guuid.Nil)
+}
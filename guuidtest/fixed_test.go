@@ -0,0 +1,34 @@
+package guuidtest
+
+import (
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestFixedGenerator(t *testing.T) {
+	want := guuid.MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70")
+	src := FixedGenerator(want)
+	for i := 0; i < 3; i++ {
+		if got, err := src.New(); err != nil || got != want {
+			t.Errorf("New() = %v, %v, want %v", got, err, want)
+		}
+	}
+}
+
+func TestNoopGenerator(t *testing.T) {
+	src := NoopGenerator()
+	if got, err := src.New(); err != nil || got != guuid.Nil {
+		t.Errorf("New() = %v, %v, want Nil", got, err)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = src.New() }); allocs != 0 {
+		t.Errorf("New() allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkNoopGenerator(b *testing.B) {
+	src := NoopGenerator()
+	for i := 0; i < b.N; i++ {
+		_, _ = src.New()
+	}
+}
@@ -0,0 +1,135 @@
+package guuidtest
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// ErrGoldenExhausted is returned by a replaying Golden source once every
+// recorded UUID has been handed out
+var ErrGoldenExhausted = errors.New("guuidtest: golden UUID sequence exhausted")
+
+// Golden records the UUIDs produced during a test and replays the same
+// sequence in later runs, for snapshot tests of output that embeds generated
+// IDs. It satisfies guuid.Source.
+type Golden struct {
+	tb     testing.TB
+	path   string
+	src    guuid.Source
+	replay bool
+
+	mu   sync.Mutex
+	ids  []guuid.UUID
+	next int
+}
+
+// NewGolden returns a Golden source backed by the file at path, one UUID per
+// line. If update is set or the file does not exist, IDs are taken from src
+// and written to path when the test finishes; otherwise they are replayed from
+// the file and src is not used. A replay fails the test if it runs out of
+// recorded IDs or does not consume all of them. Tests usually pass a flag:
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//
+//	ids := guuidtest.NewGolden(t, "testdata/ids.golden", guuid.Default(), *update)
+func NewGolden(tb testing.TB, path string, src guuid.Source, update bool) *Golden {
+	tb.Helper()
+	g := &Golden{tb: tb, path: path, src: src}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil && !update:
+		if g.ids, err = parseGolden(data); err != nil {
+			tb.Fatalf("guuidtest: %s: %v", path, err)
+		}
+		g.replay = true
+		tb.Cleanup(g.checkConsumed)
+	case err == nil || errors.Is(err, os.ErrNotExist):
+		tb.Cleanup(g.write)
+	default:
+		tb.Fatalf("guuidtest: %v", err)
+	}
+	return g
+}
+
+// New returns the next recorded UUID when replaying, or a new UUID from the
+// underlying source when recording
+func (g *Golden) New() (guuid.UUID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.replay {
+		if g.next >= len(g.ids) {
+			g.tb.Errorf("guuidtest: %s: requested more than the %d recorded UUIDs", g.path, len(g.ids))
+			return guuid.Nil, ErrGoldenExhausted
+		}
+		g.next++
+		return g.ids[g.next-1], nil
+	}
+
+	id, err := g.src.New()
+	if err != nil {
+		return id, err
+	}
+	g.ids = append(g.ids, id)
+	return id, nil
+}
+
+// Replaying reports whether IDs come from the golden file
+func (g *Golden) Replaying() bool {
+	return g.replay
+}
+
+// write saves the recorded sequence to the golden file
+func (g *Golden) write() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, id := range g.ids {
+		buf.WriteString(id.String())
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(g.path), 0o755); err != nil {
+		g.tb.Errorf("guuidtest: %v", err)
+		return
+	}
+	if err := os.WriteFile(g.path, buf.Bytes(), 0o644); err != nil {
+		g.tb.Errorf("guuidtest: %v", err)
+	}
+}
+
+// checkConsumed fails the test if a replay left recorded IDs unused
+func (g *Golden) checkConsumed() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.next < len(g.ids) {
+		g.tb.Errorf("guuidtest: %s: used %d of %d recorded UUIDs", g.path, g.next, len(g.ids))
+	}
+}
+
+// parseGolden reads one UUID per line, ignoring blank lines
+func parseGolden(data []byte) ([]guuid.UUID, error) {
+	var ids []guuid.UUID
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; sc.Scan(); line++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		id, err := guuid.Parse(string(text))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, sc.Err()
+}
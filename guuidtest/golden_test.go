@@ -0,0 +1,86 @@
+package guuidtest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// fakeTB records failures instead of failing the enclosing test
+type fakeTB struct {
+	testing.TB
+	failed   bool
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()                       {}
+func (f *fakeTB) Errorf(string, ...interface{}) { f.failed = true }
+func (f *fakeTB) Fatalf(string, ...interface{}) { f.failed = true }
+func (f *fakeTB) Cleanup(fn func())             { f.cleanups = append(f.cleanups, fn) }
+
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestGolden_RecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "ids.golden")
+	src := NewStepGenerator(time.UnixMilli(1700000000000), time.Millisecond)
+
+	rec := &fakeTB{}
+	g := NewGolden(rec, path, src, false)
+	if g.Replaying() {
+		t.Fatal("Replaying() = true without a golden file")
+	}
+	var want []guuid.UUID
+	for i := 0; i < 3; i++ {
+		want = append(want, guuid.Must(g.New()))
+	}
+	rec.finish()
+	if rec.failed {
+		t.Fatal("recording failed")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+
+	play := &fakeTB{}
+	g = NewGolden(play, path, nil, false)
+	if !g.Replaying() {
+		t.Fatal("Replaying() = false with a golden file")
+	}
+	for i, w := range want {
+		if got := guuid.Must(g.New()); got != w {
+			t.Errorf("New() #%d = %v, want %v", i, got, w)
+		}
+	}
+	if _, err := g.New(); !errors.Is(err, ErrGoldenExhausted) {
+		t.Errorf("New() past the end error = %v, want ErrGoldenExhausted", err)
+	}
+	play.finish()
+	if !play.failed {
+		t.Error("over-consuming replay did not fail the test")
+	}
+}
+
+func TestGolden_Unconsumed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.golden")
+	if err := os.WriteFile(path, []byte(guuid.NamespaceDNS.String()+"\n\n"+guuid.NamespaceURL.String()+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tb := &fakeTB{}
+	g := NewGolden(tb, path, nil, false)
+	if got := guuid.Must(g.New()); got != guuid.NamespaceDNS {
+		t.Errorf("New() = %v, want %v", got, guuid.NamespaceDNS)
+	}
+	tb.finish()
+	if !tb.failed {
+		t.Error("partially consumed replay did not fail the test")
+	}
+}
@@ -0,0 +1,43 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// XXH64 primes
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+// Hash64 returns the XXH64 hash of the 16 bytes of u with the given seed.
+// XXH64 is specified at https://github.com/Cyan4973/xxHash and implemented in
+// most languages, so other services can compute the same value, e.g.
+// xxhash.xxh64(uuid.bytes, seed) in Python. The result is stable across
+// releases and suits maps, consistent hashing and probabilistic sketches.
+func (u UUID) Hash64(seed uint64) uint64 {
+	h := seed + xxPrime5 + 16
+	for i := 0; i < 16; i += 8 {
+		k := binary.LittleEndian.Uint64(u[i:])
+		k = bits.RotateLeft64(k*xxPrime2, 31) * xxPrime1
+		h ^= k
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+	}
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
+
+// Hash32 returns a 32-bit hash of u: Hash64(uint64(seed)) with its high and
+// low halves XORed together
+func (u UUID) Hash32(seed uint32) uint32 {
+	h := u.Hash64(uint64(seed))
+	return uint32(h>>32) ^ uint32(h)
+}
@@ -0,0 +1,32 @@
+package guuid
+
+import "testing"
+
+func TestUUID_Hash64(t *testing.T) {
+	// Reference values from an independent XXH64 implementation
+	tests := []struct {
+		uuid UUID
+		seed uint64
+		want uint64
+	}{
+		{Nil, 0, 0xaf09f71516247c32},
+		{Nil, 42, 0xcf492c84babef8b1},
+		{MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70"), 0, 0x244a698846d214cf},
+		{MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70"), 42, 0xada4d79ed42ff65e},
+	}
+	for _, tt := range tests {
+		if got := tt.uuid.Hash64(tt.seed); got != tt.want {
+			t.Errorf("%v.Hash64(%d) = %#x, want %#x", tt.uuid, tt.seed, got, tt.want)
+		}
+	}
+}
+
+func TestUUID_Hash32(t *testing.T) {
+	u := MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70")
+	if got, want := u.Hash32(0), uint32(0x244a6988^0x46d214cf); got != want {
+		t.Errorf("Hash32(0) = %#x, want %#x", got, want)
+	}
+	if u.Hash32(1) == u.Hash32(2) {
+		t.Error("Hash32() ignores the seed")
+	}
+}
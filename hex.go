@@ -0,0 +1,76 @@
+package guuid
+
+// hexValues maps each byte to the value of the hex digit it represents, or
+// to 0xFF if it is not a hex digit. Upper and lower case map alike, so no
+// case folding is needed while decoding.
+var hexValues = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xFF
+	}
+	for i := 0; i < 16; i++ {
+		t["0123456789abcdef"[i]] = byte(i)
+		t["0123456789ABCDEF"[i]] = byte(i)
+	}
+	return t
+}()
+
+// canonicalOffsets holds the position of each byte's hex digits in the
+// canonical 36-character form
+var canonicalOffsets = [16]int{0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34}
+
+// xtob decodes the hex digits hi and lo into a byte
+func xtob(hi, lo byte) (byte, bool) {
+	h, l := hexValues[hi], hexValues[lo]
+	return h<<4 | l, h|l != 0xFF // valid digits are at most 0x0F
+}
+
+// decodeHex decodes the 2*len(dst) hex digits of src into dst and reports
+// whether they were all valid
+func decodeHex[T string | []byte](dst []byte, src T) bool {
+	ok := true
+	for i := range dst {
+		b, valid := xtob(src[2*i], src[2*i+1])
+		dst[i] = b
+		ok = ok && valid
+	}
+	return ok
+}
+
+// parseText implements Parse for both strings and byte slices, so callers
+// holding bytes need not convert them
+func parseText[T string | []byte](s T) (UUID, error) {
+	var uuid UUID
+
+	// Remove common prefixes and suffixes
+	if len(s) >= 9 && string(s[:9]) == "urn:uuid:" {
+		s = s[9:]
+	}
+	if len(s) > 0 && s[0] == '{' {
+		s = s[1:]
+	}
+	if len(s) > 0 && s[len(s)-1] == '}' {
+		s = s[:len(s)-1]
+	}
+
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return uuid, ErrInvalidFormat
+		}
+		for i, x := range canonicalOffsets {
+			b, ok := xtob(s[x], s[x+1])
+			if !ok {
+				return uuid, ErrInvalidFormat
+			}
+			uuid[i] = b
+		}
+		return uuid, nil
+	case 32:
+		if !decodeHex(uuid[:], s) {
+			return uuid, ErrInvalidFormat
+		}
+		return uuid, nil
+	}
+
+	return uuid, ErrInvalidFormat
+}
@@ -0,0 +1,75 @@
+package guuid
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestHexValues(t *testing.T) {
+	for c := 0; c < 256; c++ {
+		want := byte(0xFF)
+		if v, err := strconv.ParseUint(string(rune(c)), 16, 8); err == nil && c < 0x80 {
+			want = byte(v)
+		}
+		if got := hexValues[c]; got != want {
+			t.Errorf("hexValues[%q] = %#x, want %#x", rune(c), got, want)
+		}
+	}
+}
+
+func TestDecodeHex(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []byte
+		ok   bool
+	}{
+		{"00ff7A", []byte{0x00, 0xFF, 0x7A}, true},
+		{"DEADbeef", []byte{0xDE, 0xAD, 0xBE, 0xEF}, true},
+		{"0g", nil, false},
+		{"g0", nil, false},
+		{" 0", nil, false},
+	}
+	for _, tt := range tests {
+		dst := make([]byte, len(tt.src)/2)
+		ok := decodeHex(dst, tt.src)
+		if okBytes := decodeHex(make([]byte, len(dst)), []byte(tt.src)); okBytes != ok {
+			t.Errorf("decodeHex(%q) differs between string and []byte", tt.src)
+		}
+		if ok != tt.ok {
+			t.Errorf("decodeHex(%q) ok = %v, want %v", tt.src, ok, tt.ok)
+			continue
+		}
+		if ok && string(dst) != string(tt.want) {
+			t.Errorf("decodeHex(%q) = %x, want %x", tt.src, dst, tt.want)
+		}
+	}
+}
+
+func TestParseText_Bytes(t *testing.T) {
+	want := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	for _, s := range []string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"F47AC10B58CC4372A5670E02B2C3D479",
+		"urn:uuid:f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"{f47ac10b-58cc-4372-a567-0e02b2c3d479}",
+	} {
+		if got, err := parseText([]byte(s)); err != nil || got != want {
+			t.Errorf("parseText(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	if _, err := parseText([]byte("urn:uuid:")); err != ErrInvalidFormat {
+		t.Errorf("parseText(urn only) error = %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestUnmarshalText_NoAllocs(t *testing.T) {
+	data := []byte("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	var u UUID
+	if allocs := testing.AllocsPerRun(100, func() { _ = u.UnmarshalText(data) }); allocs != 0 {
+		t.Errorf("UnmarshalText() allocates %v times, want 0", allocs)
+	}
+	var src interface{} = data
+	if allocs := testing.AllocsPerRun(100, func() { _ = u.Scan(src) }); allocs != 0 {
+		t.Errorf("Scan([]byte) allocates %v times, want 0", allocs)
+	}
+}
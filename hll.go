@@ -0,0 +1,118 @@
+package guuid
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Precision bounds of a HyperLogLog
+const (
+	MinHLLPrecision     = 4
+	MaxHLLPrecision     = 18
+	DefaultHLLPrecision = 14 // 16 KiB of registers, about 0.8% standard error
+)
+
+// hllFormat is the first byte of a marshaled HyperLogLog
+const hllFormat = 1
+
+// HyperLogLog estimates the number of distinct UUIDs added to it in fixed
+// memory: 2^precision one-byte registers, with a standard error of about
+// 1.04/sqrt(2^precision). UUIDs are placed by UUID.Hash64 with seed 0, so
+// sketches built by different services can be marshaled, exchanged and
+// merged. It is not safe for concurrent use.
+type HyperLogLog struct {
+	p   uint8
+	reg []uint8
+}
+
+// NewHyperLogLog returns an empty HyperLogLog with 2^precision registers,
+// precision clamped to [MinHLLPrecision, MaxHLLPrecision]
+func NewHyperLogLog(precision int) *HyperLogLog {
+	p := uint8(min(max(precision, MinHLLPrecision), MaxHLLPrecision))
+	return &HyperLogLog{p: p, reg: make([]uint8, 1<<p)}
+}
+
+// Precision returns the base-2 logarithm of the number of registers
+func (h *HyperLogLog) Precision() int {
+	return int(h.p)
+}
+
+// Add records u
+func (h *HyperLogLog) Add(u UUID) {
+	x := u.Hash64(0)
+	i := x >> (64 - h.p)
+	rank := uint8(bits.LeadingZeros64(x<<h.p|1<<(h.p-1))) + 1
+	if rank > h.reg[i] {
+		h.reg[i] = rank
+	}
+}
+
+// Estimate returns the estimated number of distinct UUIDs added
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.reg))
+	sum, zeros := 0.0, 0
+	for _, r := range h.reg {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(h.reg) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	est := alpha * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+// Merge folds other into h, so h estimates the distinct UUIDs added to
+// either. It returns ErrSketchMismatch if the precisions differ.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other.p != h.p {
+		return ErrSketchMismatch
+	}
+	for i, r := range other.reg {
+		h.reg[i] = max(h.reg[i], r)
+	}
+	return nil
+}
+
+// Reset empties h
+func (h *HyperLogLog) Reset() {
+	clear(h.reg)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The
+// encoding is a format byte (1), the precision and the registers.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 2+len(h.reg))
+	buf[0], buf[1] = hllFormat, h.p
+	copy(buf[2:], h.reg)
+	return buf, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != hllFormat || data[1] < MinHLLPrecision || data[1] > MaxHLLPrecision {
+		return ErrInvalidFormat
+	}
+	p := data[1]
+	if len(data) != 2+1<<p {
+		return ErrInvalidLength
+	}
+	h.p = p
+	h.reg = append(h.reg[:0], data[2:]...)
+	return nil
+}
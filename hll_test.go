@@ -0,0 +1,90 @@
+package guuid
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	for _, n := range []int{0, 1, 100, 10000, 200000} {
+		h := NewHyperLogLog(DefaultHLLPrecision)
+		ids := testIDs(n)
+		for _, u := range ids {
+			h.Add(u)
+			h.Add(u) // duplicates do not count
+		}
+		got := h.Estimate()
+		if n == 0 {
+			if got != 0 {
+				t.Errorf("Estimate() of empty = %d, want 0", got)
+			}
+			continue
+		}
+		// Six standard errors, so random IDs essentially never fail the test
+		if relErr := math.Abs(float64(got)-float64(n)) / float64(n); relErr > 0.05 {
+			t.Errorf("Estimate() = %d for %d IDs, error %.3f", got, n, relErr)
+		}
+	}
+}
+
+func TestNewHyperLogLog_Clamp(t *testing.T) {
+	tests := []struct{ in, want int }{{0, MinHLLPrecision}, {10, 10}, {30, MaxHLLPrecision}}
+	for _, tt := range tests {
+		if got := NewHyperLogLog(tt.in).Precision(); got != tt.want {
+			t.Errorf("NewHyperLogLog(%d).Precision() = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	ids := testIDs(30000)
+	a, b := NewHyperLogLog(12), NewHyperLogLog(12)
+	for _, u := range ids[:20000] {
+		a.Add(u)
+	}
+	for _, u := range ids[10000:] {
+		b.Add(u)
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if got := a.Estimate(); math.Abs(float64(got)-30000)/30000 > 0.05 {
+		t.Errorf("merged Estimate() = %d, want about 30000", got)
+	}
+
+	if err := a.Merge(NewHyperLogLog(10)); !errors.Is(err, ErrSketchMismatch) {
+		t.Errorf("Merge() error = %v, want ErrSketchMismatch", err)
+	}
+
+	a.Reset()
+	if got := a.Estimate(); got != 0 {
+		t.Errorf("Estimate() after Reset() = %d, want 0", got)
+	}
+}
+
+func TestHyperLogLog_MarshalBinary(t *testing.T) {
+	h := NewHyperLogLog(8)
+	for _, u := range testIDs(500) {
+		h.Add(u)
+	}
+	data, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	var got HyperLogLog
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if got.Precision() != 8 || got.Estimate() != h.Estimate() {
+		t.Errorf("round trip = p%d %d, want p8 %d", got.Precision(), got.Estimate(), h.Estimate())
+	}
+
+	bad := [][]byte{nil, {2, 8}, {1, 3}, {1, 8, 0}}
+	for _, b := range bad {
+		if err := got.UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%v) error = nil", b)
+		}
+	}
+}
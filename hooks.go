@@ -0,0 +1,34 @@
+package guuid
+
+import "time"
+
+// Hook is called after every generation attempt with the resulting UUID and
+// error. Hooks run outside the generator lock, in registration order, on the
+// calling goroutine; they must be safe for concurrent use.
+type Hook func(UUID, error)
+
+// WithHook registers a post-generation hook, e.g. for auditing, sampling or metrics
+func WithHook(h Hook) Option {
+	return func(g *Generator) {
+		g.postHooks = append(g.postHooks, h)
+	}
+}
+
+// WithPreHook registers a function called before every generation attempt
+func WithPreHook(h func()) Option {
+	return func(g *Generator) {
+		g.preHooks = append(g.preHooks, h)
+	}
+}
+
+// WithOnOverflow registers a function called when the 12-bit counter
+// overflows within a millisecond and the generator starts borrowing future
+// milliseconds. lead is how far the embedded timestamp runs ahead of the
+// requested time. Sustained overflow means the load exceeds 4096 IDs per
+// millisecond for this generator. Like Hook, fn runs outside the generator
+// lock on the calling goroutine.
+func WithOnOverflow(fn func(lead time.Duration)) Option {
+	return func(g *Generator) {
+		g.onOverflow = append(g.onOverflow, fn)
+	}
+}
@@ -0,0 +1,67 @@
+package guuid
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGenerator_Hooks(t *testing.T) {
+	var pre, post, failed atomic.Int64
+	var last atomic.Value
+
+	opts := []Option{
+		WithPreHook(func() { pre.Add(1) }),
+		WithHook(func(u UUID, err error) {
+			post.Add(1)
+			if err != nil {
+				failed.Add(1)
+				return
+			}
+			last.Store(u)
+		}),
+	}
+
+	gen := NewGenerator(opts...)
+	for i := 0; i < 10; i++ {
+		uuid := Must(gen.New())
+		if last.Load().(UUID) != uuid {
+			t.Errorf("post hook saw %v, want %v", last.Load(), uuid)
+		}
+	}
+	if pre.Load() != 10 || post.Load() != 10 || failed.Load() != 0 {
+		t.Errorf("hook counts pre=%d post=%d failed=%d, want 10/10/0", pre.Load(), post.Load(), failed.Load())
+	}
+
+	broken := NewGeneratorWithReader(&brokenReader{}, opts...)
+	if _, err := broken.New(); err == nil {
+		t.Fatal("New() expected error with broken reader")
+	}
+	if failed.Load() != 1 {
+		t.Errorf("post hook failure count = %d, want 1", failed.Load())
+	}
+}
+
+func TestGenerator_OnOverflow(t *testing.T) {
+	var leads []time.Duration
+	gen := NewGenerator(WithOnOverflow(func(lead time.Duration) {
+		leads = append(leads, lead)
+	}))
+	now := time.UnixMilli(time.Now().UnixMilli())
+
+	Must(gen.NewWithTime(now))
+	gen.clockSeq = 0xFFE
+	for i := 0; i < 3; i++ {
+		Must(gen.NewWithTime(now))
+	}
+
+	if len(leads) != 1 {
+		t.Fatalf("OnOverflow called %d times, want 1", len(leads))
+	}
+	if leads[0] != time.Millisecond {
+		t.Errorf("OnOverflow lead = %v, want %v", leads[0], time.Millisecond)
+	}
+	if got := gen.Stats(); got.Overflows != 1 || got.Generated != 4 {
+		t.Errorf("Stats() = %+v, want 1 overflow and 4 generated", got)
+	}
+}
@@ -0,0 +1,123 @@
+// Package httpmw provides net/http middleware that tags every request with a
+// UUIDv7 request ID.
+//
+// The middleware honors an incoming X-Request-ID header when present, otherwise
+// generates a new UUIDv7. The ID is stored in the request context and echoed
+// in the response header:
+//
+//	mux := http.NewServeMux()
+//	http.ListenAndServe(":8080", httpmw.RequestID(mux))
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    log.Printf("request %s", httpmw.FromContext(r.Context()))
+//	}
+package httpmw
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// HeaderRequestID is the default header carrying the request ID
+const HeaderRequestID = "X-Request-ID"
+
+// maxIncomingLen bounds the length of a client-supplied request ID
+const maxIncomingLen = 128
+
+// contextKey is the unexported type for context keys defined in this package
+type contextKey struct{}
+
+// config holds the middleware settings
+type config struct {
+	header        string
+	generator     *guuid.Generator
+	trustIncoming bool
+}
+
+// Option configures the middleware
+type Option func(*config)
+
+// WithHeader sets the header used to read and echo the request ID
+func WithHeader(name string) Option {
+	return func(c *config) {
+		c.header = name
+	}
+}
+
+// WithGenerator sets the generator used for new request IDs
+func WithGenerator(gen *guuid.Generator) Option {
+	return func(c *config) {
+		c.generator = gen
+	}
+}
+
+// WithTrustIncoming controls whether an incoming request ID header is honored (default true)
+func WithTrustIncoming(trust bool) Option {
+	return func(c *config) {
+		c.trustIncoming = trust
+	}
+}
+
+// RequestID is the middleware with default settings
+func RequestID(next http.Handler) http.Handler {
+	return Middleware()(next)
+}
+
+// Middleware returns a request-ID middleware configured by opts
+func Middleware(opts ...Option) func(http.Handler) http.Handler {
+	cfg := config{
+		header:        HeaderRequestID,
+		trustIncoming: true,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := ""
+			if cfg.trustIncoming {
+				id = r.Header.Get(cfg.header)
+				if len(id) > maxIncomingLen {
+					id = ""
+				}
+			}
+			if id == "" {
+				id = cfg.newID()
+			}
+
+			w.Header().Set(cfg.header, id)
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+		})
+	}
+}
+
+// newID generates a request ID, falling back to an empty ID if entropy fails
+func (c *config) newID() string {
+	var (
+		id  guuid.UUID
+		err error
+	)
+	if c.generator != nil {
+		id, err = c.generator.New()
+	} else {
+		id, err = guuid.New()
+	}
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+// NewContext returns a copy of ctx carrying the request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or "" if none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
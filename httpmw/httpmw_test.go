@@ -0,0 +1,80 @@
+package httpmw
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func TestRequestID_Generates(t *testing.T) {
+	var ctxID string
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = FromContext(r.Context())
+	})
+	rec := httptest.NewRecorder()
+	RequestID(inner).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	id, err := guuid.Parse(ctxID)
+	if err != nil {
+		t.Fatalf("context request ID %q is not a UUID: %v", ctxID, err)
+	}
+	if id.Version() != guuid.VersionTimeSorted {
+		t.Errorf("request ID version = %v, want %v", id.Version(), guuid.VersionTimeSorted)
+	}
+	if got := rec.Header().Get(HeaderRequestID); got != ctxID {
+		t.Errorf("response header = %q, want %q", got, ctxID)
+	}
+}
+
+func TestRequestID_HonorsIncoming(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		incoming string
+		wantSame bool
+	}{
+		{"trusted", nil, "abc-123", true},
+		{"untrusted", []Option{WithTrustIncoming(false)}, "abc-123", false},
+		{"too long", nil, strings.Repeat("x", maxIncomingLen+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctxID string
+			inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxID = FromContext(r.Context())
+			})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(HeaderRequestID, tt.incoming)
+			rec := httptest.NewRecorder()
+			Middleware(tt.opts...)(inner).ServeHTTP(rec, req)
+
+			if (ctxID == tt.incoming) != tt.wantSame {
+				t.Errorf("context request ID = %q, incoming %q, wantSame %v", ctxID, tt.incoming, tt.wantSame)
+			}
+			if rec.Header().Get(HeaderRequestID) != ctxID {
+				t.Errorf("response header = %q, want %q", rec.Header().Get(HeaderRequestID), ctxID)
+			}
+		})
+	}
+}
+
+func TestMiddleware_CustomHeaderAndGenerator(t *testing.T) {
+	var ctxID string
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = FromContext(r.Context())
+	})
+	mw := Middleware(WithHeader("X-Trace-ID"), WithGenerator(guuid.NewGenerator()))
+	rec := httptest.NewRecorder()
+	mw(inner).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if ctxID == "" || rec.Header().Get("X-Trace-ID") != ctxID {
+		t.Errorf("X-Trace-ID = %q, context = %q", rec.Header().Get("X-Trace-ID"), ctxID)
+	}
+	if rec.Header().Get(HeaderRequestID) != "" {
+		t.Error("default header should not be set when a custom header is configured")
+	}
+}
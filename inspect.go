@@ -0,0 +1,72 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// Inspection is a structured breakdown of the fields of a UUID.
+// Time-related and counter fields are only populated for versions that
+// define them; HasTime reports whether they are meaningful.
+type Inspection struct {
+	Version Version
+	Variant Variant
+
+	HasTime   bool
+	Timestamp int64     // Unix milliseconds (UUIDv7)
+	Time      time.Time // Timestamp as time.Time (UUIDv7)
+
+	Counter uint16 // 12-bit rand_a field, used as a monotonic counter by Generator (UUIDv7)
+	Random  uint64 // 62-bit rand_b field (UUIDv7)
+}
+
+// Inspect decomposes u into its version-specific fields
+func (u UUID) Inspect() Inspection {
+	in := Inspection{
+		Version: u.Version(),
+		Variant: u.Variant(),
+	}
+
+	if in.Version == VersionTimeSorted {
+		in.HasTime = true
+		in.Timestamp = u.Timestamp()
+		in.Time = u.Time()
+		in.Counter = binary.BigEndian.Uint16(u[6:8]) & 0x0fff
+		in.Random = binary.BigEndian.Uint64(u[8:16]) & 0x3fffffffffffffff
+	}
+
+	return in
+}
+
+// V7Parts splits a UUIDv7 into its millisecond timestamp, 12-bit rand_a
+// counter and rand_b bytes. The two variant bits are cleared in rand, so
+// NewV7FromParts(u.V7Parts()) == u for any RFC 4122 variant UUIDv7.
+// For other versions the result is meaningless.
+func (u UUID) V7Parts() (ts int64, counter uint16, rand [8]byte) {
+	ts = int64(binary.BigEndian.Uint64(u[0:8]) >> 16)
+	counter = binary.BigEndian.Uint16(u[6:8]) & 0x0fff
+	copy(rand[:], u[8:16])
+	rand[0] &= 0x3f
+	return ts, counter, rand
+}
+
+// NewV7FromParts assembles a UUIDv7 from a Unix millisecond timestamp, a
+// 12-bit counter and 8 random bytes, setting the version and variant bits.
+// The top two bits of rand[0] are overwritten by the variant.
+//
+// It returns ErrTimestampOutOfRange if ts does not fit in 48 bits and
+// ErrInvalidFormat if counter does not fit in 12 bits.
+func NewV7FromParts(ts int64, counter uint16, rand [8]byte) (UUID, error) {
+	var uuid UUID
+	if ts < 0 || ts >= 1<<48 {
+		return uuid, ErrTimestampOutOfRange
+	}
+	if counter > 0x0fff {
+		return uuid, ErrInvalidFormat
+	}
+	binary.BigEndian.PutUint64(uuid[0:8], uint64(ts)<<16|uint64(counter))
+	copy(uuid[8:16], rand[:])
+	uuid.SetVersion(VersionTimeSorted)
+	uuid.SetVariant(VariantRFC4122)
+	return uuid, nil
+}
@@ -0,0 +1,93 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUUID_Inspect(t *testing.T) {
+	gen := NewGenerator()
+	now := time.Now()
+	uuid := Must(gen.NewWithTime(now))
+
+	in := uuid.Inspect()
+	if in.Version != VersionTimeSorted || in.Variant != VariantRFC4122 {
+		t.Errorf("Inspect() version/variant = %v/%v", in.Version, in.Variant)
+	}
+	if !in.HasTime || in.Timestamp != now.UnixMilli() {
+		t.Errorf("Inspect() timestamp = %d, want %d", in.Timestamp, now.UnixMilli())
+	}
+	if in.Counter != gen.clockSeq {
+		t.Errorf("Inspect() counter = %#x, want %#x", in.Counter, gen.clockSeq)
+	}
+	if in.Random>>62 != 0 {
+		t.Errorf("Inspect() random has more than 62 bits: %#x", in.Random)
+	}
+}
+
+func TestUUID_Inspect_Fields(t *testing.T) {
+	uuid := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	in := uuid.Inspect()
+
+	if in.Timestamp != 0x01890a5dac96 {
+		t.Errorf("Inspect() timestamp = %#x, want %#x", in.Timestamp, int64(0x01890a5dac96))
+	}
+	if in.Counter != 0x74b {
+		t.Errorf("Inspect() counter = %#x, want %#x", in.Counter, 0x74b)
+	}
+	if in.Random != 0x3cceb302099a8057 {
+		t.Errorf("Inspect() random = %#x, want %#x", in.Random, uint64(0x3cceb302099a8057))
+	}
+}
+
+func TestUUID_Inspect_NonV7(t *testing.T) {
+	in := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479").Inspect()
+	if in.Version != VersionRandom {
+		t.Errorf("Inspect() version = %v, want %v", in.Version, VersionRandom)
+	}
+	if in.HasTime || in.Timestamp != 0 || in.Counter != 0 || in.Random != 0 {
+		t.Errorf("Inspect() populated v7 fields for v4 UUID: %+v", in)
+	}
+}
+
+func TestUUID_V7Parts_RoundTrip(t *testing.T) {
+	gen := NewGenerator()
+	for i := 0; i < 100; i++ {
+		uuid := Must(gen.New())
+		ts, counter, rand := uuid.V7Parts()
+		if ts != uuid.Timestamp() {
+			t.Errorf("V7Parts() ts = %d, want %d", ts, uuid.Timestamp())
+		}
+		if counter != uuid.Inspect().Counter {
+			t.Errorf("V7Parts() counter = %#x, want %#x", counter, uuid.Inspect().Counter)
+		}
+		rebuilt, err := NewV7FromParts(ts, counter, rand)
+		if err != nil {
+			t.Fatalf("NewV7FromParts() error = %v", err)
+		}
+		if rebuilt != uuid {
+			t.Errorf("NewV7FromParts(V7Parts()) = %v, want %v", rebuilt, uuid)
+		}
+	}
+}
+
+func TestNewV7FromParts(t *testing.T) {
+	uuid, err := NewV7FromParts(0x01890a5dac96, 0x74b, [8]byte{0xfc, 0xce, 0xb3, 0x02, 0x09, 0x9a, 0x80, 0x57})
+	if err != nil {
+		t.Fatalf("NewV7FromParts() error = %v", err)
+	}
+	if want := MustParse("01890a5d-ac96-774b-bcce-b302099a8057"); uuid != want {
+		t.Errorf("NewV7FromParts() = %v, want %v", uuid, want)
+	}
+
+	if _, err := NewV7FromParts(-1, 0, [8]byte{}); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("NewV7FromParts(-1) error = %v, want %v", err, ErrTimestampOutOfRange)
+	}
+	if _, err := NewV7FromParts(1<<48, 0, [8]byte{}); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("NewV7FromParts(2^48) error = %v, want %v", err, ErrTimestampOutOfRange)
+	}
+	if _, err := NewV7FromParts(0, 0x1000, [8]byte{}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("NewV7FromParts(counter 0x1000) error = %v, want %v", err, ErrInvalidFormat)
+	}
+}
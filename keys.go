@@ -0,0 +1,66 @@
+package guuid
+
+import "math/bits"
+
+// Spanner and Bigtable split tables into key ranges served by different
+// nodes. UUIDv7 keys start with a timestamp, so every new row lands at the
+// end of the keyspace and one node takes all the writes, a hotspot. Reversing
+// the bits of the timestamp (as Spanner does for bit-reversed sequences)
+// spreads consecutive IDs evenly across the keyspace. The trade-off: rows are
+// no longer stored in creation order, so time-range scans over the key become
+// full scans, and reversed and plain keys must never be mixed in one column.
+// The version, variant, counter and random bits are left in place, so the
+// transform is cheap and exactly reversible.
+
+// ReverseTimestamp returns u with the bit order of its 48-bit timestamp
+// reversed. Applying it twice returns the original UUID.
+func ReverseTimestamp(u UUID) UUID {
+	ts := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 | uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+	ts = bits.Reverse64(ts) >> 16
+	u[0], u[1], u[2], u[3], u[4], u[5] = byte(ts>>40), byte(ts>>32), byte(ts>>24), byte(ts>>16), byte(ts>>8), byte(ts)
+	return u
+}
+
+// SpannerKey formats u for a Spanner STRING(36) key column, reversing its
+// timestamp first if reverse is set
+func SpannerKey(u UUID, reverse bool) string {
+	if reverse {
+		u = ReverseTimestamp(u)
+	}
+	return u.String()
+}
+
+// ParseSpannerKey parses a key written by SpannerKey with the same reverse setting
+func ParseSpannerKey(key string, reversed bool) (UUID, error) {
+	u, err := Parse(key)
+	if err != nil {
+		return Nil, err
+	}
+	if reversed {
+		u = ReverseTimestamp(u)
+	}
+	return u, nil
+}
+
+// BigtableKey returns u as a 16-byte Bigtable row key, reversing its
+// timestamp first if reverse is set. Bigtable sorts keys bytewise, so plain
+// keys keep creation order.
+func BigtableKey(u UUID, reverse bool) []byte {
+	if reverse {
+		u = ReverseTimestamp(u)
+	}
+	return u[:]
+}
+
+// ParseBigtableKey decodes a row key written by BigtableKey with the same
+// reverse setting
+func ParseBigtableKey(key []byte, reversed bool) (UUID, error) {
+	if len(key) != 16 {
+		return Nil, ErrInvalidLength
+	}
+	u := UUID(key)
+	if reversed {
+		u = ReverseTimestamp(u)
+	}
+	return u, nil
+}
@@ -0,0 +1,63 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReverseTimestamp(t *testing.T) {
+	u := MustParse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70")
+	r := ReverseTimestamp(u)
+
+	if ReverseTimestamp(r) != u {
+		t.Errorf("ReverseTimestamp() is not an involution: %v", ReverseTimestamp(r))
+	}
+	if [10]byte(r[6:]) != [10]byte(u[6:]) {
+		t.Errorf("ReverseTimestamp() changed non-timestamp bits: %v", r)
+	}
+	// 0x018f3e5a7b2c reversed over 48 bits
+	if got, want := r.String(), "34de5a7c-f180-7d4e-8f1a-2b3c4d5e6f70"; got != want {
+		t.Errorf("ReverseTimestamp() = %s, want %s", got, want)
+	}
+}
+
+func TestReverseTimestamp_Spreads(t *testing.T) {
+	gen := NewGenerator()
+	at := time.UnixMilli(1700000000000)
+	a := ReverseTimestamp(Must(gen.NewWithTime(at)))
+	b := ReverseTimestamp(Must(gen.NewWithTime(at.Add(time.Millisecond))))
+	if a[0] == b[0] {
+		t.Errorf("consecutive milliseconds share leading byte %#x after reversal", a[0])
+	}
+}
+
+func TestSpannerKey(t *testing.T) {
+	u := Must(New())
+	for _, reverse := range []bool{false, true} {
+		key := SpannerKey(u, reverse)
+		if (key == u.String()) == reverse {
+			t.Errorf("SpannerKey(reverse=%v) = %s", reverse, key)
+		}
+		got, err := ParseSpannerKey(key, reverse)
+		if err != nil || got != u {
+			t.Errorf("ParseSpannerKey(%s, %v) = %v, %v, want %v", key, reverse, got, err, u)
+		}
+	}
+	if _, err := ParseSpannerKey("nope", true); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("ParseSpannerKey() error = %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestBigtableKey(t *testing.T) {
+	u := Must(New())
+	for _, reverse := range []bool{false, true} {
+		got, err := ParseBigtableKey(BigtableKey(u, reverse), reverse)
+		if err != nil || got != u {
+			t.Errorf("Bigtable round trip (reverse=%v) = %v, %v, want %v", reverse, got, err, u)
+		}
+	}
+	if _, err := ParseBigtableKey([]byte{1, 2}, false); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("ParseBigtableKey() error = %v, want ErrInvalidLength", err)
+	}
+}
@@ -0,0 +1,35 @@
+package guuid
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// lenientText makes UnmarshalText accept padded and quoted input
+var lenientText atomic.Bool
+
+// SetStrictText controls how UUID.UnmarshalText treats its input. In strict
+// mode, the default, it accepts exactly what Parse accepts. With strict set to
+// false it first trims surrounding whitespace and one pair of matching single
+// or double quotes, as ParseLenient does, so values such as `" f47ac10b-... "`
+// from CSV files or environment variables decode instead of failing. The
+// setting is process-wide and safe to change concurrently.
+func SetStrictText(strict bool) {
+	lenientText.Store(!strict)
+}
+
+// ParseLenient parses a UUID like Parse after trimming surrounding whitespace
+// and one pair of matching single or double quotes, with any whitespace
+// inside the quotes
+func ParseLenient(s string) (UUID, error) {
+	return Parse(trimText(s))
+}
+
+// trimText strips whitespace and one pair of matching quotes around s
+func trimText(s string) string {
+	s = strings.TrimSpace(s)
+	if n := len(s); n >= 2 && (s[0] == '"' || s[0] == '\'') && s[n-1] == s[0] {
+		s = strings.TrimSpace(s[1 : n-1])
+	}
+	return s
+}
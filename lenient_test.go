@@ -0,0 +1,54 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLenient(t *testing.T) {
+	const canonical = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	want := MustParse(canonical)
+
+	tests := []struct {
+		in      string
+		wantErr bool
+	}{
+		{canonical, false},
+		{"  " + canonical + "\n", false},
+		{`"` + canonical + `"`, false},
+		{`' ` + canonical + ` '`, false},
+		{` " ` + canonical + ` " `, false},
+		{`"` + canonical + `'`, true},
+		{`""` + canonical + `""`, true},
+		{`"`, true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLenient(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLenient(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != want {
+			t.Errorf("ParseLenient(%q) = %v, want %v", tt.in, got, want)
+		}
+	}
+}
+
+func TestUUID_UnmarshalTextStrict(t *testing.T) {
+	padded := []byte(` "f47ac10b-58cc-4372-a567-0e02b2c3d479" `)
+
+	var u UUID
+	if err := u.UnmarshalText(padded); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("strict UnmarshalText() error = %v, want ErrInvalidFormat", err)
+	}
+
+	SetStrictText(false)
+	defer SetStrictText(true)
+	if err := u.UnmarshalText(padded); err != nil {
+		t.Errorf("lenient UnmarshalText() error = %v", err)
+	}
+	if u != MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479") {
+		t.Errorf("lenient UnmarshalText() = %v", u)
+	}
+}
@@ -0,0 +1,186 @@
+//go:build darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd
+
+package localseq
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// nextN draws n values from s
+func nextN(t *testing.T, s *Sequence, n int) []uint64 {
+	t.Helper()
+	vals := make([]uint64, n)
+	for i := range vals {
+		v, err := s.Next()
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		vals[i] = v
+	}
+	return vals
+}
+
+// crash releases s without recording its position, as if the process died
+func crash(t *testing.T, s *Sequence) {
+	t.Helper()
+	if err := s.f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s.f = nil
+}
+
+func TestSequence_Restart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seq")
+
+	tests := []struct {
+		name  string
+		stop  func(*testing.T, *Sequence)
+		first uint64
+	}{
+		{"new", nil, 1},
+		{"clean close", func(t *testing.T, s *Sequence) { s.Close() }, 6},
+		{"crash", crash, 16},
+		{"after crash", func(t *testing.T, s *Sequence) { s.Close() }, 21},
+	}
+
+	var s *Sequence
+	for _, tt := range tests {
+		if tt.stop != nil {
+			tt.stop(t, s)
+		}
+		var err error
+		if s, err = Open(path, WithStep(10)); err != nil {
+			t.Fatalf("%s: Open() error = %v", tt.name, err)
+		}
+		vals := nextN(t, s, 5)
+		if vals[0] != tt.first || vals[4] != tt.first+4 {
+			t.Errorf("%s: Next() = %v, want %d..%d", tt.name, vals, tt.first, tt.first+4)
+		}
+	}
+	s.Close()
+
+	if _, err := s.Next(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Next() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestSequence_Locked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seq")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+
+	if _, err := Open(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("second Open() error = %v, want ErrLocked", err)
+	}
+}
+
+func TestSequence_TornSlot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seq")
+	s, err := Open(path, WithStep(10))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	nextN(t, s, 15) // the last write reserved up to 21
+	crash(t, s)
+
+	// Corrupt the newest slot; the previous bound (11) must be used
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[headerSize+s.slot*slotSize] ^= 0xff
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err = Open(path); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+	if got := nextN(t, s, 1)[0]; got != 11 {
+		t.Errorf("Next() after torn write = %d, want 11", got)
+	}
+}
+
+func TestSequence_SyncFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seq")
+	s, err := Open(path, WithStep(10))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+	nextN(t, s, 11) // the last write reserved up to 21
+	durable, gen := s.slot, s.gen
+
+	// Make the next write fail by closing the descriptor
+	f := s.f
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	nextN(t, s, 9)
+	if _, err := s.Next(); err == nil {
+		t.Fatal("Next() with a failing sync returned no error")
+	}
+	if s.slot != durable || s.gen != gen {
+		t.Errorf("failed persist moved slot/gen from %d/%d to %d/%d", durable, gen, s.slot, s.gen)
+	}
+
+	if s.f, err = os.OpenFile(path, os.O_RDWR, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := nextN(t, s, 1)[0]; got != 21 {
+		t.Errorf("Next() after recovery = %d, want 21", got)
+	}
+	if limit, _, ok := decodeSlot(s.data[:], durable); !ok || limit != 21 {
+		t.Errorf("durable slot = %d, %v after retry, want 21 intact", limit, ok)
+	}
+}
+
+func TestOpen_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seq")
+	if err := os.WriteFile(path, []byte("not a sequence file, clearly not"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Open() error = %v, want ErrCorrupt", err)
+	}
+}
+
+func TestSequence_Concurrent(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "seq"), WithStep(7))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+
+	var mu sync.Mutex
+	seen := make(map[uint64]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				v, err := s.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				seen[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 800 {
+		t.Errorf("got %d distinct values, want 800", len(seen))
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !(darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd)
+
+package localseq
+
+import "os"
+
+// lockFile reports that file locking is unavailable on this platform
+func lockFile(*os.File) error {
+	return ErrNotSupported
+}
@@ -0,0 +1,119 @@
+package guuid
+
+import "container/heap"
+
+// Stream yields UUIDs one at a time, returning false once it is exhausted
+type Stream interface {
+	Next() (UUID, bool)
+}
+
+// StreamFunc adapts a function to the Stream interface
+type StreamFunc func() (UUID, bool)
+
+// Next implements Stream
+func (f StreamFunc) Next() (UUID, bool) {
+	return f()
+}
+
+// SliceStream returns a Stream over ids
+func SliceStream(ids []UUID) Stream {
+	return StreamFunc(func() (UUID, bool) {
+		if len(ids) == 0 {
+			return Nil, false
+		}
+		u := ids[0]
+		ids = ids[1:]
+		return u, true
+	})
+}
+
+// Merger merges K streams, each already sorted in byte order, into one
+// globally sorted stream, e.g. v7 IDs written by several nodes for log
+// compaction or event replay. IDs equal across streams are all returned, in
+// stream order. A Merger is not safe for concurrent use.
+type Merger struct {
+	streams []Stream
+	heap    mergeHeap
+	started bool
+	source  int
+}
+
+// NewMerger returns a Merger over streams
+func NewMerger(streams ...Stream) *Merger {
+	return &Merger{streams: streams, source: -1}
+}
+
+// Next returns the next UUID in sorted order, or false once every stream is
+// exhausted. Each stream is read one ID ahead.
+func (m *Merger) Next() (UUID, bool) {
+	if !m.started {
+		m.started = true
+		for i, s := range m.streams {
+			if u, ok := s.Next(); ok {
+				m.heap = append(m.heap, mergeItem{u, i})
+			}
+		}
+		heap.Init(&m.heap)
+	}
+	if len(m.heap) == 0 {
+		m.source = -1
+		return Nil, false
+	}
+
+	top := m.heap[0]
+	m.source = top.src
+	if u, ok := m.streams[top.src].Next(); ok {
+		m.heap[0].id = u
+		heap.Fix(&m.heap, 0)
+	} else {
+		heap.Pop(&m.heap)
+	}
+	return top.id, true
+}
+
+// Source returns the index of the stream the last ID returned by Next came
+// from, or -1 if Next has not returned one
+func (m *Merger) Source() int {
+	return m.source
+}
+
+// MergeSorted merges already-sorted slices into one sorted slice
+func MergeSorted(lists ...[]UUID) []UUID {
+	n := 0
+	streams := make([]Stream, len(lists))
+	for i, ids := range lists {
+		n += len(ids)
+		streams[i] = SliceStream(ids)
+	}
+	out := make([]UUID, 0, n)
+	m := NewMerger(streams...)
+	for u, ok := m.Next(); ok; u, ok = m.Next() {
+		out = append(out, u)
+	}
+	return out
+}
+
+// mergeItem is the head of one stream
+type mergeItem struct {
+	id  UUID
+	src int
+}
+
+// mergeHeap is a min-heap of stream heads, ties broken by stream index
+type mergeHeap []mergeItem
+
+func (h mergeHeap) Len() int { return len(h) }
+func (h mergeHeap) Less(i, j int) bool {
+	if c := h[i].id.Compare(h[j].id); c != 0 {
+		return c < 0
+	}
+	return h[i].src < h[j].src
+}
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)   { *h = append(*h, x.(mergeItem)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,55 @@
+package guuid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMerger(t *testing.T) {
+	all := testIDs(300)
+	lists := make([][]UUID, 4)
+	for i, u := range all {
+		lists[i*7%4] = append(lists[i*7%4], u)
+	}
+	lists = append(lists, nil)
+
+	m := NewMerger(SliceStream(lists[0]), SliceStream(lists[1]), SliceStream(lists[2]), SliceStream(lists[3]), SliceStream(nil))
+	if m.Source() != -1 {
+		t.Errorf("Source() before Next = %d, want -1", m.Source())
+	}
+	var got []UUID
+	for u, ok := m.Next(); ok; u, ok = m.Next() {
+		if !slices.Contains(lists[m.Source()], u) {
+			t.Fatalf("Source() = %d, but %v is not in that stream", m.Source(), u)
+		}
+		got = append(got, u)
+	}
+	if !slices.Equal(got, all) {
+		t.Errorf("merged %d IDs out of order or incomplete, want %d", len(got), len(all))
+	}
+	if _, ok := m.Next(); ok || m.Source() != -1 {
+		t.Error("Next() after exhaustion returned an ID")
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	a, b, c := UUID{0x01}, UUID{0x02}, UUID{0x03}
+	tests := []struct {
+		name  string
+		lists [][]UUID
+		want  []UUID
+	}{
+		{"none", nil, []UUID{}},
+		{"empty streams", [][]UUID{nil, {}}, []UUID{}},
+		{"single", [][]UUID{{a, b}}, []UUID{a, b}},
+		{"interleaved", [][]UUID{{a, c}, {b}}, []UUID{a, b, c}},
+		{"duplicates kept", [][]UUID{{a, b}, {b, c}}, []UUID{a, b, b, c}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSorted(tt.lists...); !slices.Equal(got, tt.want) {
+				t.Errorf("MergeSorted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
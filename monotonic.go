@@ -0,0 +1,123 @@
+package guuid
+
+import (
+	"sync"
+	"time"
+)
+
+// ViolationKind classifies a Violation
+type ViolationKind int
+
+const (
+	// ViolationOutOfOrder is an ID that sorts before the previous ID from
+	// the same source
+	ViolationOutOfOrder ViolationKind = iota + 1
+	// ViolationDuplicate is an ID already seen within the checker's window
+	ViolationDuplicate
+)
+
+// String returns "out of order" or "duplicate"
+func (k ViolationKind) String() string {
+	switch k {
+	case ViolationOutOfOrder:
+		return "out of order"
+	case ViolationDuplicate:
+		return "duplicate"
+	}
+	return "unknown"
+}
+
+// Violation describes an ID reported by a MonotonicityChecker
+type Violation struct {
+	Kind   ViolationKind
+	Source string // source the ID was checked for
+	ID     UUID
+	Prev   UUID // highest ID from Source before ID, Nil for its first ID
+
+	// Backstep is how far the timestamp of ID lies behind that of Prev, for
+	// UUIDv7s; zero if it does not
+	Backstep time.Duration
+}
+
+// MonotonicityStats holds counters accumulated by a MonotonicityChecker
+type MonotonicityStats struct {
+	Checked    uint64
+	OutOfOrder uint64
+	Duplicates uint64
+}
+
+// MonotonicityChecker continuously verifies a live feed of IDs, such as the
+// events consumed from a bus. IDs are checked per source, typically the
+// producing node: each must sort after the previous ID from its source, and
+// no ID may repeat one of the last window IDs from any source. It is safe for
+// concurrent use.
+type MonotonicityChecker struct {
+	onViolation func(Violation)
+
+	mu    sync.Mutex
+	last  map[string]UUID
+	stats MonotonicityStats
+
+	dedupeWindow
+}
+
+// NewMonotonicityChecker returns a MonotonicityChecker detecting duplicates
+// among the last window IDs and calling onViolation, if not nil, for every
+// violation found
+func NewMonotonicityChecker(window int, onViolation func(Violation)) *MonotonicityChecker {
+	return &MonotonicityChecker{
+		onViolation:  onViolation,
+		last:         make(map[string]UUID),
+		dedupeWindow: newDedupeWindow(window),
+	}
+}
+
+// Check records u as the latest ID from source and reports whether it is in
+// order and not a duplicate. An out-of-order ID does not lower the source's
+// high-water mark, so after a clock step every ID is reported until the
+// source catches up. Violations are passed to the callback before Check
+// returns; an out-of-order duplicate is reported once for each kind.
+func (c *MonotonicityChecker) Check(source string, u UUID) bool {
+	var found []Violation
+
+	c.mu.Lock()
+	c.stats.Checked++
+	prev, ok := c.last[source]
+	if ok && u.Compare(prev) < 0 {
+		c.stats.OutOfOrder++
+		v := Violation{Kind: ViolationOutOfOrder, Source: source, ID: u, Prev: prev}
+		if u.Version() == VersionTimeSorted && prev.Version() == VersionTimeSorted {
+			v.Backstep = max(time.Duration(prev.Timestamp()-u.Timestamp())*time.Millisecond, 0)
+		}
+		found = append(found, v)
+	} else {
+		c.last[source] = u
+	}
+	if c.record(u) {
+		c.stats.Duplicates++
+		found = append(found, Violation{Kind: ViolationDuplicate, Source: source, ID: u, Prev: prev})
+	}
+	c.mu.Unlock()
+
+	if c.onViolation != nil {
+		for _, v := range found {
+			c.onViolation(v)
+		}
+	}
+	return len(found) == 0
+}
+
+// Stats returns a snapshot of the checker's counters
+func (c *MonotonicityChecker) Stats() MonotonicityStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Forget drops the last ID recorded for source, e.g. when a node restarts
+// with a reset clock, so its next ID is not compared against the old one
+func (c *MonotonicityChecker) Forget(source string) {
+	c.mu.Lock()
+	delete(c.last, source)
+	c.mu.Unlock()
+}
@@ -0,0 +1,99 @@
+package guuid
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMonotonicityChecker(t *testing.T) {
+	var got []Violation
+	c := NewMonotonicityChecker(16, func(v Violation) { got = append(got, v) })
+
+	gen := NewGenerator()
+	at := time.UnixMilli(1700000000000)
+	a := Must(gen.NewWithTime(at))
+	b := Must(gen.NewWithTime(at.Add(10 * time.Millisecond)))
+	early := Must(NewV7FromParts(at.UnixMilli()+3, 0, [8]byte{}))
+
+	tests := []struct {
+		source string
+		id     UUID
+		ok     bool
+	}{
+		{"node-1", a, true},
+		{"node-2", early, true}, // sources are ordered independently
+		{"node-1", b, true},
+		{"node-1", early, false}, // out of order and a duplicate
+		{"node-1", b, false},     // duplicate, not out of order
+	}
+	for i, tt := range tests {
+		if ok := c.Check(tt.source, tt.id); ok != tt.ok {
+			t.Errorf("Check() #%d = %v, want %v", i, ok, tt.ok)
+		}
+	}
+
+	want := []Violation{
+		{Kind: ViolationOutOfOrder, Source: "node-1", ID: early, Prev: b, Backstep: 7 * time.Millisecond},
+		{Kind: ViolationDuplicate, Source: "node-1", ID: early, Prev: b},
+		{Kind: ViolationDuplicate, Source: "node-1", ID: b, Prev: b},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("violation %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if s := c.Stats(); s != (MonotonicityStats{Checked: 5, OutOfOrder: 1, Duplicates: 2}) {
+		t.Errorf("Stats() = %+v", s)
+	}
+}
+
+func TestMonotonicityChecker_Forget(t *testing.T) {
+	c := NewMonotonicityChecker(4, nil)
+	late := Must(NewV7FromParts(2000, 0, [8]byte{}))
+	early := Must(NewV7FromParts(1000, 0, [8]byte{}))
+
+	c.Check("n", late)
+	c.Forget("n")
+	if !c.Check("n", early) {
+		t.Error("Check() after Forget() = false, want true")
+	}
+}
+
+func TestMonotonicityChecker_Concurrent(t *testing.T) {
+	c := NewMonotonicityChecker(1024, func(v Violation) {
+		t.Errorf("unexpected violation %+v", v)
+	})
+	var wg sync.WaitGroup
+	for _, source := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func(source string) {
+			defer wg.Done()
+			gen := NewGenerator()
+			for i := 0; i < 1000; i++ {
+				c.Check(source, Must(gen.New()))
+			}
+		}(source)
+	}
+	wg.Wait()
+	if got := c.Stats().Checked; got != 4000 {
+		t.Errorf("Stats().Checked = %d, want 4000", got)
+	}
+}
+
+func TestViolationKind_String(t *testing.T) {
+	tests := map[ViolationKind]string{
+		ViolationOutOfOrder: "out of order",
+		ViolationDuplicate:  "duplicate",
+		0:                   "unknown",
+	}
+	for k, want := range tests {
+		if got := k.String(); got != want {
+			t.Errorf("ViolationKind(%d).String() = %q, want %q", k, got, want)
+		}
+	}
+}
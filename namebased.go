@@ -0,0 +1,123 @@
+package guuid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+	"io"
+)
+
+// Predefined namespaces for name-based UUIDs, from RFC 9562 section 6.6
+var (
+	NamespaceDNS  = MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceURL  = MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceOID  = MustParse("6ba7b812-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceX500 = MustParse("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+)
+
+// NewV3 returns the name-based UUIDv3 (MD5) for name in namespace ns
+func NewV3(ns UUID, name []byte) UUID {
+	return newHashed(md5.New(), VersionNameBasedMD5, ns, name)
+}
+
+// NewV5 returns the name-based UUIDv5 (SHA-1) for name in namespace ns
+func NewV5(ns UUID, name []byte) UUID {
+	return newHashed(sha1.New(), VersionNameBasedSHA1, ns, name)
+}
+
+// DeriveV5 derives a UUIDv5 from a path of names below ns, applying NewV5
+// once per part: DeriveV5(ns, "tenant-42", "invoice", "1001") equals
+// NewV5(NewV5(NewV5(ns, "tenant-42"), "invoice"), "1001"). The same path
+// always maps to the same UUID, in any environment, which makes derived IDs
+// suitable keys for idempotent upserts. Unlike joining the parts with a
+// separator, the derivation cannot confuse ("a/b", "c") with ("a", "b/c").
+//
+// With no parts, DeriveV5 returns ns.
+func DeriveV5(ns UUID, parts ...string) UUID {
+	id := ns
+	for _, p := range parts {
+		id = NewV5(id, []byte(p))
+	}
+	return id
+}
+
+// NewV8SHA256 returns a name-based UUIDv8 using SHA-256, for environments
+// where the SHA-1 of UUIDv5 is not acceptable. It follows the example in
+// RFC 9562 Appendix B.2:
+//
+//	digest = SHA-256(ns || name)
+//	UUID   = digest[0:16] with the version set to 8 and the variant to RFC 9562
+//
+// leaving 122 bits of the digest. As with v5, the same namespace and name
+// always produce the same UUID. UUIDv8 layouts are application-defined, so a
+// v8 UUID cannot be recognised as belonging to this profile from its bits
+// alone; use VerifyV8SHA256 to check one against its expected name.
+func NewV8SHA256(ns UUID, name []byte) UUID {
+	return newHashed(sha256.New(), VersionCustom, ns, name)
+}
+
+// DeriveV8SHA256 is DeriveV5 using NewV8SHA256 at each step
+func DeriveV8SHA256(ns UUID, parts ...string) UUID {
+	id := ns
+	for _, p := range parts {
+		id = NewV8SHA256(id, []byte(p))
+	}
+	return id
+}
+
+// VerifyV8SHA256 reports whether u is the NewV8SHA256 UUID for name in ns
+func VerifyV8SHA256(u, ns UUID, name []byte) bool {
+	return u == NewV8SHA256(ns, name)
+}
+
+// ValidateNameBased checks that u has the RFC 9562 variant and a name-based
+// version: 3, 5 or 8. It returns ErrInvalidVariant or ErrInvalidVersion.
+func ValidateNameBased(u UUID) error {
+	if u.Variant() != VariantRFC4122 {
+		return ErrInvalidVariant
+	}
+	switch u.Version() {
+	case VersionNameBasedMD5, VersionNameBasedSHA1, VersionCustom:
+		return nil
+	}
+	return ErrInvalidVersion
+}
+
+// NewFromReader returns the UUIDv5 of the content read from r until EOF in
+// namespace ns. It streams r through SHA-1 without buffering it, and equals
+// NewV5(ns, content), so large blobs can be deduplicated by ID.
+func NewFromReader(ns UUID, r io.Reader) (UUID, error) {
+	return newHashedReader(sha1.New(), VersionNameBasedSHA1, ns, r)
+}
+
+// NewV8FromReader is like NewFromReader but hashes with SHA-256, equalling
+// NewV8SHA256(ns, content)
+func NewV8FromReader(ns UUID, r io.Reader) (UUID, error) {
+	return newHashedReader(sha256.New(), VersionCustom, ns, r)
+}
+
+// newHashedReader is newHashed with the name streamed from r
+func newHashedReader(h hash.Hash, v Version, ns UUID, r io.Reader) (UUID, error) {
+	h.Write(ns[:])
+	if _, err := io.Copy(h, r); err != nil {
+		return Nil, err
+	}
+	return hashedUUID(h, v), nil
+}
+
+// newHashed builds a name-based UUID from the leading bytes of h(ns || name)
+func newHashed(h hash.Hash, v Version, ns UUID, name []byte) UUID {
+	h.Write(ns[:])
+	h.Write(name)
+	return hashedUUID(h, v)
+}
+
+// hashedUUID sets the version and variant on the leading bytes of h's digest
+func hashedUUID(h hash.Hash, v Version) UUID {
+	var uuid UUID
+	copy(uuid[:], h.Sum(nil))
+	uuid.SetVersion(v)
+	uuid.SetVariant(VariantRFC4122)
+	return uuid
+}
@@ -0,0 +1,126 @@
+package guuid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNewV3V5_KnownValues(t *testing.T) {
+	// Values from Python's uuid module and util-linux uuidgen
+	tests := []struct {
+		name string
+		got  UUID
+		want string
+	}{
+		{"v3 dns", NewV3(NamespaceDNS, []byte("python.org")), "6fa459ea-ee8a-3ca4-894e-db77e160355e"},
+		{"v5 dns", NewV5(NamespaceDNS, []byte("python.org")), "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{"v5 url", NewV5(NamespaceURL, []byte("http://python.org/")), "4c565f0d-3f5a-5890-b41b-20cf47701c5e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.String() != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeriveV5(t *testing.T) {
+	ns := NamespaceURL
+
+	got := DeriveV5(ns, "tenant-42", "invoice", "1001")
+	want := NewV5(NewV5(NewV5(ns, []byte("tenant-42")), []byte("invoice")), []byte("1001"))
+	if got != want {
+		t.Errorf("DeriveV5() = %v, want %v", got, want)
+	}
+	if got.Version() != VersionNameBasedSHA1 || got.Variant() != VariantRFC4122 {
+		t.Errorf("DeriveV5() version/variant = %v/%v", got.Version(), got.Variant())
+	}
+	if got != DeriveV5(ns, "tenant-42", "invoice", "1001") {
+		t.Error("DeriveV5() is not deterministic")
+	}
+
+	if DeriveV5(ns, "a/b", "c") == DeriveV5(ns, "a", "b/c") {
+		t.Error("DeriveV5() confused different paths")
+	}
+	if DeriveV5(ns) != ns {
+		t.Error("DeriveV5() with no parts should return ns")
+	}
+}
+
+func TestNewFromReader(t *testing.T) {
+	content := bytes.Repeat([]byte("large blob "), 100000)
+
+	got, err := NewFromReader(NamespaceURL, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("NewFromReader() error = %v", err)
+	}
+	if want := NewV5(NamespaceURL, content); got != want {
+		t.Errorf("NewFromReader() = %v, want NewV5() %v", got, want)
+	}
+
+	// RFC 9562 Appendix B.2 example of a SHA-256 name-based UUIDv8
+	v8, err := NewV8FromReader(NamespaceDNS, strings.NewReader("www.example.com"))
+	if err != nil {
+		t.Fatalf("NewV8FromReader() error = %v", err)
+	}
+	if want := "5c146b14-3c52-8afd-938a-375d0df1fbf6"; v8.String() != want {
+		t.Errorf("NewV8FromReader() = %v, want %v", v8, want)
+	}
+}
+
+func TestNewFromReader_Error(t *testing.T) {
+	if _, err := NewFromReader(NamespaceURL, iotest.ErrReader(io.ErrUnexpectedEOF)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("NewFromReader() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestNewV8SHA256(t *testing.T) {
+	// RFC 9562 Appendix B.2
+	got := NewV8SHA256(NamespaceDNS, []byte("www.example.com"))
+	if want := "5c146b14-3c52-8afd-938a-375d0df1fbf6"; got.String() != want {
+		t.Errorf("NewV8SHA256() = %v, want %v", got, want)
+	}
+	if got.Version() != VersionCustom || got.Variant() != VariantRFC4122 {
+		t.Errorf("NewV8SHA256() version/variant = %v/%v", got.Version(), got.Variant())
+	}
+
+	if !VerifyV8SHA256(got, NamespaceDNS, []byte("www.example.com")) {
+		t.Error("VerifyV8SHA256() = false for matching name")
+	}
+	if VerifyV8SHA256(got, NamespaceURL, []byte("www.example.com")) {
+		t.Error("VerifyV8SHA256() = true for wrong namespace")
+	}
+
+	derived := DeriveV8SHA256(NamespaceURL, "tenant", "invoice")
+	if want := NewV8SHA256(NewV8SHA256(NamespaceURL, []byte("tenant")), []byte("invoice")); derived != want {
+		t.Errorf("DeriveV8SHA256() = %v, want %v", derived, want)
+	}
+}
+
+func TestValidateNameBased(t *testing.T) {
+	tests := []struct {
+		name string
+		uuid UUID
+		want error
+	}{
+		{"v3", NewV3(NamespaceDNS, []byte("x")), nil},
+		{"v5", NewV5(NamespaceDNS, []byte("x")), nil},
+		{"v8", NewV8SHA256(NamespaceDNS, []byte("x")), nil},
+		{"v7", Must(New()), ErrInvalidVersion},
+		{"nil", Nil, ErrInvalidVariant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateNameBased(tt.uuid); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateNameBased() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package guuid
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultNamespaceRoot is the root of the namespaces returned by the
+// package-level Namespace function
+var DefaultNamespaceRoot = NewV5(NamespaceURL, []byte("https://github.com/Lzww0608/guuid/namespace"))
+
+// NamespaceRegistry derives application namespaces for name-based UUIDs from a
+// root namespace, so teams can refer to namespaces by name instead of
+// hard-coding random constants. A dotted name is derived one segment at a
+// time with DeriveV5, so "billing.invoice" is the child "invoice" of the
+// namespace "billing", and the same name maps to the same UUID in every
+// process that shares the root.
+//
+// A NamespaceRegistry is safe for concurrent use.
+type NamespaceRegistry struct {
+	root UUID
+
+	mu     sync.RWMutex
+	byName map[string]UUID
+	byID   map[UUID]string
+}
+
+// NewNamespaceRegistry returns an empty registry deriving namespaces from root
+func NewNamespaceRegistry(root UUID) *NamespaceRegistry {
+	return &NamespaceRegistry{
+		root:   root,
+		byName: make(map[string]UUID),
+		byID:   make(map[UUID]string),
+	}
+}
+
+// Root returns the namespace all others are derived from
+func (r *NamespaceRegistry) Root() UUID {
+	return r.root
+}
+
+// Namespace returns the namespace for name, registering it on first use
+func (r *NamespaceRegistry) Namespace(name string) UUID {
+	r.mu.RLock()
+	ns, ok := r.byName[name]
+	r.mu.RUnlock()
+	if ok {
+		return ns
+	}
+
+	ns = DeriveV5(r.root, strings.Split(name, ".")...)
+	r.mu.Lock()
+	r.byName[name] = ns
+	r.byID[ns] = name
+	r.mu.Unlock()
+	return ns
+}
+
+// Lookup returns the namespace registered under name
+func (r *NamespaceRegistry) Lookup(name string) (UUID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ns, ok := r.byName[name]
+	return ns, ok
+}
+
+// NameOf returns the name a registered namespace was derived from
+func (r *NamespaceRegistry) NameOf(ns UUID) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.byID[ns]
+	return name, ok
+}
+
+// Names returns the registered names in sorted order
+func (r *NamespaceRegistry) Names() []string {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// defaultNamespaces is the registry used by the package-level Namespace function
+var defaultNamespaces = NewNamespaceRegistry(DefaultNamespaceRoot)
+
+// Namespace returns the namespace for name derived from DefaultNamespaceRoot,
+// e.g. guuid.NewV5(guuid.Namespace("billing.invoice"), []byte(invoiceNo)).
+// Applications that must not share namespaces with other users of this
+// package should create their own NamespaceRegistry with a private root.
+func Namespace(name string) UUID {
+	return defaultNamespaces.Namespace(name)
+}
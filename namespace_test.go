@@ -0,0 +1,66 @@
+package guuid
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestNamespaceRegistry(t *testing.T) {
+	root := NewV5(NamespaceURL, []byte("https://example.com"))
+	r := NewNamespaceRegistry(root)
+
+	invoice := r.Namespace("billing.invoice")
+	if want := DeriveV5(root, "billing", "invoice"); invoice != want {
+		t.Errorf("Namespace(billing.invoice) = %v, want %v", invoice, want)
+	}
+	if got := NewV5(r.Namespace("billing"), []byte("invoice")); got != invoice {
+		t.Errorf("child of billing = %v, want %v", got, invoice)
+	}
+	if r.Namespace("billing.invoice") != invoice {
+		t.Error("Namespace() is not stable")
+	}
+
+	if ns, ok := r.Lookup("billing.invoice"); !ok || ns != invoice {
+		t.Errorf("Lookup() = %v, %v, want %v, true", ns, ok, invoice)
+	}
+	if _, ok := r.Lookup("unknown"); ok {
+		t.Error("Lookup(unknown) = true")
+	}
+	if name, ok := r.NameOf(invoice); !ok || name != "billing.invoice" {
+		t.Errorf("NameOf() = %q, %v", name, ok)
+	}
+	if got, want := r.Names(), []string{"billing", "billing.invoice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	other := NewNamespaceRegistry(NamespaceDNS)
+	if other.Namespace("billing.invoice") == invoice {
+		t.Error("registries with different roots produced the same namespace")
+	}
+}
+
+func TestNamespaceRegistry_Concurrent(t *testing.T) {
+	r := NewNamespaceRegistry(DefaultNamespaceRoot)
+	var wg sync.WaitGroup
+	results := make([]UUID, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = r.Namespace("orders.line")
+		}(i)
+	}
+	wg.Wait()
+	for _, ns := range results[1:] {
+		if ns != results[0] {
+			t.Fatal("concurrent Namespace() calls disagree")
+		}
+	}
+}
+
+func TestNamespace_Default(t *testing.T) {
+	if got, want := Namespace("billing.invoice"), DeriveV5(DefaultNamespaceRoot, "billing", "invoice"); got != want {
+		t.Errorf("Namespace() = %v, want %v", got, want)
+	}
+}
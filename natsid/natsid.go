@@ -0,0 +1,160 @@
+// Package natsid serves and requests UUIDv7s over NATS request/reply.
+//
+// The package has no dependency on a NATS client library; it works on raw
+// message payloads so it can be wired to any client version. A responder
+// subscribes with Handle:
+//
+//	gen := guuid.NewGenerator()
+//	nc.Subscribe("ids.v7", func(m *nats.Msg) {
+//		m.Respond(natsid.Handle(gen, m.Data))
+//	})
+//
+// and a Client wraps the connection's Request method:
+//
+//	c := natsid.NewClient(func(data []byte) ([]byte, error) {
+//		m, err := nc.Request("ids.v7", data, time.Second)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return m.Data, nil
+//	}, 256)
+//
+// A request payload is the batch size as ASCII decimal; an empty payload asks
+// for one ID. A reply is a status byte followed by n*16 bytes of UUIDs
+// (status 0) or a UTF-8 error message (status 1).
+package natsid
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// MaxPayload is the NATS server's default max_payload, the largest message it
+// accepts
+const MaxPayload = 1 << 20
+
+// MaxBatch is the largest number of UUIDs a single request may ask for, sized
+// so that the reply fits in MaxPayload
+const MaxBatch = (MaxPayload - 1) / 16
+
+// DefaultBatchSize is the number of UUIDs a Client fetches per request
+const DefaultBatchSize = 128
+
+const (
+	statusOK    = 0
+	statusError = 1
+)
+
+// Handle builds the reply for a request payload using gen, or the default
+// generator if gen is nil.
+func Handle(gen *guuid.Generator, data []byte) []byte {
+	if gen == nil {
+		gen = guuid.Default()
+	}
+
+	n := 1
+	if len(data) > 0 {
+		v, err := strconv.Atoi(string(data))
+		if err != nil || v < 1 || v > MaxBatch {
+			return errorReply("batch size out of range")
+		}
+		n = v
+	}
+
+	reply := make([]byte, 1, 1+n*16)
+	reply[0] = statusOK
+	for i := 0; i < n; i++ {
+		id, err := gen.New()
+		if err != nil {
+			return errorReply(err.Error())
+		}
+		reply = append(reply, id[:]...)
+	}
+	return reply
+}
+
+// errorReply encodes an error reply
+func errorReply(msg string) []byte {
+	return append([]byte{statusError}, msg...)
+}
+
+// RequestFunc sends a request payload to the ID service and returns the reply
+// payload, typically by wrapping nats.Conn.Request.
+type RequestFunc func(data []byte) ([]byte, error)
+
+// Client requests UUIDs in batches and serves them from a local cache.
+// IDs within a batch are ordered; IDs cached by different clients interleave.
+//
+// Client is safe for concurrent use.
+type Client struct {
+	request RequestFunc
+	batch   int
+
+	mu  sync.Mutex
+	buf []guuid.UUID
+}
+
+// NewClient returns a Client that fetches batchSize IDs per request.
+// A batchSize outside 1..MaxBatch selects DefaultBatchSize.
+func NewClient(request RequestFunc, batchSize int) *Client {
+	if batchSize < 1 || batchSize > MaxBatch {
+		batchSize = DefaultBatchSize
+	}
+	return &Client{request: request, batch: batchSize}
+}
+
+// New returns the next cached UUID, requesting a new batch when the cache is empty
+func (c *Client) New() (guuid.UUID, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.buf) == 0 {
+		ids, err := c.fetch(c.batch)
+		if err != nil {
+			return guuid.Nil, err
+		}
+		c.buf = ids
+	}
+	id := c.buf[0]
+	c.buf = c.buf[1:]
+	return id, nil
+}
+
+// NewBatch requests n fresh UUIDs, bypassing the cache
+func (c *Client) NewBatch(n int) ([]guuid.UUID, error) {
+	if n < 1 || n > MaxBatch {
+		return nil, errors.New("natsid: batch size " + strconv.Itoa(n) + " out of range")
+	}
+	return c.fetch(n)
+}
+
+// fetch performs one request and decodes the reply
+func (c *Client) fetch(n int) ([]guuid.UUID, error) {
+	reply, err := c.request([]byte(strconv.Itoa(n)))
+	if err != nil {
+		return nil, err
+	}
+	return decodeReply(reply, n)
+}
+
+// decodeReply parses a reply payload expected to hold n UUIDs
+func decodeReply(reply []byte, n int) ([]guuid.UUID, error) {
+	if len(reply) == 0 {
+		return nil, errors.New("natsid: empty reply")
+	}
+	if reply[0] != statusOK {
+		return nil, errors.New("natsid: " + string(reply[1:]))
+	}
+	body := reply[1:]
+	if len(body) != n*16 {
+		return nil, errors.New("natsid: malformed reply")
+	}
+	ids := make([]guuid.UUID, n)
+	for i := range ids {
+		copy(ids[i][:], body[i*16:])
+	}
+	return ids, nil
+}
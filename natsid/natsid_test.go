@@ -0,0 +1,111 @@
+package natsid
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// loopback returns a RequestFunc that answers in-process and counts calls
+func loopback(gen *guuid.Generator, calls *int) RequestFunc {
+	return func(data []byte) ([]byte, error) {
+		*calls++
+		return Handle(gen, data), nil
+	}
+}
+
+func TestHandle(t *testing.T) {
+	gen := guuid.NewGenerator()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantN   int
+		wantErr bool
+	}{
+		{"empty means one", "", 1, false},
+		{"batch", "10", 10, false},
+		{"max", "65535", MaxBatch, false},
+		{"zero", "0", 0, true},
+		{"too large", "65536", 0, true},
+		{"not a number", "abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := decodeReply(Handle(gen, []byte(tt.data)), tt.wantN)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Handle(%q) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if len(ids) != tt.wantN {
+				t.Errorf("Handle(%q) returned %d IDs, want %d", tt.data, len(ids), tt.wantN)
+			}
+		})
+	}
+}
+
+func TestHandle_ReplyFitsMaxPayload(t *testing.T) {
+	reply := Handle(guuid.NewGenerator(), []byte(strconv.Itoa(MaxBatch)))
+	if reply[0] != statusOK {
+		t.Fatalf("Handle(MaxBatch) error = %s", reply[1:])
+	}
+	if len(reply) > 1<<20 {
+		t.Errorf("Handle(MaxBatch) reply is %d bytes, want at most 1 MiB", len(reply))
+	}
+}
+
+func TestClient_New_Caches(t *testing.T) {
+	var calls int
+	c := NewClient(loopback(guuid.NewGenerator(), &calls), 10)
+
+	var prev guuid.UUID
+	for i := 0; i < 25; i++ {
+		id, err := c.New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if id.Compare(prev) <= 0 {
+			t.Errorf("New() = %v, not after %v", id, prev)
+		}
+		prev = id
+	}
+	if calls != 3 {
+		t.Errorf("requests = %d, want 3", calls)
+	}
+}
+
+func TestClient_NewBatch(t *testing.T) {
+	var calls int
+	c := NewClient(loopback(guuid.NewGenerator(), &calls), 0)
+
+	ids, err := c.NewBatch(500)
+	if err != nil {
+		t.Fatalf("NewBatch() error = %v", err)
+	}
+	if len(ids) != 500 {
+		t.Errorf("NewBatch() returned %d IDs, want 500", len(ids))
+	}
+	if _, err := c.NewBatch(0); err == nil {
+		t.Error("NewBatch(0) expected error")
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	transport := errors.New("no responders")
+	c := NewClient(func([]byte) ([]byte, error) { return nil, transport }, 1)
+	if _, err := c.New(); !errors.Is(err, transport) {
+		t.Errorf("New() error = %v, want %v", err, transport)
+	}
+
+	c = NewClient(func([]byte) ([]byte, error) { return []byte{statusOK, 1, 2}, nil }, 1)
+	if _, err := c.New(); err == nil {
+		t.Error("New() expected error for malformed reply")
+	}
+
+	c = NewClient(func([]byte) ([]byte, error) { return errorReply("boom"), nil }, 1)
+	if _, err := c.New(); err == nil || err.Error() != "natsid: boom" {
+		t.Errorf("New() error = %v, want natsid: boom", err)
+	}
+}
@@ -0,0 +1,65 @@
+package guuid
+
+import "database/sql/driver"
+
+// NullUUID represents a UUID that may be null. It implements the sql.Scanner
+// and driver.Valuer interfaces so it can be used for nullable columns, and
+// marshals to JSON null when not valid.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements the sql.Scanner interface. An empty []byte, which some
+// drivers return for NULL binary columns, scans as NULL.
+func (n *NullUUID) Scan(src interface{}) error {
+	if b, ok := src.([]byte); src == nil || ok && len(b) == 0 {
+		n.UUID, n.Valid = Nil, false
+		return nil
+	}
+	if err := n.UUID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Value()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// An invalid NullUUID is encoded as null.
+func (n NullUUID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	buf := make([]byte, 38)
+	buf[0] = '"'
+	encodeHex(buf[1:37], n.UUID)
+	buf[37] = '"'
+	return buf, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// A JSON null decodes to an invalid NullUUID.
+func (n *NullUUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.UUID, n.Valid = Nil, false
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return ErrInvalidFormat
+	}
+	id, err := Parse(string(data[1 : len(data)-1]))
+	if err != nil {
+		return err
+	}
+	n.UUID, n.Valid = id, true
+	return nil
+}
@@ -0,0 +1,94 @@
+package guuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullUUID_Scan(t *testing.T) {
+	var n NullUUID
+	if err := n.Scan("f47ac10b-58cc-4372-a567-0e02b2c3d479"); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if !n.Valid || n.UUID != MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479") {
+		t.Errorf("Scan() = %+v, want valid UUID", n)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error = %v", err)
+	}
+	if n.Valid || !n.UUID.IsNil() {
+		t.Errorf("Scan(nil) = %+v, want invalid", n)
+	}
+
+	n = NullUUID{UUID: MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), Valid: true}
+	if err := n.Scan([]byte{}); err != nil {
+		t.Fatalf("Scan([]byte{}) error = %v", err)
+	}
+	if n.Valid || !n.UUID.IsNil() {
+		t.Errorf("Scan([]byte{}) = %+v, want invalid", n)
+	}
+
+	if err := n.Scan(123); err == nil {
+		t.Error("Scan() expected error for invalid type")
+	}
+}
+
+func TestNullUUID_Value(t *testing.T) {
+	val, err := NullUUID{}.Value()
+	if err != nil || val != nil {
+		t.Errorf("Value() of invalid NullUUID = %v, %v, want nil, nil", val, err)
+	}
+
+	uuid := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	val, err = NullUUID{UUID: uuid, Valid: true}.Value()
+	if err != nil || val != uuid.String() {
+		t.Errorf("Value() = %v, %v, want %v, nil", val, err, uuid.String())
+	}
+}
+
+func TestNullUUID_JSON(t *testing.T) {
+	type TestStruct struct {
+		ID NullUUID `json:"id"`
+	}
+
+	data, err := json.Marshal(TestStruct{})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != `{"id":null}` {
+		t.Errorf("json.Marshal() = %s, want {\"id\":null}", data)
+	}
+
+	// Callers may modify the returned slice
+	b, _ := NullUUID{}.MarshalJSON()
+	b[0] = 'X'
+	if b, _ = (NullUUID{}).MarshalJSON(); string(b) != "null" {
+		t.Errorf("MarshalJSON() after modifying an earlier result = %s, want null", b)
+	}
+
+	var ts TestStruct
+	ts.ID.Valid = true
+	if err := json.Unmarshal(data, &ts); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if ts.ID.Valid {
+		t.Error("json.Unmarshal(null) produced valid NullUUID")
+	}
+
+	uuid := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	data, err = json.Marshal(TestStruct{ID: NullUUID{UUID: uuid, Valid: true}})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if err := json.Unmarshal(data, &ts); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !ts.ID.Valid || ts.ID.UUID != uuid {
+		t.Errorf("JSON round-trip = %+v, want %v", ts.ID, uuid)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"not-a-uuid"}`), &ts); err == nil {
+		t.Error("json.Unmarshal() expected error for invalid UUID")
+	}
+}
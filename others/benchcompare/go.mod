@@ -0,0 +1,11 @@
+module github.com/Lzww0608/guuid/others/benchcompare
+
+go 1.21.0
+
+require (
+	github.com/Lzww0608/guuid v0.0.0
+	github.com/gofrs/uuid/v5 v5.4.0
+	github.com/google/uuid v1.6.0
+)
+
+replace github.com/Lzww0608/guuid => ../..
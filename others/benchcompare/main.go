@@ -0,0 +1,125 @@
+// Command benchcompare measures guuid against github.com/google/uuid and
+// github.com/gofrs/uuid on the local machine. It complements "guuid bench",
+// which covers guuid alone; the comparison lives in this nested module so the
+// root module keeps no external dependencies.
+//
+// Usage:
+//
+//	cd others/benchcompare && go run . [-run parse]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/Lzww0608/guuid"
+	gofrs "github.com/gofrs/uuid/v5"
+	google "github.com/google/uuid"
+)
+
+// benchCase is a single throughput measurement of one library
+type benchCase struct {
+	lib  string
+	name string
+	fn   func(b *testing.B)
+}
+
+// benchCases returns the operations measured for each library. Every library
+// generates version 7 UUIDs so the generation numbers are comparable.
+func benchCases() []benchCase {
+	sample := guuid.Must(guuid.New())
+	canonical := sample.String()
+	googleSample := google.UUID(sample)
+	gofrsSample := gofrs.UUID(sample)
+
+	return []benchCase{
+		{"guuid", "new", func(b *testing.B) {
+			gen := guuid.NewGenerator()
+			for i := 0; i < b.N; i++ {
+				if _, err := gen.New(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"google", "new", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := google.NewV7(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"gofrs", "new", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := gofrs.NewV7(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"guuid", "parse", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := guuid.Parse(canonical); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"google", "parse", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := google.Parse(canonical); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"gofrs", "parse", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := gofrs.FromString(canonical); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{"guuid", "string", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = sample.String()
+			}
+		}},
+		{"google", "string", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = googleSample.String()
+			}
+		}},
+		{"gofrs", "string", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = gofrsSample.String()
+			}
+		}},
+	}
+}
+
+func main() {
+	filter := flag.String("run", "", "only run cases whose name contains this string")
+	flag.Parse()
+
+	fmt.Printf("%s %s/%s, GOMAXPROCS=%d\n", runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.GOMAXPROCS(0))
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "case\tlibrary\tns/op\tops/s\tallocs/op")
+	for _, c := range benchCases() {
+		if *filter != "" && !strings.Contains(c.name, *filter) {
+			continue
+		}
+		fn := c.fn
+		res := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			fn(b)
+		})
+		nsPerOp := float64(res.T.Nanoseconds()) / float64(res.N)
+		fmt.Fprintf(tw, "%s\t%s\t%.1f\t%.0f\t%d\n", c.name, c.lib, nsPerOp, 1e9/nsPerOp, res.AllocsPerOp())
+	}
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "benchcompare: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/Lzww0608/guuid/others/leafSegment/leafpb"
+)
+
+// accessSweepInterval is how often buckets of idle clients are dropped
+const accessSweepInterval = time.Minute
+
+// grpcAccess authenticates gRPC callers by API key and limits how many IDs
+// each client may draw, like guuidd's WithAPIKeys and WithQuota.
+type grpcAccess struct {
+	keys  map[string]string // API key -> client name; nil leaves the service open
+	rate  float64           // IDs per second per client; 0 for unlimited
+	burst int               // IDs a client may draw at once
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// newGRPCAccess returns access control for keys and a per-client rate. A
+// burst of zero or less allows one second of rate, and at least one ID.
+func newGRPCAccess(keys map[string]string, rate float64, burst int) *grpcAccess {
+	if burst <= 0 {
+		burst = max(int(rate), 1)
+	}
+	return &grpcAccess{keys: keys, rate: rate, burst: burst}
+}
+
+// intercept is a grpc.UnaryServerInterceptor enforcing the API keys and rate
+func (a *grpcAccess) intercept(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	client, ok := a.authenticate(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid API key")
+	}
+	if a.rate > 0 {
+		n := 1
+		if r, ok := req.(*leafpb.GetIDBatchRequest); ok && r.GetCount() > 1 {
+			n = int(r.GetCount())
+		}
+		if n > a.burst {
+			return nil, status.Errorf(codes.InvalidArgument, "count must not exceed the quota burst of %d", a.burst)
+		}
+		if ok, wait := a.take(client, n, time.Now()); !ok {
+			return nil, status.Errorf(codes.ResourceExhausted, "quota exceeded, retry in %v", wait.Round(time.Millisecond))
+		}
+	}
+	return handler(ctx, req)
+}
+
+// authenticate resolves the caller to a client name: the name of its API key
+// from the "authorization: Bearer" or "x-api-key" metadata, or its peer host
+// when no keys are configured
+func (a *grpcAccess) authenticate(ctx context.Context) (string, bool) {
+	if a.keys == nil {
+		if p, ok := peer.FromContext(ctx); ok {
+			if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+				return host, true
+			}
+			return p.Addr.String(), true
+		}
+		return "", true
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	key := first(md.Get("x-api-key"))
+	if auth := first(md.Get("authorization")); key == "" && auth != "" {
+		key, _ = strings.CutPrefix(auth, "Bearer ")
+	}
+	if key == "" {
+		return "", false
+	}
+
+	// Compare against every key so the response time does not reveal a prefix match
+	name, found := "", false
+	for k, v := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			name, found = v, true
+		}
+	}
+	return name, found
+}
+
+// first returns the first of vals, or ""
+func first(vals []string) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+
+// take tries to withdraw n IDs for client, returning how long until they
+// are available if the bucket is short
+func (a *grpcAccess) take(client string, n int, now time.Time) (bool, time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.buckets == nil {
+		a.buckets = make(map[string]*bucket)
+	}
+	if now.Sub(a.swept) >= accessSweepInterval {
+		a.swept = now
+		for c, b := range a.buckets {
+			if b.refill(a.rate, a.burst, now) >= float64(a.burst) {
+				delete(a.buckets, c)
+			}
+		}
+	}
+	b := a.buckets[client]
+	if b == nil {
+		b = &bucket{tokens: float64(a.burst), last: now}
+		a.buckets[client] = b
+	}
+	b.tokens, b.last = b.refill(a.rate, a.burst, now), now
+	if float64(n) <= b.tokens {
+		b.tokens -= float64(n)
+		return true, 0
+	}
+	return false, time.Duration((float64(n) - b.tokens) / a.rate * float64(time.Second))
+}
+
+// bucket is a token bucket refilled continuously at the client rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill returns the tokens the bucket holds at now
+func (b *bucket) refill(rate float64, burst int, now time.Time) float64 {
+	return math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+}
+
+// loadKeys reads an API key file of "client key" lines, the format used by
+// cmd/guuidd. Blank lines and lines starting with # are ignored.
+func loadKeys(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := make(map[string]string)
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: want \"client key\"", path, line)
+		}
+		keys[fields[1]] = fields[0]
+	}
+	return keys, sc.Err()
+}
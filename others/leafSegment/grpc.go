@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Lzww0608/guuid/others/leafSegment/leafclient"
+	"github.com/Lzww0608/guuid/others/leafSegment/leafpb"
+)
+
+// MaxBatchSize is the largest number of IDs a single GetIDBatch call may request.
+const MaxBatchSize = leafclient.MaxBatchSize
+
+// GetIDBatch returns the next n IDs for the chosen business tag, in increasing order.
+func (s *LeafServer) GetIDBatch(bizTag string, n int) ([]int64, error) {
+	ids := make([]int64, 0, n)
+	for i := 0; i < n; i++ {
+		id, err := s.GetID(bizTag)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// serveGRPC serves the Leaf gRPC service for leaf on addr, with callers
+// checked by access, until the listener fails or SIGTERM/SIGINT arrives. On a
+// signal it stops accepting new calls and lets in-flight ones finish for up
+// to grace before closing connections.
+func serveGRPC(leaf *LeafServer, addr string, grace time.Duration, access *grpcAccess) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(access.intercept))
+	leafpb.RegisterLeafServer(srv, newGRPCServer(leaf))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		log.Printf("Leaf gRPC server draining")
+		gracefulStop(srv, grace)
+	}()
+
+	log.Printf("Leaf gRPC server listening on %s", lis.Addr())
+	return srv.Serve(lis)
+}
+
+// gracefulStop waits up to grace for in-flight calls, then forces srv to stop.
+func gracefulStop(srv *grpc.Server, grace time.Duration) {
+	timer := time.AfterFunc(grace, srv.Stop)
+	defer timer.Stop()
+	srv.GracefulStop()
+}
+
+// grpcServer exposes a LeafServer through the leafpb.Leaf gRPC service.
+type grpcServer struct {
+	leafpb.UnimplementedLeafServer
+	leaf *LeafServer
+}
+
+// newGRPCServer wraps leaf in a leafpb.LeafServer implementation.
+func newGRPCServer(leaf *LeafServer) *grpcServer {
+	return &grpcServer{leaf: leaf}
+}
+
+// GetID implements leafpb.LeafServer.
+func (s *grpcServer) GetID(ctx context.Context, req *leafpb.GetIDRequest) (*leafpb.GetIDResponse, error) {
+	if req.GetBizTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag is required")
+	}
+	id, err := s.leaf.GetID(req.GetBizTag())
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "allocate id: %v", err)
+	}
+	return &leafpb.GetIDResponse{Id: id}, nil
+}
+
+// GetIDBatch implements leafpb.LeafServer.
+func (s *grpcServer) GetIDBatch(ctx context.Context, req *leafpb.GetIDBatchRequest) (*leafpb.GetIDBatchResponse, error) {
+	if req.GetBizTag() == "" {
+		return nil, status.Error(codes.InvalidArgument, "biz_tag is required")
+	}
+	if n := req.GetCount(); n < 1 || n > MaxBatchSize {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("count must be between 1 and %d", MaxBatchSize))
+	}
+	ids, err := s.leaf.GetIDBatch(req.GetBizTag(), int(req.GetCount()))
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "allocate ids: %v", err)
+	}
+	return &leafpb.GetIDBatchResponse{Ids: ids}, nil
+}
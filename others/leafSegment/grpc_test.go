@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Lzww0608/guuid/others/leafSegment/leafclient"
+	"github.com/Lzww0608/guuid/others/leafSegment/leafpb"
+)
+
+// newTestConn serves a LeafServer with a preloaded "order" segment over an
+// in-memory listener and returns a client connection to it
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	return newTestConnWith(t, newGRPCAccess(nil, 0, 0))
+}
+
+// newTestConnWith is newTestConn with access control and extra dial options
+func newTestConnWith(t *testing.T, access *grpcAccess, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	leaf := &LeafServer{buffers: map[string]*DoubleBuffer{
+		"order": {bizTag: "order", current: NewSegment(1000, 1000+1<<20, 1<<20)},
+	}}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(access.intercept))
+	leafpb.RegisterLeafServer(srv, newGRPCServer(leaf))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	opts = append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestGRPC_RoundTrip(t *testing.T) {
+	client := leafclient.NewClient(newTestConn(t))
+	ctx := context.Background()
+
+	first, err := client.GetID(ctx, "order")
+	if err != nil || first != 1001 {
+		t.Fatalf("GetID() = %d, %v, want 1001", first, err)
+	}
+	ids, err := client.GetIDBatch(ctx, "order", 5)
+	if err != nil {
+		t.Fatalf("GetIDBatch() error = %v", err)
+	}
+	if want := []int64{1002, 1003, 1004, 1005, 1006}; !slices.Equal(ids, want) {
+		t.Errorf("GetIDBatch() = %v, want %v", ids, want)
+	}
+	if ids, err = client.GetIDBatch(ctx, "order", MaxBatchSize); err != nil || len(ids) != MaxBatchSize {
+		t.Errorf("GetIDBatch(MaxBatchSize) = %d IDs, %v", len(ids), err)
+	}
+}
+
+func TestGRPC_InvalidArguments(t *testing.T) {
+	rpc := leafpb.NewLeafClient(newTestConn(t))
+	ctx := context.Background()
+
+	if _, err := rpc.GetID(ctx, &leafpb.GetIDRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetID() without biz_tag error = %v, want InvalidArgument", err)
+	}
+	for _, count := range []int32{-1, 0, MaxBatchSize + 1, math.MaxInt32} {
+		_, err := rpc.GetIDBatch(ctx, &leafpb.GetIDBatchRequest{BizTag: "order", Count: count})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetIDBatch(count=%d) error = %v, want InvalidArgument", count, err)
+		}
+	}
+	if _, err := rpc.GetIDBatch(ctx, &leafpb.GetIDBatchRequest{Count: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetIDBatch() without biz_tag error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestClient_GetIDBatchCountRange(t *testing.T) {
+	client := leafclient.NewClient(newTestConn(t))
+	counts := []int{0, -5}
+	if math.MaxInt > math.MaxInt32 {
+		big := int64(math.MaxInt32) + 1
+		counts = append(counts, int(big), int(2*big))
+	}
+	for _, n := range counts {
+		if _, err := client.GetIDBatch(context.Background(), "order", n); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetIDBatch(%d) error = %v, want InvalidArgument", n, err)
+		}
+	}
+}
+
+func TestGRPC_APIKeys(t *testing.T) {
+	access := newGRPCAccess(map[string]string{"secret": "billing"}, 0, 0)
+	ctx := context.Background()
+
+	for _, key := range []string{"", "wrong"} {
+		var opts []grpc.DialOption
+		if key != "" {
+			opts = append(opts, leafclient.WithAPIKey(key))
+		}
+		client := leafclient.NewClient(newTestConnWith(t, access, opts...))
+		if _, err := client.GetID(ctx, "order"); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("GetID() with key %q error = %v, want Unauthenticated", key, err)
+		}
+	}
+
+	client := leafclient.NewClient(newTestConnWith(t, access, leafclient.WithAPIKey("secret")))
+	if _, err := client.GetID(ctx, "order"); err != nil {
+		t.Errorf("GetID() with a valid key error = %v", err)
+	}
+}
+
+func TestGRPC_Quota(t *testing.T) {
+	access := newGRPCAccess(map[string]string{"a": "team-a", "b": "team-b"}, 0.001, 10)
+	ctx := context.Background()
+	a := leafclient.NewClient(newTestConnWith(t, access, leafclient.WithAPIKey("a")))
+
+	if _, err := a.GetIDBatch(ctx, "order", 11); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetIDBatch() above the burst error = %v, want InvalidArgument", err)
+	}
+	if _, err := a.GetIDBatch(ctx, "order", 10); err != nil {
+		t.Fatalf("GetIDBatch() within the burst error = %v", err)
+	}
+	if _, err := a.GetID(ctx, "order"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("GetID() over quota error = %v, want ResourceExhausted", err)
+	}
+
+	// Each client has its own bucket
+	b := leafclient.NewClient(newTestConnWith(t, access, leafclient.WithAPIKey("b")))
+	if _, err := b.GetID(ctx, "order"); err != nil {
+		t.Errorf("GetID() for another client error = %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"sync"
@@ -276,13 +277,34 @@ func (s *LeafServer) GetID(bizTag string) (int64, error) {
 
 func main() {
 	// Please modify this DSN with your real DB credentials before use.
-	dsn := "lzww:123456@tcp(127.0.0.1:3306)/test_db?parseTime=true"
-
-	server, err := NewLeafServer(dsn)
+	dsn := flag.String("dsn", "lzww:123456@tcp(127.0.0.1:3306)/test_db?parseTime=true", "MySQL data source name")
+	grpcAddr := flag.String("grpc", "", "serve the Leaf gRPC service on this address (e.g. :9090) instead of running the demo")
+	grace := flag.Duration("grace", 30*time.Second, "time allowed for in-flight gRPC calls to finish on shutdown")
+	keysFile := flag.String("keys", "", "file of \"client key\" lines; gRPC callers must present one of the keys")
+	rate := flag.Float64("quota", 0, "IDs per second allowed per gRPC client (0 for unlimited)")
+	burst := flag.Int("burst", 0, "IDs a gRPC client may draw at once (default: one second of -quota)")
+	flag.Parse()
+
+	server, err := NewLeafServer(*dsn)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if *grpcAddr != "" {
+		var keys map[string]string
+		if *keysFile != "" {
+			if keys, err = loadKeys(*keysFile); err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			log.Printf("Leaf gRPC server has no -keys file; any caller may draw IDs")
+		}
+		if err := serveGRPC(server, *grpcAddr, *grace, newGRPCAccess(keys, *rate, *burst)); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	log.Println("Leaf Server Started...")
 
 	var wg sync.WaitGroup
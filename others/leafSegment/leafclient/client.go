@@ -0,0 +1,98 @@
+// Package leafclient is a Go client for the leaf segment gRPC service.
+package leafclient
+
+import (
+	"context"
+	"math"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/Lzww0608/guuid/others/leafSegment/leafpb"
+)
+
+// MaxBatchSize is the largest count the leaf server accepts in one
+// GetIDBatch call
+const MaxBatchSize = 10000
+
+// Client requests segment-allocated IDs from a leaf server.
+type Client struct {
+	conn *grpc.ClientConn // nil when the connection is owned by the caller
+	rpc  leafpb.LeafClient
+}
+
+// Dial connects to the leaf server at target. Unless opts include
+// grpc.WithTransportCredentials the connection is unencrypted.
+func Dial(target string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, rpc: leafpb.NewLeafClient(conn)}, nil
+}
+
+// WithAPIKey returns a DialOption that presents key with every call, for
+// servers started with -keys. The key is sent even over an unencrypted
+// connection, so use TLS outside trusted networks.
+func WithAPIKey(key string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(apiKey(key))
+}
+
+// apiKey is a credentials.PerRPCCredentials sending a bearer token
+type apiKey string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (k apiKey) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(k)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+func (apiKey) RequireTransportSecurity() bool {
+	return false
+}
+
+// NewClient returns a Client using an existing connection, which the caller
+// remains responsible for closing.
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: leafpb.NewLeafClient(conn)}
+}
+
+// GetID returns the next ID for bizTag.
+func (c *Client) GetID(ctx context.Context, bizTag string) (int64, error) {
+	resp, err := c.rpc.GetID(ctx, &leafpb.GetIDRequest{BizTag: bizTag})
+	if err != nil {
+		return 0, err
+	}
+	return resp.GetId(), nil
+}
+
+// GetIDBatch returns the next n IDs for bizTag, in increasing order. Counts
+// below 1 or above math.MaxInt32 are rejected with codes.InvalidArgument
+// without calling the server, which applies its own lower limit.
+func (c *Client) GetIDBatch(ctx context.Context, bizTag string, n int) ([]int64, error) {
+	if n < 1 || int64(n) > math.MaxInt32 {
+		return nil, errBatchCount(n)
+	}
+	resp, err := c.rpc.GetIDBatch(ctx, &leafpb.GetIDBatchRequest{BizTag: bizTag, Count: int32(n)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetIds(), nil
+}
+
+// errBatchCount is the error for a batch count the server would reject
+func errBatchCount(n int) error {
+	return status.Errorf(codes.InvalidArgument, "leafclient: batch count %d out of range", n)
+}
+
+// Close closes the connection opened by Dial. It is a no-op for clients
+// created with NewClient.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
@@ -0,0 +1,305 @@
+package leafclient
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrBreakerOpen is returned while the circuit breaker is open and no
+// fallback is configured.
+var ErrBreakerOpen = errors.New("leafclient: circuit breaker open")
+
+// Default settings of a Resilient client.
+const (
+	DefaultRetries          = 3
+	DefaultBaseBackoff      = 20 * time.Millisecond
+	DefaultMaxBackoff       = time.Second
+	DefaultFailureThreshold = 5
+	DefaultCooldown         = 10 * time.Second
+)
+
+// IDService is the leaf service API, implemented by Client.
+type IDService interface {
+	GetID(ctx context.Context, bizTag string) (int64, error)
+	GetIDBatch(ctx context.Context, bizTag string, n int) ([]int64, error)
+}
+
+// FallbackFunc issues an ID locally while the leaf service is unreachable.
+// Its IDs must never collide with segment IDs, e.g. snowflake IDs whose
+// timestamp bits put them far above any segment counter.
+type FallbackFunc func(bizTag string) (int64, error)
+
+// BreakerState is the state of a Resilient client's circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed passes calls to the service.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen short-circuits calls to the fallback.
+	BreakerOpen
+	// BreakerHalfOpen lets one probe call through after the cooldown.
+	BreakerHalfOpen
+)
+
+// String returns the name of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Option configures a Resilient client.
+type Option func(*Resilient)
+
+// WithRetries sets how many times a transient failure is retried.
+func WithRetries(n int) Option {
+	return func(r *Resilient) {
+		r.retries = n
+	}
+}
+
+// WithBackoff sets the base and maximum delay between retries. The delay
+// before retry i is drawn uniformly from [0, min(max, base*2^i)].
+func WithBackoff(base, max time.Duration) Option {
+	return func(r *Resilient) {
+		r.baseBackoff, r.maxBackoff = base, max
+	}
+}
+
+// WithBreaker sets how many consecutive failed calls open the breaker and
+// how long it stays open before a probe call is let through.
+func WithBreaker(threshold int, cooldown time.Duration) Option {
+	return func(r *Resilient) {
+		r.breaker.threshold, r.breaker.cooldown = threshold, cooldown
+	}
+}
+
+// WithFallback sets the generator used while the breaker is open or after
+// transient failures outlast the retries. Other errors, such as a rejected
+// request or a cancelled context, are returned to the caller instead.
+func WithFallback(fn FallbackFunc) Option {
+	return func(r *Resilient) {
+		r.fallback = fn
+	}
+}
+
+// Resilient wraps an IDService with retries, jittered exponential backoff and
+// a circuit breaker that diverts calls to a local fallback generator, so ID
+// issuance survives database or network outages. It is safe for concurrent use.
+type Resilient struct {
+	svc         IDService
+	retries     int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	fallback    FallbackFunc
+	breaker     breaker
+}
+
+// NewResilient wraps svc, usually a *Client.
+func NewResilient(svc IDService, opts ...Option) *Resilient {
+	r := &Resilient{
+		svc:         svc,
+		retries:     DefaultRetries,
+		baseBackoff: DefaultBaseBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+		breaker: breaker{
+			threshold: DefaultFailureThreshold,
+			cooldown:  DefaultCooldown,
+		},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// State returns the current breaker state.
+func (r *Resilient) State() BreakerState {
+	return r.breaker.current(time.Now())
+}
+
+// GetID returns the next ID for bizTag from the service, or from the
+// fallback when the service is unavailable.
+func (r *Resilient) GetID(ctx context.Context, bizTag string) (int64, error) {
+	var id int64
+	outage, err := r.do(ctx, func() (err error) {
+		id, err = r.svc.GetID(ctx, bizTag)
+		return err
+	})
+	if outage && r.fallback != nil {
+		return r.fallback(bizTag)
+	}
+	return id, err
+}
+
+// GetIDBatch returns the next n IDs for bizTag from the service, or from the
+// fallback when the service is unavailable. Counts outside 1..MaxBatchSize
+// are rejected with codes.InvalidArgument whatever the breaker state.
+func (r *Resilient) GetIDBatch(ctx context.Context, bizTag string, n int) ([]int64, error) {
+	if n < 1 || n > MaxBatchSize {
+		return nil, errBatchCount(n)
+	}
+	var ids []int64
+	outage, err := r.do(ctx, func() (err error) {
+		ids, err = r.svc.GetIDBatch(ctx, bizTag, n)
+		return err
+	})
+	if outage && r.fallback != nil {
+		ids = make([]int64, n)
+		for i := range ids {
+			if ids[i], err = r.fallback(bizTag); err != nil {
+				return nil, err
+			}
+		}
+		return ids, nil
+	}
+	return ids, err
+}
+
+// do runs call with retries, honoring and updating the breaker. outage
+// reports whether err means the service is unavailable: the breaker is open
+// or transient failures outlasted the retries. Non-transient errors are the
+// service's answer and are returned as is; if ctx is done, do returns
+// ctx.Err(). Neither counts for or against the breaker.
+func (r *Resilient) do(ctx context.Context, call func() error) (outage bool, err error) {
+	if !r.breaker.allow(time.Now()) {
+		return true, ErrBreakerOpen
+	}
+	for attempt := 0; ; attempt++ {
+		err = call()
+		if err == nil {
+			r.breaker.success()
+			return false, nil
+		}
+		if ctx.Err() != nil {
+			r.breaker.release()
+			return false, ctx.Err()
+		}
+		if !transient(err) {
+			r.breaker.release()
+			return false, err
+		}
+		if attempt >= r.retries {
+			break
+		}
+		if werr := sleep(ctx, r.backoff(attempt)); werr != nil {
+			r.breaker.release()
+			return false, werr
+		}
+	}
+	r.breaker.failure(time.Now())
+	return true, err
+}
+
+// backoff returns a full-jitter delay before retry attempt.
+func (r *Resilient) backoff(attempt int) time.Duration {
+	d := r.maxBackoff
+	if attempt < 30 && r.baseBackoff<<attempt < d {
+		d = r.baseBackoff << attempt
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// transient reports whether err is a temporary failure worth retrying.
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// breaker is a consecutive-failure circuit breaker.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+}
+
+// allow reports whether a call may proceed at now, moving an open breaker to
+// half-open once the cooldown has passed.
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		return true
+	case BreakerHalfOpen:
+		return false // a probe is already in flight
+	}
+	return true
+}
+
+// release ends a call that gave no verdict on the service's health. A
+// half-open breaker returns to open with its cooldown already elapsed, so the
+// next call probes again.
+func (b *breaker) release() {
+	b.mu.Lock()
+	if b.state == BreakerHalfOpen {
+		b.state = BreakerOpen
+	}
+	b.mu.Unlock()
+}
+
+// success closes the breaker.
+func (b *breaker) success() {
+	b.mu.Lock()
+	b.state, b.failures = BreakerClosed, 0
+	b.mu.Unlock()
+}
+
+// failure records a failed call, opening the breaker after a failed probe or
+// threshold consecutive failures.
+func (b *breaker) failure(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state, b.openedAt = BreakerOpen, now
+	}
+}
+
+// current returns the state as it would be seen by a call at now.
+func (b *breaker) current(now time.Time) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && now.Sub(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
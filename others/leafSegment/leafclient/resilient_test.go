@@ -0,0 +1,226 @@
+package leafclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeService is an IDService that replays scripted errors, then succeeds
+type fakeService struct {
+	mu    sync.Mutex
+	errs  []error // returned by successive calls; nil entries succeed
+	err   error   // returned once errs is used up
+	calls int
+}
+
+func (f *fakeService) next() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return err
+	}
+	return f.err
+}
+
+func (f *fakeService) GetID(ctx context.Context, bizTag string) (int64, error) {
+	if err := f.next(); err != nil {
+		return 0, err
+	}
+	return 42, nil
+}
+
+func (f *fakeService) GetIDBatch(ctx context.Context, bizTag string, n int) ([]int64, error) {
+	if err := f.next(); err != nil {
+		return nil, err
+	}
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	return ids, nil
+}
+
+// fallbackID is the ID issued by testFallback
+const fallbackID = 1 << 62
+
+func testFallback(string) (int64, error) {
+	return fallbackID, nil
+}
+
+func TestResilient_GetID(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+	tests := []struct {
+		name      string
+		errs      []error
+		err       error
+		fallback  bool
+		wantID    int64
+		wantCode  codes.Code
+		wantCalls int
+	}{
+		{"success", nil, nil, true, 42, codes.OK, 1},
+		{"transient then success", []error{unavailable, status.Error(codes.DeadlineExceeded, "slow")}, nil, true, 42, codes.OK, 3},
+		{"retries exhausted", nil, unavailable, true, fallbackID, codes.OK, 3},
+		{"retries exhausted without fallback", nil, unavailable, false, 0, codes.Unavailable, 3},
+		{"invalid argument", nil, status.Error(codes.InvalidArgument, "bad"), true, 0, codes.InvalidArgument, 1},
+		{"permission denied", nil, status.Error(codes.PermissionDenied, "no"), true, 0, codes.PermissionDenied, 1},
+		{"unauthenticated", nil, status.Error(codes.Unauthenticated, "who"), true, 0, codes.Unauthenticated, 1},
+		{"not found", nil, status.Error(codes.NotFound, "tag"), true, 0, codes.NotFound, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeService{errs: tt.errs, err: tt.err}
+			opts := []Option{WithRetries(2), WithBackoff(0, 0)}
+			if tt.fallback {
+				opts = append(opts, WithFallback(testFallback))
+			}
+			r := NewResilient(svc, opts...)
+
+			id, err := r.GetID(context.Background(), "order")
+			if status.Code(err) != tt.wantCode || id != tt.wantID {
+				t.Errorf("GetID() = %d, %v, want %d, %v", id, err, tt.wantID, tt.wantCode)
+			}
+			if svc.calls != tt.wantCalls {
+				t.Errorf("service called %d times, want %d", svc.calls, tt.wantCalls)
+			}
+			if got := r.State(); got != BreakerClosed {
+				t.Errorf("State() = %v, want closed", got)
+			}
+		})
+	}
+}
+
+func TestResilient_Breaker(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+	svc := &fakeService{err: status.Error(codes.Unavailable, "down")}
+	r := NewResilient(svc, WithRetries(0), WithBreaker(2, cooldown), WithFallback(testFallback))
+	ctx := context.Background()
+
+	// Two consecutive failures open the breaker
+	for i := 0; i < 2; i++ {
+		if id, err := r.GetID(ctx, "order"); err != nil || id != fallbackID {
+			t.Fatalf("GetID() #%d = %d, %v, want fallback", i, id, err)
+		}
+	}
+	if got := r.State(); got != BreakerOpen {
+		t.Fatalf("State() after failures = %v, want open", got)
+	}
+
+	// While open, calls go to the fallback without reaching the service
+	if id, err := r.GetID(ctx, "order"); err != nil || id != fallbackID || svc.calls != 2 {
+		t.Errorf("GetID() while open = %d, %v with %d calls, want fallback and 2 calls", id, err, svc.calls)
+	}
+
+	// After the cooldown a failed probe reopens the breaker
+	time.Sleep(cooldown)
+	if got := r.State(); got != BreakerHalfOpen {
+		t.Fatalf("State() after cooldown = %v, want half-open", got)
+	}
+	r.GetID(ctx, "order")
+	if got := r.State(); got != BreakerOpen || svc.calls != 3 {
+		t.Fatalf("State() after failed probe = %v with %d calls, want open and 3 calls", got, svc.calls)
+	}
+
+	// A successful probe closes it
+	time.Sleep(cooldown)
+	svc.err = nil
+	if id, err := r.GetID(ctx, "order"); err != nil || id != 42 {
+		t.Errorf("GetID() probe = %d, %v, want 42", id, err)
+	}
+	if got := r.State(); got != BreakerClosed {
+		t.Errorf("State() after successful probe = %v, want closed", got)
+	}
+}
+
+func TestResilient_BreakerOpenWithoutFallback(t *testing.T) {
+	svc := &fakeService{err: status.Error(codes.Unavailable, "down")}
+	r := NewResilient(svc, WithRetries(0), WithBreaker(1, time.Hour))
+	r.GetID(context.Background(), "order")
+	if _, err := r.GetID(context.Background(), "order"); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("GetID() while open error = %v, want ErrBreakerOpen", err)
+	}
+}
+
+func TestResilient_NonTransientReleasesProbe(t *testing.T) {
+	const cooldown = 10 * time.Millisecond
+	svc := &fakeService{err: status.Error(codes.Unavailable, "down")}
+	r := NewResilient(svc, WithRetries(0), WithBreaker(1, cooldown))
+	r.GetID(context.Background(), "order")
+	time.Sleep(cooldown)
+
+	svc.err = status.Error(codes.InvalidArgument, "bad")
+	if _, err := r.GetID(context.Background(), "order"); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("GetID() probe error = %v, want InvalidArgument", err)
+	}
+	if got := r.State(); got != BreakerHalfOpen {
+		t.Errorf("State() after inconclusive probe = %v, want half-open", got)
+	}
+	svc.err = nil
+	if _, err := r.GetID(context.Background(), "order"); err != nil {
+		t.Errorf("GetID() second probe error = %v", err)
+	}
+}
+
+func TestResilient_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc := &fakeService{err: status.Error(codes.Canceled, "context canceled")}
+	r := NewResilient(svc, WithRetries(0), WithBreaker(1, time.Hour), WithFallback(testFallback))
+
+	if _, err := r.GetID(ctx, "order"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetID() with cancelled context error = %v, want context.Canceled", err)
+	}
+	if got := r.State(); got != BreakerClosed {
+		t.Errorf("State() after cancelled call = %v, want closed", got)
+	}
+
+	// Cancellation while backing off between transient failures
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	svc = &fakeService{err: status.Error(codes.Unavailable, "down")}
+	r = NewResilient(svc, WithRetries(5), WithBackoff(time.Hour, time.Hour), WithFallback(testFallback))
+	if _, err := r.GetID(ctx, "order"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetID() timing out in backoff error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestResilient_GetIDBatchFallback(t *testing.T) {
+	svc := &fakeService{err: status.Error(codes.Unavailable, "down")}
+	r := NewResilient(svc, WithRetries(0), WithFallback(testFallback))
+	ids, err := r.GetIDBatch(context.Background(), "order", 3)
+	if err != nil || len(ids) != 3 || ids[0] != fallbackID {
+		t.Errorf("GetIDBatch() = %v, %v, want 3 fallback IDs", ids, err)
+	}
+
+	svc.err = nil
+	if ids, err = r.GetIDBatch(context.Background(), "order", 3); err != nil || len(ids) != 3 || ids[2] != 3 {
+		t.Errorf("GetIDBatch() = %v, %v, want [1 2 3]", ids, err)
+	}
+}
+
+func TestResilient_GetIDBatchCountRange(t *testing.T) {
+	svc := &fakeService{err: status.Error(codes.Unavailable, "down")}
+	r := NewResilient(svc, WithRetries(0), WithBreaker(1, time.Hour), WithFallback(testFallback))
+	r.GetID(context.Background(), "order")
+	if got := r.State(); got != BreakerOpen {
+		t.Fatalf("State() = %v, want open", got)
+	}
+
+	for _, n := range []int{0, -1, MaxBatchSize + 1} {
+		if _, err := r.GetIDBatch(context.Background(), "order", n); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetIDBatch(%d) with the breaker open error = %v, want InvalidArgument", n, err)
+		}
+	}
+	if svc.calls != 1 {
+		t.Errorf("service called %d times, want 1", svc.calls)
+	}
+}
@@ -0,0 +1,5 @@
+// Package leafpb contains the protobuf messages and gRPC stubs of the leaf
+// segment service, generated from leaf.proto.
+package leafpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative leaf.proto
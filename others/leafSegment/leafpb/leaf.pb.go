@@ -0,0 +1,355 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: leaf.proto
+
+package leafpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BizTag string `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+}
+
+func (x *GetIDRequest) Reset() {
+	*x = GetIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaf_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIDRequest) ProtoMessage() {}
+
+func (x *GetIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaf_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIDRequest.ProtoReflect.Descriptor instead.
+func (*GetIDRequest) Descriptor() ([]byte, []int) {
+	return file_leaf_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetIDRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+type GetIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetIDResponse) Reset() {
+	*x = GetIDResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaf_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIDResponse) ProtoMessage() {}
+
+func (x *GetIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaf_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIDResponse.ProtoReflect.Descriptor instead.
+func (*GetIDResponse) Descriptor() ([]byte, []int) {
+	return file_leaf_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetIDResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetIDBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BizTag string `protobuf:"bytes,1,opt,name=biz_tag,json=bizTag,proto3" json:"biz_tag,omitempty"`
+	Count  int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *GetIDBatchRequest) Reset() {
+	*x = GetIDBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaf_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetIDBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIDBatchRequest) ProtoMessage() {}
+
+func (x *GetIDBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaf_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIDBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetIDBatchRequest) Descriptor() ([]byte, []int) {
+	return file_leaf_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetIDBatchRequest) GetBizTag() string {
+	if x != nil {
+		return x.BizTag
+	}
+	return ""
+}
+
+func (x *GetIDBatchRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetIDBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *GetIDBatchResponse) Reset() {
+	*x = GetIDBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_leaf_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetIDBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIDBatchResponse) ProtoMessage() {}
+
+func (x *GetIDBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaf_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIDBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetIDBatchResponse) Descriptor() ([]byte, []int) {
+	return file_leaf_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetIDBatchResponse) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_leaf_proto protoreflect.FileDescriptor
+
+var file_leaf_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x6c, 0x65, 0x61, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x67, 0x75,
+	0x75, 0x69, 0x64, 0x2e, 0x6c, 0x65, 0x61, 0x66, 0x2e, 0x76, 0x31, 0x22, 0x27, 0x0a, 0x0c, 0x47,
+	0x65, 0x74, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62,
+	0x69, 0x7a, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x69,
+	0x7a, 0x54, 0x61, 0x67, 0x22, 0x1f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x49, 0x44, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x42, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x49, 0x44, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69,
+	0x7a, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x69, 0x7a,
+	0x54, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x26, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x03, 0x69, 0x64,
+	0x73, 0x32, 0x9d, 0x01, 0x0a, 0x04, 0x4c, 0x65, 0x61, 0x66, 0x12, 0x42, 0x0a, 0x05, 0x47, 0x65,
+	0x74, 0x49, 0x44, 0x12, 0x1b, 0x2e, 0x67, 0x75, 0x75, 0x69, 0x64, 0x2e, 0x6c, 0x65, 0x61, 0x66,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x67, 0x75, 0x75, 0x69, 0x64, 0x2e, 0x6c, 0x65, 0x61, 0x66, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x49, 0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x20, 0x2e, 0x67,
+	0x75, 0x75, 0x69, 0x64, 0x2e, 0x6c, 0x65, 0x61, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x67, 0x75, 0x75, 0x69, 0x64, 0x2e, 0x6c, 0x65, 0x61, 0x66, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x49, 0x44, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x4c, 0x7a, 0x77, 0x77, 0x30, 0x36, 0x30, 0x38, 0x2f, 0x67, 0x75, 0x75, 0x69, 0x64, 0x2f, 0x6f,
+	0x74, 0x68, 0x65, 0x72, 0x73, 0x2f, 0x6c, 0x65, 0x61, 0x66, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x2f, 0x6c, 0x65, 0x61, 0x66, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_leaf_proto_rawDescOnce sync.Once
+	file_leaf_proto_rawDescData = file_leaf_proto_rawDesc
+)
+
+func file_leaf_proto_rawDescGZIP() []byte {
+	file_leaf_proto_rawDescOnce.Do(func() {
+		file_leaf_proto_rawDescData = protoimpl.X.CompressGZIP(file_leaf_proto_rawDescData)
+	})
+	return file_leaf_proto_rawDescData
+}
+
+var file_leaf_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_leaf_proto_goTypes = []any{
+	(*GetIDRequest)(nil),       // 0: guuid.leaf.v1.GetIDRequest
+	(*GetIDResponse)(nil),      // 1: guuid.leaf.v1.GetIDResponse
+	(*GetIDBatchRequest)(nil),  // 2: guuid.leaf.v1.GetIDBatchRequest
+	(*GetIDBatchResponse)(nil), // 3: guuid.leaf.v1.GetIDBatchResponse
+}
+var file_leaf_proto_depIdxs = []int32{
+	0, // 0: guuid.leaf.v1.Leaf.GetID:input_type -> guuid.leaf.v1.GetIDRequest
+	2, // 1: guuid.leaf.v1.Leaf.GetIDBatch:input_type -> guuid.leaf.v1.GetIDBatchRequest
+	1, // 2: guuid.leaf.v1.Leaf.GetID:output_type -> guuid.leaf.v1.GetIDResponse
+	3, // 3: guuid.leaf.v1.Leaf.GetIDBatch:output_type -> guuid.leaf.v1.GetIDBatchResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_leaf_proto_init() }
+func file_leaf_proto_init() {
+	if File_leaf_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_leaf_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leaf_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*GetIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leaf_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetIDBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_leaf_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetIDBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_leaf_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_leaf_proto_goTypes,
+		DependencyIndexes: file_leaf_proto_depIdxs,
+		MessageInfos:      file_leaf_proto_msgTypes,
+	}.Build()
+	File_leaf_proto = out.File
+	file_leaf_proto_rawDesc = nil
+	file_leaf_proto_goTypes = nil
+	file_leaf_proto_depIdxs = nil
+}
@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: leaf.proto
+
+package leafpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Leaf_GetID_FullMethodName      = "/guuid.leaf.v1.Leaf/GetID"
+	Leaf_GetIDBatch_FullMethodName = "/guuid.leaf.v1.Leaf/GetIDBatch"
+)
+
+// LeafClient is the client API for Leaf service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Leaf hands out segment-allocated IDs, unique and increasing per business tag.
+type LeafClient interface {
+	// GetID returns the next ID for a business tag.
+	GetID(ctx context.Context, in *GetIDRequest, opts ...grpc.CallOption) (*GetIDResponse, error)
+	// GetIDBatch returns the next count IDs for a business tag, in increasing order.
+	GetIDBatch(ctx context.Context, in *GetIDBatchRequest, opts ...grpc.CallOption) (*GetIDBatchResponse, error)
+}
+
+type leafClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeafClient(cc grpc.ClientConnInterface) LeafClient {
+	return &leafClient{cc}
+}
+
+func (c *leafClient) GetID(ctx context.Context, in *GetIDRequest, opts ...grpc.CallOption) (*GetIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIDResponse)
+	err := c.cc.Invoke(ctx, Leaf_GetID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leafClient) GetIDBatch(ctx context.Context, in *GetIDBatchRequest, opts ...grpc.CallOption) (*GetIDBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIDBatchResponse)
+	err := c.cc.Invoke(ctx, Leaf_GetIDBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeafServer is the server API for Leaf service.
+// All implementations must embed UnimplementedLeafServer
+// for forward compatibility.
+//
+// Leaf hands out segment-allocated IDs, unique and increasing per business tag.
+type LeafServer interface {
+	// GetID returns the next ID for a business tag.
+	GetID(context.Context, *GetIDRequest) (*GetIDResponse, error)
+	// GetIDBatch returns the next count IDs for a business tag, in increasing order.
+	GetIDBatch(context.Context, *GetIDBatchRequest) (*GetIDBatchResponse, error)
+	mustEmbedUnimplementedLeafServer()
+}
+
+// UnimplementedLeafServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeafServer struct{}
+
+func (UnimplementedLeafServer) GetID(context.Context, *GetIDRequest) (*GetIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetID not implemented")
+}
+func (UnimplementedLeafServer) GetIDBatch(context.Context, *GetIDBatchRequest) (*GetIDBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIDBatch not implemented")
+}
+func (UnimplementedLeafServer) mustEmbedUnimplementedLeafServer() {}
+func (UnimplementedLeafServer) testEmbeddedByValue()              {}
+
+// UnsafeLeafServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeafServer will
+// result in compilation errors.
+type UnsafeLeafServer interface {
+	mustEmbedUnimplementedLeafServer()
+}
+
+func RegisterLeafServer(s grpc.ServiceRegistrar, srv LeafServer) {
+	// If the following call pancis, it indicates UnimplementedLeafServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Leaf_ServiceDesc, srv)
+}
+
+func _Leaf_GetID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeafServer).GetID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaf_GetID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeafServer).GetID(ctx, req.(*GetIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaf_GetIDBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIDBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeafServer).GetIDBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaf_GetIDBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeafServer).GetIDBatch(ctx, req.(*GetIDBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Leaf_ServiceDesc is the grpc.ServiceDesc for Leaf service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Leaf_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "guuid.leaf.v1.Leaf",
+	HandlerType: (*LeafServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetID",
+			Handler:    _Leaf_GetID_Handler,
+		},
+		{
+			MethodName: "GetIDBatch",
+			Handler:    _Leaf_GetIDBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaf.proto",
+}
@@ -0,0 +1,5 @@
+module github.com/Lzww0608/guuid/others/leafSnowflake
+
+go 1.21.0
+
+require github.com/go-zookeeper/zk v1.0.4
@@ -0,0 +1,73 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// OutboxTx assigns IDs to the outbox rows written by one transaction. The
+// first call to Next takes a single UUIDv7 from the generator; every row of
+// the transaction shares its timestamp and counter and is numbered by a
+// 32-bit sub-counter in the last four bytes. Rows of one transaction are
+// therefore strictly increasing and contiguous, and sort before the rows of
+// any transaction that takes its first ID later, even within the same
+// millisecond, so a relay reading the outbox in ID order publishes whole
+// transactions in the order they took their IDs.
+//
+// For relay order to match commit order, take the first ID as late as
+// possible, e.g. after locking the outbox table just before commit. An
+// OutboxTx is not safe for concurrent use.
+type OutboxTx struct {
+	gen  *Generator
+	base UUID
+	seq  uint64
+}
+
+// NewOutboxTx returns an OutboxTx drawing from gen, or from the default
+// generator if gen is nil
+func NewOutboxTx(gen *Generator) *OutboxTx {
+	if gen == nil {
+		gen = Default()
+	}
+	return &OutboxTx{gen: gen}
+}
+
+// Next returns the ID of the transaction's next outbox row
+func (tx *OutboxTx) Next() (UUID, error) {
+	if tx.seq == 0 {
+		base, err := tx.gen.New()
+		if err != nil {
+			return Nil, err
+		}
+		tx.base = base
+	}
+	if tx.seq > math.MaxUint32 {
+		return Nil, ErrOutboxExhausted
+	}
+	u := tx.base
+	binary.BigEndian.PutUint32(u[12:], uint32(tx.seq))
+	tx.seq++
+	return u, nil
+}
+
+// Assign returns IDs for n more outbox rows. A negative n is an error.
+func (tx *OutboxTx) Assign(n int) ([]UUID, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("guuid: negative outbox row count %d", n)
+	}
+	ids := make([]UUID, n)
+	for i := range ids {
+		u, err := tx.Next()
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = u
+	}
+	return ids, nil
+}
+
+// Len returns the number of IDs assigned so far
+func (tx *OutboxTx) Len() int {
+	return int(tx.seq)
+}
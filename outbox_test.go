@@ -0,0 +1,98 @@
+package guuid
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestOutboxTx_Ordered(t *testing.T) {
+	gen := NewGenerator()
+	before := Must(gen.New())
+
+	tx := NewOutboxTx(gen)
+	ids, err := tx.Assign(1000)
+	if err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+	more := Must(tx.Next())
+	after := Must(gen.New())
+
+	if tx.Len() != 1001 {
+		t.Errorf("Len() = %d, want 1001", tx.Len())
+	}
+	ids = append(ids, more)
+	if !slices.IsSortedFunc(ids, Compare) || len(Dedupe(slices.Clone(ids))) != len(ids) {
+		t.Error("transaction IDs not strictly increasing")
+	}
+	for _, u := range ids {
+		if u.Version() != VersionTimeSorted || u.Variant() != VariantRFC4122 {
+			t.Fatalf("ID %v is not a valid UUIDv7", u)
+		}
+	}
+	if ids[0].Compare(before) <= 0 || ids[len(ids)-1].Compare(after) >= 0 {
+		t.Errorf("transaction IDs not between %v and %v", before, after)
+	}
+}
+
+func TestOutboxTx_TransactionsContiguous(t *testing.T) {
+	gen := NewGenerator()
+	const txs, rows = 20, 50
+
+	var mu sync.Mutex
+	var all []UUID
+	owner := make(map[UUID]int)
+	var wg sync.WaitGroup
+	for i := 0; i < txs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tx := NewOutboxTx(gen)
+			for j := 0; j < rows; j++ {
+				u := Must(tx.Next())
+				mu.Lock()
+				all = append(all, u)
+				owner[u] = i
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	slices.SortFunc(all, Compare)
+	seen := make(map[int]bool)
+	for i, u := range all {
+		if i > 0 && owner[u] != owner[all[i-1]] {
+			if seen[owner[u]] {
+				t.Fatalf("rows of transaction %d are interleaved with others", owner[u])
+			}
+		}
+		seen[owner[u]] = true
+	}
+	if len(owner) != txs*rows {
+		t.Errorf("got %d distinct IDs, want %d", len(owner), txs*rows)
+	}
+}
+
+func TestOutboxTx_EntropyError(t *testing.T) {
+	tx := NewOutboxTx(NewGeneratorWithReader(&brokenReader{}))
+	if _, err := tx.Next(); err == nil {
+		t.Error("Next() with broken entropy expected error")
+	}
+	if tx.Len() != 0 {
+		t.Errorf("Len() after failure = %d, want 0", tx.Len())
+	}
+}
+
+func TestOutboxTx_AssignNegative(t *testing.T) {
+	tx := NewOutboxTx(NewGenerator())
+	if ids, err := tx.Assign(-1); err == nil {
+		t.Errorf("Assign(-1) = %v, want error", ids)
+	}
+	if ids, err := tx.Assign(0); err != nil || len(ids) != 0 {
+		t.Errorf("Assign(0) = %v, %v, want no IDs", ids, err)
+	}
+	if tx.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", tx.Len())
+	}
+}
@@ -0,0 +1,46 @@
+package guuid
+
+import "time"
+
+// DefaultOverflowSleepLimit bounds a single WithOverflowSleep wait when no
+// limit is given. It covers the wait for the millisecond after the current
+// one, the only wait an overflow at the wall-clock time needs.
+const DefaultOverflowSleepLimit = time.Millisecond
+
+// WithOverflowSleep makes the generator wait for the wall clock to reach the
+// next millisecond when the 12-bit counter overflows, instead of borrowing a
+// future millisecond. Embedded timestamps then never run ahead of the clock,
+// which matters for audit-sensitive workloads, at the cost of blocking all
+// callers of the generator for up to a millisecond per overflow.
+//
+// limit bounds a single wait; if reaching the next millisecond would take
+// longer, e.g. because earlier overflows already borrowed ahead or the clock
+// stepped backwards, or because the time passed to NewWithTime or added by
+// WithDither is in the future, the generator borrows as usual rather than
+// block every caller. A limit of zero or less selects
+// DefaultOverflowSleepLimit. Overflows are reported to WithOnOverflow hooks
+// and Stats either way.
+func WithOverflowSleep(limit time.Duration) Option {
+	return func(g *Generator) {
+		g.overflowSleep = true
+		g.overflowSleepMax = limit
+	}
+}
+
+// waitForMilli blocks until the wall clock reaches the Unix millisecond ms,
+// unless that is further away than the WithOverflowSleep bound. It must be
+// called with g.mu held.
+func (g *Generator) waitForMilli(ms uint64) {
+	until := time.UnixMilli(int64(ms))
+	d := time.Until(until)
+	limit := g.overflowSleepMax
+	if limit <= 0 {
+		limit = DefaultOverflowSleepLimit
+	}
+	if d > limit {
+		return
+	}
+	for ; d > 0; d = time.Until(until) {
+		time.Sleep(d)
+	}
+}
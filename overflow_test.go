@@ -0,0 +1,45 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithOverflowSleep(t *testing.T) {
+	gen := NewGenerator(WithOverflowSleep(0))
+	now := time.Now()
+	gen.lastTimestamp = uint64(now.UnixMilli())
+	gen.clockSeq = 0xFFF
+
+	uuid := Must(gen.NewWithTime(now))
+	after := time.Now().UnixMilli()
+
+	if got, want := uuid.Timestamp(), now.UnixMilli()+1; got != want {
+		t.Errorf("Timestamp() = %d, want %d", got, want)
+	}
+	if uuid.Timestamp() > after {
+		t.Errorf("Timestamp() = %d runs ahead of the clock (%d)", uuid.Timestamp(), after)
+	}
+	if gen.Stats().Overflows != 1 {
+		t.Errorf("Stats().Overflows = %d, want 1", gen.Stats().Overflows)
+	}
+}
+
+func TestWithOverflowSleep_Bounded(t *testing.T) {
+	for _, limit := range []time.Duration{time.Millisecond, 0} {
+		gen := NewGenerator(WithOverflowSleep(limit))
+		now := time.Now()
+		ahead := uint64(now.Add(time.Hour).UnixMilli())
+		gen.lastTimestamp = ahead
+		gen.clockSeq = 0xFFF
+
+		start := time.Now()
+		uuid := Must(gen.NewWithTime(now))
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("limit %v: NewWithTime() blocked for %v, want bounded wait", limit, elapsed)
+		}
+		if got, want := uuid.Timestamp(), int64(ahead)+1; got != want {
+			t.Errorf("limit %v: Timestamp() = %d, want borrowed %d", limit, got, want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package guuid
+
+import (
+	"time"
+)
+
+// TimeBucket returns the index of the d-sized time bucket containing the
+// UUIDv7 timestamp, counted from the Unix epoch. Buckets are aligned to UTC,
+// so TimeBucket(24*time.Hour) numbers UTC days. It returns 0 for non-v7 UUIDs
+// or if d is shorter than a millisecond.
+func (u UUID) TimeBucket(d time.Duration) int64 {
+	ms := d.Milliseconds()
+	if ms <= 0 {
+		return 0
+	}
+	return u.Timestamp() / ms
+}
+
+// Truncate returns the UUIDv7 time rounded down to a multiple of d since the
+// Unix epoch, i.e. the start of the bucket numbered by TimeBucket. Buckets are
+// aligned to UTC, so Truncate(time.Hour) is the start of the UTC hour. It
+// returns u.Time() unchanged if d is shorter than a millisecond and the zero
+// time for non-v7 UUIDs.
+func (u UUID) Truncate(d time.Duration) time.Time {
+	if u.Version() != VersionTimeSorted {
+		return time.Time{}
+	}
+	ms := d.Milliseconds()
+	if ms <= 0 {
+		return u.Time()
+	}
+	ts := u.Timestamp()
+	return time.UnixMilli(ts - ts%ms)
+}
+
+// PartitionKey formats the UUIDv7 timestamp in UTC using layout, giving a
+// partition label for time-partitioned tables, e.g. "20060102" for daily or
+// "2006010215" for hourly partitions. It returns "" for non-v7 UUIDs.
+func (u UUID) PartitionKey(layout string) string {
+	if u.Version() != VersionTimeSorted {
+		return ""
+	}
+	return u.Time().UTC().Format(layout)
+}
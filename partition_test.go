@@ -0,0 +1,87 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUUID_TimeBucket(t *testing.T) {
+	gen := NewGenerator()
+	ts := time.Date(2024, 3, 15, 13, 45, 30, 0, time.UTC)
+	uuid := Must(gen.NewWithTime(ts))
+
+	day := uuid.TimeBucket(24 * time.Hour)
+	if want := ts.Unix() / 86400; day != want {
+		t.Errorf("TimeBucket(24h) = %d, want %d", day, want)
+	}
+	hour := uuid.TimeBucket(time.Hour)
+	if want := ts.Unix() / 3600; hour != want {
+		t.Errorf("TimeBucket(1h) = %d, want %d", hour, want)
+	}
+
+	if got := uuid.TimeBucket(time.Microsecond); got != 0 {
+		t.Errorf("TimeBucket(1µs) = %d, want 0", got)
+	}
+	if got := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479").TimeBucket(time.Hour); got != 0 {
+		t.Errorf("TimeBucket() for non-v7 = %d, want 0", got)
+	}
+}
+
+func TestUUID_Truncate(t *testing.T) {
+	gen := NewGenerator()
+	ts := time.Date(2024, 3, 15, 13, 45, 30, 123000000, time.UTC)
+	uuid := Must(gen.NewWithTime(ts))
+
+	tests := []struct {
+		d    time.Duration
+		want time.Time
+	}{
+		{time.Minute, time.Date(2024, 3, 15, 13, 45, 0, 0, time.UTC)},
+		{time.Hour, time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)},
+		{15 * time.Minute, time.Date(2024, 3, 15, 13, 45, 0, 0, time.UTC)},
+		{24 * time.Hour, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{time.Microsecond, ts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			got := uuid.Truncate(tt.d)
+			if !got.Equal(tt.want) {
+				t.Errorf("Truncate(%v) = %v, want %v", tt.d, got, tt.want)
+			}
+			if tt.d >= time.Millisecond && got.UnixMilli() != uuid.TimeBucket(tt.d)*tt.d.Milliseconds() {
+				t.Errorf("Truncate(%v) disagrees with TimeBucket", tt.d)
+			}
+		})
+	}
+
+	if got := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479").Truncate(time.Hour); !got.IsZero() {
+		t.Errorf("Truncate() for non-v7 = %v, want zero time", got)
+	}
+}
+
+func TestUUID_PartitionKey(t *testing.T) {
+	gen := NewGenerator()
+	loc := time.FixedZone("UTC+8", 8*3600)
+	// 02:30 on the 16th in UTC+8 is still the 15th in UTC
+	uuid := Must(gen.NewWithTime(time.Date(2024, 3, 16, 2, 30, 0, 0, loc)))
+
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"20060102", "20240315"},
+		{"2006010215", "2024031518"},
+		{"p2006_01", "p2024_03"},
+	}
+
+	for _, tt := range tests {
+		if got := uuid.PartitionKey(tt.layout); got != tt.want {
+			t.Errorf("PartitionKey(%q) = %q, want %q", tt.layout, got, tt.want)
+		}
+	}
+
+	if got := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479").PartitionKey("20060102"); got != "" {
+		t.Errorf("PartitionKey() for non-v7 = %q, want empty", got)
+	}
+}
@@ -0,0 +1,31 @@
+package guuid
+
+import "strings"
+
+// PrefixRange returns the smallest and largest UUIDs whose hex digits start
+// with hexPrefix, so a key-value store can prefix scan the inclusive range
+// [lo, hi] of a byte-ordered UUID keyspace. The prefix is case-insensitive
+// and may contain hyphens, e.g. "018f3e5a-7b". An empty prefix covers the
+// whole keyspace. If the prefix has more than 32 digits or a non-hex
+// character, lo is MaxUUID and hi is Nil: an empty range.
+func PrefixRange(hexPrefix string) (lo, hi UUID) {
+	digits := strings.ReplaceAll(hexPrefix, "-", "")
+	if len(digits) > 32 {
+		return MaxUUID, Nil
+	}
+	hi = MaxUUID
+	for i := 0; i < len(digits); i++ {
+		v := hexValues[digits[i]]
+		if v == 0xFF {
+			return MaxUUID, Nil
+		}
+		if i%2 == 0 {
+			lo[i/2] = v << 4
+			hi[i/2] = v<<4 | 0x0F
+		} else {
+			lo[i/2] |= v
+			hi[i/2] = lo[i/2]
+		}
+	}
+	return lo, hi
+}
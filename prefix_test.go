@@ -0,0 +1,44 @@
+package guuid
+
+import "testing"
+
+func TestPrefixRange(t *testing.T) {
+	tests := []struct {
+		prefix string
+		lo, hi string
+	}{
+		{"", "00000000-0000-0000-0000-000000000000", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"0", "00000000-0000-0000-0000-000000000000", "0fffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"018f3e5a-7b", "018f3e5a-7b00-0000-0000-000000000000", "018f3e5a-7bff-ffff-ffff-ffffffffffff"},
+		{"018F3E5A7B2", "018f3e5a-7b20-0000-0000-000000000000", "018f3e5a-7b2f-ffff-ffff-ffffffffffff"},
+		{"f47ac10b-58cc-4372-a567-0e02b2c3d479", "f47ac10b-58cc-4372-a567-0e02b2c3d479", "f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+	}
+	for _, tt := range tests {
+		lo, hi := PrefixRange(tt.prefix)
+		if lo.String() != tt.lo || hi.String() != tt.hi {
+			t.Errorf("PrefixRange(%q) = %v, %v, want %s, %s", tt.prefix, lo, hi, tt.lo, tt.hi)
+		}
+	}
+}
+
+func TestPrefixRange_Contains(t *testing.T) {
+	lo, hi := PrefixRange("018f3")
+	for _, s := range []string{"018f3000-0000-7000-8000-000000000000", "018f3fff-ffff-7fff-bfff-ffffffffffff"} {
+		if u := MustParse(s); u.Compare(lo) < 0 || u.Compare(hi) > 0 {
+			t.Errorf("%s is outside [%v, %v]", s, lo, hi)
+		}
+	}
+	for _, s := range []string{"018f2fff-ffff-7fff-bfff-ffffffffffff", "018f4000-0000-7000-8000-000000000000"} {
+		if u := MustParse(s); u.Compare(lo) >= 0 && u.Compare(hi) <= 0 {
+			t.Errorf("%s is inside [%v, %v]", s, lo, hi)
+		}
+	}
+}
+
+func TestPrefixRange_Invalid(t *testing.T) {
+	for _, prefix := range []string{"xyz", "018g", "f47ac10b58cc4372a5670e02b2c3d4790"} {
+		if lo, hi := PrefixRange(prefix); lo != MaxUUID || hi != Nil {
+			t.Errorf("PrefixRange(%q) = %v, %v, want an empty range", prefix, lo, hi)
+		}
+	}
+}
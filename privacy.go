@@ -0,0 +1,49 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// WithTimestampDither shifts the embedded timestamp of every UUID by a random
+// offset in [-window, +window], at millisecond resolution, so that public IDs
+// do not reveal exactly when they were created. IDs from the generator remain
+// monotonic: a dithered time earlier than the last one issued is replaced by
+// the last one, so under sustained load embedded times drift toward the upper
+// end of the window. Sorting by ID still orders events to within about
+// 2*window. Windows shorter than a millisecond disable dithering.
+func WithTimestampDither(window time.Duration) Option {
+	return func(g *Generator) {
+		g.ditherMs = window.Milliseconds()
+	}
+}
+
+// WithTimestampGranularity truncates the embedded timestamp of every UUID to a
+// multiple of d since the Unix epoch, e.g. time.Second or time.Minute, so IDs
+// reveal creation time only to that precision. IDs within one period share a
+// timestamp and are ordered by the 12-bit counter; after 4096 IDs in the same
+// period the generator borrows the next millisecond as usual, so the embedded
+// time may then run slightly past the period start. Values under a
+// millisecond disable truncation.
+//
+// When combined with WithTimestampDither, truncation is applied first.
+func WithTimestampGranularity(d time.Duration) Option {
+	return func(g *Generator) {
+		g.granularityMs = d.Milliseconds()
+	}
+}
+
+// dither applies the WithTimestampDither offset to timestamp. It must be
+// called with g.mu held.
+func (g *Generator) dither(timestamp uint64) (uint64, error) {
+	var b [8]byte
+	if err := g.readRandom(b[:]); err != nil {
+		return 0, err
+	}
+	span := uint64(2*g.ditherMs + 1)
+	offset := int64(binary.BigEndian.Uint64(b[:])%span) - g.ditherMs
+	if offset < 0 && uint64(-offset) > timestamp {
+		return 0, nil
+	}
+	return uint64(int64(timestamp) + offset), nil
+}
@@ -0,0 +1,91 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithTimestampDither(t *testing.T) {
+	const window = 5 * time.Minute
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var minOff, maxOff time.Duration
+	for i := 0; i < 200; i++ {
+		// A fresh generator per ID keeps the monotonic clamp out of the way
+		gen := NewGenerator(WithTimestampDither(window))
+		u := Must(gen.NewWithTime(base))
+		off := u.Time().Sub(base)
+		if off < -window || off > window {
+			t.Fatalf("dithered offset %v outside ±%v", off, window)
+		}
+		minOff, maxOff = min(minOff, off), max(maxOff, off)
+	}
+	// With 200 samples over ±5 minutes, both sides of the window are hit
+	if minOff > -time.Minute || maxOff < time.Minute {
+		t.Errorf("offsets span [%v, %v], want most of ±%v", minOff, maxOff, window)
+	}
+}
+
+func TestWithTimestampDither_Monotonic(t *testing.T) {
+	gen := NewGenerator(WithTimestampDither(time.Second))
+	base := time.Now()
+	prev := Must(gen.NewWithTime(base))
+	for i := 0; i < 1000; i++ {
+		u := Must(gen.NewWithTime(base.Add(time.Duration(i) * time.Millisecond)))
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("UUID %d not after previous", i)
+		}
+		prev = u
+	}
+}
+
+func TestWithTimestampDither_NearEpoch(t *testing.T) {
+	gen := NewGenerator(WithTimestampDither(time.Hour))
+	for i := 0; i < 50; i++ {
+		u := Must(gen.NewWithTime(time.UnixMilli(10)))
+		if u.Timestamp() < 0 || u.Timestamp() > int64(time.Hour/time.Millisecond)+10 {
+			t.Fatalf("timestamp %d out of range", u.Timestamp())
+		}
+	}
+}
+
+func TestWithTimestampGranularity(t *testing.T) {
+	base := time.Date(2024, 6, 1, 12, 34, 56, 789000000, time.UTC)
+
+	tests := []struct {
+		d    time.Duration
+		want time.Time
+	}{
+		{time.Second, time.Date(2024, 6, 1, 12, 34, 56, 0, time.UTC)},
+		{time.Minute, time.Date(2024, 6, 1, 12, 34, 0, 0, time.UTC)},
+		{time.Millisecond, base},
+		{time.Microsecond, base},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			gen := NewGenerator(WithTimestampGranularity(tt.d))
+			u := Must(gen.NewWithTime(base))
+			if !u.Time().Equal(tt.want) {
+				t.Errorf("Time() = %v, want %v", u.Time().UTC(), tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTimestampGranularity_Ordering(t *testing.T) {
+	gen := NewGenerator(WithTimestampGranularity(time.Minute))
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev := Must(gen.NewWithTime(base))
+	for i := 1; i < 100; i++ {
+		u := Must(gen.NewWithTime(base.Add(time.Duration(i) * 100 * time.Millisecond)))
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("UUID %d not after previous", i)
+		}
+		// The counter may overflow once, borrowing a single millisecond
+		if d := u.Timestamp() - base.UnixMilli(); d < 0 || d > 1 {
+			t.Fatalf("UUID %d timestamp = %d, want %d", i, u.Timestamp(), base.UnixMilli())
+		}
+		prev = u
+	}
+}
@@ -0,0 +1,164 @@
+package guuid
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Provider issues IDs under one strategy. IDs are returned as strings, a
+// canonical UUID or a decimal integer, so call sites do not change when a
+// service switches strategy through configuration.
+type Provider interface {
+	NextID(ctx context.Context) (string, error)
+}
+
+// ProviderFunc adapts a function to the Provider interface
+type ProviderFunc func(ctx context.Context) (string, error)
+
+// NextID calls f
+func (f ProviderFunc) NextID(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// ProviderFactory builds a Provider from string settings, e.g. read from a
+// config file or environment variables
+type ProviderFactory func(cfg map[string]string) (Provider, error)
+
+// providers holds the registered factories by name
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]ProviderFactory{
+		"uuidv7":    newUUIDv7Provider,
+		"uuidv4":    newUUIDv4Provider,
+		"snowflake": newSnowflakeProvider,
+	}
+)
+
+// RegisterProvider makes a Provider available under name, replacing any
+// earlier registration. The built-in providers are "uuidv7", "uuidv4" and
+// "snowflake"; others, such as the leaf segment service, are registered by
+// the application:
+//
+//	guuid.RegisterProvider("segment", func(cfg map[string]string) (guuid.Provider, error) {
+//	    return guuid.SegmentProvider(leaf.GetID, cfg["biz_tag"]), nil
+//	})
+func RegisterProvider(name string, factory ProviderFactory) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = factory
+}
+
+// NewProvider returns the Provider registered under name, configured by cfg
+func NewProvider(name string, cfg map[string]string) (Provider, error) {
+	providersMu.RLock()
+	factory, ok := providers[name]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+	return factory(cfg)
+}
+
+// Providers returns the registered provider names in sorted order
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SourceProvider returns a Provider issuing the UUIDs of src as strings
+func SourceProvider(src Source) Provider {
+	return ProviderFunc(func(context.Context) (string, error) {
+		uuid, err := src.New()
+		if err != nil {
+			return "", err
+		}
+		return uuid.String(), nil
+	})
+}
+
+// SegmentProvider returns a Provider issuing IDs from a segment allocator for
+// bizTag, such as the GetID method of the leaf segment client
+func SegmentProvider(next func(ctx context.Context, bizTag string) (int64, error), bizTag string) Provider {
+	return ProviderFunc(func(ctx context.Context) (string, error) {
+		id, err := next(ctx, bizTag)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(id, 10), nil
+	})
+}
+
+// newUUIDv7Provider issues UUIDv7s from the default generator
+func newUUIDv7Provider(map[string]string) (Provider, error) {
+	return SourceProvider(SourceFunc(New)), nil
+}
+
+// newUUIDv4Provider issues random UUIDv4s from the default generator
+func newUUIDv4Provider(map[string]string) (Provider, error) {
+	return SourceProvider(SourceFunc(func() (UUID, error) {
+		return Default().newV4()
+	})), nil
+}
+
+// Snowflake layout, matching others/leafSnowflake: 41 bits of milliseconds
+// since SnowflakeEpoch, a 10-bit node ID and a 12-bit sequence
+const (
+	SnowflakeEpoch = 1672531200000 // 2023-01-01T00:00:00Z in Unix milliseconds
+
+	snowflakeNodeBits = 10
+	snowflakeSeqBits  = 12
+	snowflakeMaxNode  = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSeq   = 1<<snowflakeSeqBits - 1
+)
+
+// newSnowflakeProvider issues snowflake IDs for the node set by cfg["node"]
+func newSnowflakeProvider(cfg map[string]string) (Provider, error) {
+	node, err := strconv.ParseInt(cfg["node"], 10, 64)
+	if err != nil || node < 0 || node > snowflakeMaxNode {
+		return nil, fmt.Errorf("guuid: snowflake node must be between 0 and %d, got %q", snowflakeMaxNode, cfg["node"])
+	}
+	s := &snowflake{node: node}
+	return ProviderFunc(func(context.Context) (string, error) {
+		return strconv.FormatInt(s.next(time.Now()), 10), nil
+	}), nil
+}
+
+// snowflake is a monotonic snowflake ID generator for one node
+type snowflake struct {
+	node int64
+
+	mu   sync.Mutex
+	last int64 // milliseconds since SnowflakeEpoch of the last ID
+	seq  int64
+}
+
+// next returns the ID for t. Like Generator, it reuses the last millisecond
+// when the clock goes backwards and borrows the next one when the sequence
+// overflows.
+func (s *snowflake) next(t time.Time) int64 {
+	ms := t.UnixMilli() - SnowflakeEpoch
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ms <= s.last {
+		ms = s.last
+		if s.seq++; s.seq > snowflakeMaxSeq {
+			s.seq = 0
+			ms++
+		}
+	} else {
+		s.seq = 0
+	}
+	s.last = ms
+	return ms<<(snowflakeNodeBits+snowflakeSeqBits) | s.node<<snowflakeSeqBits | s.seq
+}
@@ -0,0 +1,90 @@
+package guuid
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     map[string]string
+		check   func(string) bool
+		wantErr error
+	}{
+		{"uuidv7", nil, func(s string) bool { return MustParse(s).Version() == VersionTimeSorted }, nil},
+		{"uuidv4", nil, func(s string) bool { return MustParse(s).Version() == VersionRandom }, nil},
+		{"snowflake", map[string]string{"node": "7"}, func(s string) bool {
+			n, err := strconv.ParseInt(s, 10, 64)
+			return err == nil && n>>snowflakeSeqBits&snowflakeMaxNode == 7
+		}, nil},
+		{"snowflake", map[string]string{"node": "1024"}, nil, errAny},
+		{"snowflake", nil, nil, errAny},
+		{"nope", nil, nil, ErrUnknownProvider},
+	}
+	for _, tt := range tests {
+		p, err := NewProvider(tt.name, tt.cfg)
+		if tt.wantErr != nil {
+			if err == nil || (tt.wantErr != errAny && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("NewProvider(%q, %v) error = %v, want %v", tt.name, tt.cfg, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("NewProvider(%q) error = %v", tt.name, err)
+		}
+		id, err := p.NextID(context.Background())
+		if err != nil || !tt.check(id) {
+			t.Errorf("%s NextID() = %q, %v", tt.name, id, err)
+		}
+	}
+}
+
+// errAny matches any non-nil error in table tests
+var errAny = errors.New("any error")
+
+func TestRegisterProvider(t *testing.T) {
+	var tag string
+	RegisterProvider("segment-test", func(cfg map[string]string) (Provider, error) {
+		return SegmentProvider(func(_ context.Context, bizTag string) (int64, error) {
+			tag = bizTag
+			return 42, nil
+		}, cfg["biz_tag"]), nil
+	})
+
+	p, err := NewProvider("segment-test", map[string]string{"biz_tag": "orders"})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if id, err := p.NextID(context.Background()); id != "42" || err != nil || tag != "orders" {
+		t.Errorf("NextID() = %q, %v with tag %q, want 42 from orders", id, err, tag)
+	}
+
+	found := false
+	for _, name := range Providers() {
+		found = found || name == "segment-test"
+	}
+	if !found {
+		t.Errorf("Providers() = %v, missing segment-test", Providers())
+	}
+}
+
+func TestSnowflake_Monotonic(t *testing.T) {
+	s := &snowflake{node: 3}
+	at := time.UnixMilli(SnowflakeEpoch + 1000)
+
+	prev := s.next(at)
+	for i := 0; i < 5000; i++ {
+		id := s.next(at)
+		if id <= prev {
+			t.Fatalf("next() #%d = %d, not after %d", i, id, prev)
+		}
+		prev = id
+	}
+	if id := s.next(at.Add(-time.Second)); id <= prev {
+		t.Errorf("next() after clock step back = %d, not after %d", id, prev)
+	}
+}
@@ -0,0 +1,107 @@
+package redisid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// fakeRedis evaluates Script in Go against an in-memory hash
+type fakeRedis struct {
+	mu   sync.Mutex
+	hash map[string][2]int64
+}
+
+func (f *fakeRedis) eval(_ context.Context, script string, keys []string, args ...any) (any, error) {
+	if script != Script || len(keys) != 1 || len(args) != 1 {
+		return nil, errors.New("unexpected call")
+	}
+	floor, ok := args[0].(int64)
+	if !ok {
+		return nil, errors.New("floor is not an int64")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.hash == nil {
+		f.hash = make(map[string][2]int64)
+	}
+	cur, ok := f.hash[keys[0]]
+	if !ok {
+		cur[0] = -1
+	}
+	switch {
+	case floor > cur[0]:
+		cur = [2]int64{floor, 0}
+	case cur[1] >= 4095:
+		cur = [2]int64{cur[0] + 1, 0}
+	default:
+		cur[1]++
+	}
+	f.hash[keys[0]] = cur
+	return []any{cur[0], cur[1]}, nil
+}
+
+func TestCounter(t *testing.T) {
+	r := &fakeRedis{}
+	c := NewCounter(r.eval, "ids")
+
+	ms, counter, err := c.Next(context.Background(), 1000)
+	if err != nil || ms != 1000 || counter != 0 {
+		t.Fatalf("Next() = %d, %d, %v, want 1000, 0, nil", ms, counter, err)
+	}
+	ms, counter, _ = c.Next(context.Background(), 999)
+	if ms != 1000 || counter != 1 {
+		t.Errorf("Next() behind = %d, %d, want 1000, 1", ms, counter)
+	}
+	for i := 0; i < 4094; i++ {
+		_, _, _ = c.Next(context.Background(), 1000)
+	}
+	ms, counter, _ = c.Next(context.Background(), 1000)
+	if ms != 1001 || counter != 0 {
+		t.Errorf("Next() after overflow = %d, %d, want 1001, 0", ms, counter)
+	}
+}
+
+func TestCounter_BadReply(t *testing.T) {
+	tests := []any{
+		nil,
+		"OK",
+		[]any{int64(1)},
+		[]any{"1", int64(0)},
+		[]any{int64(1), int64(4096)},
+		[]any{int64(-1), int64(0)},
+	}
+	for _, reply := range tests {
+		c := NewCounter(func(context.Context, string, []string, ...any) (any, error) {
+			return reply, nil
+		}, "ids")
+		if _, _, err := c.Next(context.Background(), 0); !errors.Is(err, ErrBadReply) {
+			t.Errorf("Next() with reply %#v error = %v, want ErrBadReply", reply, err)
+		}
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	r := &fakeRedis{}
+	a, b := NewGenerator(r.eval, "ids"), NewGenerator(r.eval, "ids")
+
+	prev, err := a.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	for i := 0; i < 1000; i++ {
+		gen := a
+		if i%2 == 0 {
+			gen = b
+		}
+		u, err := gen.NewWithContext(context.Background())
+		if err != nil {
+			t.Fatalf("NewWithContext() error = %v", err)
+		}
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("NewWithContext() #%d = %v, not after %v", i, u, prev)
+		}
+		prev = u
+	}
+}
@@ -0,0 +1,63 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegionGenerator(t *testing.T) {
+	at := time.UnixMilli(1700000000123)
+	for _, region := range []uint8{0, 1, 0x5A, MaxRegion} {
+		gen := NewRegionGenerator(region)
+		u := Must(gen.NewWithTime(at))
+
+		if u.Version() != VersionCustom || u.Variant() != VariantRFC4122 {
+			t.Errorf("region %d: version/variant = %v/%v, want v8 RFC", region, u.Version(), u.Variant())
+		}
+		if got, ok := RegionOf(u); !ok || got != region {
+			t.Errorf("RegionOf() = %d, %v, want %d", got, ok, region)
+		}
+		if got := RegionTime(u); !got.Equal(at) {
+			t.Errorf("RegionTime() = %v, want %v", got, at)
+		}
+	}
+}
+
+func TestRegionGenerator_Monotonic(t *testing.T) {
+	gen := NewRegionGenerator(7)
+	at := time.Now()
+
+	prev := Must(gen.NewWithTime(at))
+	for i := 0; i < 5000; i++ {
+		u := Must(gen.NewWithTime(at))
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("NewWithTime() #%d = %v, not after %v", i, u, prev)
+		}
+		prev = u
+	}
+}
+
+func TestRegionFromV7(t *testing.T) {
+	v7 := UUID{0x01, 0x8f, 0x3e, 0x5a, 0x7b, 0x2c, 0x7A, 0xBC, 0xBF, 0xFF, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66}
+	u := regionFromV7(v7, 0xD3)
+
+	counter := uint16(u[7]&0x0F)<<8 | uint16(u[8]&0x3F)<<2 | uint16(u[9]>>6)
+	if counter != 0xABC {
+		t.Errorf("counter = %#x, want 0xabc", counter)
+	}
+	if u[9]&0x3F != 0x3F || [6]byte(u[10:]) != [6]byte(v7[10:]) {
+		t.Errorf("random bits not preserved: %v", u)
+	}
+	if [6]byte(u[:6]) != [6]byte(v7[:6]) {
+		t.Errorf("timestamp not preserved: %v", u)
+	}
+}
+
+func TestRegionOf_NotV8(t *testing.T) {
+	if _, ok := RegionOf(Must(New())); ok {
+		t.Error("RegionOf(v7) ok = true")
+	}
+	if !RegionTime(Must(New())).IsZero() {
+		t.Error("RegionTime(v7) is not zero")
+	}
+}
@@ -0,0 +1,181 @@
+// Package ring places UUID-keyed work on a consistent-hash ring, so that
+// adding or removing a worker only moves the IDs adjacent to its points:
+//
+//	r := ring.New()
+//	r.Add("worker-a", "worker-b", "worker-c")
+//	owner := r.Owner(orderID)
+//
+// IDs are placed by UUID.Hash64 rather than their bytes, so time-ordered
+// UUIDv7s spread evenly. Each worker owns Replicas virtual nodes at
+// positions derived from its name with UUIDv5 in the Namespace namespace,
+// so rings built from the same names and options agree across processes.
+package ring
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// DefaultReplicas is the number of virtual nodes per worker
+const DefaultReplicas = 128
+
+// Namespace is the UUIDv5 namespace of virtual node names, "<node>#<i>"
+var Namespace = guuid.Namespace("ring")
+
+// point is a virtual node
+type point struct {
+	hash uint64
+	node string
+}
+
+// Option configures a Ring
+type Option func(*Ring)
+
+// WithReplicas sets the number of virtual nodes per worker. More replicas
+// balance load more evenly at the cost of memory. Values under 1 are ignored.
+func WithReplicas(n int) Option {
+	return func(r *Ring) {
+		if n > 0 {
+			r.replicas = n
+		}
+	}
+}
+
+// WithSeed sets the seed passed to UUID.Hash64 when placing IDs and virtual
+// nodes, to decorrelate rings sharing the same workers
+func WithSeed(seed uint64) Option {
+	return func(r *Ring) {
+		r.seed = seed
+	}
+}
+
+// Ring is a consistent-hash ring of named workers. It is safe for concurrent use.
+type Ring struct {
+	replicas int
+	seed     uint64
+
+	mu     sync.RWMutex
+	points []point // sorted by hash, then node
+	nodes  map[string]struct{}
+}
+
+// New returns an empty Ring
+func New(opts ...Option) *Ring {
+	r := &Ring{
+		replicas: DefaultReplicas,
+		nodes:    make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Add places nodes on the ring; nodes already present are ignored
+func (r *Ring) Add(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, node := range nodes {
+		if _, ok := r.nodes[node]; ok {
+			continue
+		}
+		r.nodes[node] = struct{}{}
+		for i := 0; i < r.replicas; i++ {
+			name := node + "#" + strconv.Itoa(i)
+			r.points = append(r.points, point{guuid.NewV5(Namespace, []byte(name)).Hash64(r.seed), node})
+		}
+	}
+	slices.SortFunc(r.points, comparePoints)
+}
+
+// Remove takes nodes off the ring; their IDs move to the next nodes clockwise
+func (r *Ring) Remove(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, node := range nodes {
+		delete(r.nodes, node)
+	}
+	r.points = slices.DeleteFunc(r.points, func(p point) bool {
+		_, ok := r.nodes[p.node]
+		return !ok
+	})
+}
+
+// Nodes returns the nodes on the ring, sorted
+func (r *Ring) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	nodes := make([]string, 0, len(r.nodes))
+	for node := range r.nodes {
+		nodes = append(nodes, node)
+	}
+	slices.Sort(nodes)
+	return nodes
+}
+
+// Owner returns the node owning id, or "" if the ring is empty
+func (r *Ring) Owner(id guuid.UUID) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return ""
+	}
+	return r.points[r.search(id)].node
+}
+
+// Owners returns up to n distinct nodes for id in ring order, starting with
+// its owner, e.g. to pick replicas or a fallback worker
+func (r *Ring) Owners(id guuid.UUID, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n = min(n, len(r.nodes))
+	if n <= 0 {
+		return nil
+	}
+	owners := make([]string, 0, n)
+	for i, start := 0, r.search(id); len(owners) < n; i++ {
+		node := r.points[(start+i)%len(r.points)].node
+		if !slices.Contains(owners, node) {
+			owners = append(owners, node)
+		}
+	}
+	return owners
+}
+
+// search returns the index of the first point at or after the position of
+// id, wrapping around; r.points must not be empty
+func (r *Ring) search(id guuid.UUID) int {
+	h := id.Hash64(r.seed)
+	i, _ := slices.BinarySearchFunc(r.points, h, func(p point, h uint64) int {
+		switch {
+		case p.hash < h:
+			return -1
+		case p.hash > h:
+			return 1
+		}
+		return 0
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return i
+}
+
+// comparePoints orders points by hash, breaking ties by node name so that
+// every process builds the same ring
+func comparePoints(a, b point) int {
+	switch {
+	case a.hash < b.hash:
+		return -1
+	case a.hash > b.hash:
+		return 1
+	case a.node < b.node:
+		return -1
+	case a.node > b.node:
+		return 1
+	}
+	return 0
+}
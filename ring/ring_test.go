@@ -0,0 +1,115 @@
+package ring
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func testIDs(n int) []guuid.UUID {
+	gen := guuid.NewGenerator()
+	ids := make([]guuid.UUID, n)
+	for i := range ids {
+		ids[i] = guuid.Must(gen.New())
+	}
+	return ids
+}
+
+func TestRing_Empty(t *testing.T) {
+	r := New()
+	id := guuid.MustNew()
+	if got := r.Owner(id); got != "" {
+		t.Errorf("Owner() = %q, want \"\"", got)
+	}
+	if got := r.Owners(id, 3); got != nil {
+		t.Errorf("Owners() = %v, want nil", got)
+	}
+}
+
+func TestRing_Balance(t *testing.T) {
+	r := New()
+	r.Add("a", "b", "c", "d")
+
+	counts := make(map[string]int)
+	ids := testIDs(40000)
+	for _, id := range ids {
+		counts[r.Owner(id)]++
+	}
+	if len(counts) != 4 {
+		t.Fatalf("owners = %v, want 4 nodes", counts)
+	}
+	for node, n := range counts {
+		if share := float64(n) / float64(len(ids)); math.Abs(share-0.25) > 0.07 {
+			t.Errorf("node %s owns %.3f of IDs, want about 0.25", node, share)
+		}
+	}
+}
+
+func TestRing_Stable(t *testing.T) {
+	a, b := New(), New()
+	a.Add("x", "y", "z")
+	b.Add("z", "x", "y", "x")
+
+	for _, id := range testIDs(1000) {
+		if a.Owner(id) != b.Owner(id) {
+			t.Fatalf("rings disagree on %v: %s vs %s", id, a.Owner(id), b.Owner(id))
+		}
+	}
+	if got := b.Nodes(); !slices.Equal(got, []string{"x", "y", "z"}) {
+		t.Errorf("Nodes() = %v", got)
+	}
+}
+
+func TestRing_Remove(t *testing.T) {
+	r := New()
+	r.Add("a", "b", "c")
+	ids := testIDs(5000)
+	before := make([]string, len(ids))
+	for i, id := range ids {
+		before[i] = r.Owner(id)
+	}
+
+	r.Remove("b")
+	for i, id := range ids {
+		got := r.Owner(id)
+		if got == "b" {
+			t.Fatalf("Owner(%v) = removed node b", id)
+		}
+		if before[i] != "b" && got != before[i] {
+			t.Fatalf("Owner(%v) moved from %s to %s", id, before[i], got)
+		}
+	}
+}
+
+func TestRing_Owners(t *testing.T) {
+	r := New(WithReplicas(16))
+	r.Add("a", "b", "c")
+	id := guuid.MustNew()
+
+	owners := r.Owners(id, 5)
+	if len(owners) != 3 || owners[0] != r.Owner(id) {
+		t.Errorf("Owners() = %v, want 3 nodes starting with %s", owners, r.Owner(id))
+	}
+	slices.Sort(owners)
+	if !slices.Equal(owners, []string{"a", "b", "c"}) {
+		t.Errorf("Owners() = %v, want distinct nodes", owners)
+	}
+}
+
+func TestWithSeed(t *testing.T) {
+	a, b := New(), New(WithSeed(7))
+	a.Add("a", "b", "c")
+	b.Add("a", "b", "c")
+
+	differ := 0
+	for _, id := range testIDs(1000) {
+		if a.Owner(id) != b.Owner(id) {
+			differ++
+		}
+	}
+	if differ == 0 {
+		t.Error("WithSeed() does not change placement")
+	}
+}
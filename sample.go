@@ -0,0 +1,138 @@
+package guuid
+
+import (
+	"slices"
+	"time"
+)
+
+// TimeSample is the sample a TimeSampler kept for one time bucket
+type TimeSample struct {
+	Start time.Time // start of the bucket
+	Seen  int       // UUIDs added to the bucket, duplicates included
+	IDs   []UUID    // sampled UUIDs in UUID (time) order
+}
+
+// TimeSampler keeps a fixed number of UUIDv7s per time bucket of a stream,
+// bucketed by their embedded timestamps, e.g. to inspect a flood of events
+// without storing all of them. Within a bucket it keeps the IDs with the
+// smallest Hash64 values, a uniform sample that is deterministic, ignores
+// duplicates, and matches what a sampler on another node keeps from the same
+// IDs. Memory grows with the number of buckets seen; call Reset after
+// collecting Samples in long-running use. It is not safe for concurrent use.
+type TimeSampler struct {
+	bucket    time.Duration
+	perBucket int
+	buckets   map[int64]*timeBucket
+}
+
+// timeBucket holds the sample of one bucket as a max-heap on hash
+type timeBucket struct {
+	seen int
+	ids  []sampledID
+}
+
+// sampledID is a sampled UUID and its hash
+type sampledID struct {
+	hash uint64
+	id   UUID
+}
+
+// NewTimeSampler returns a TimeSampler keeping up to perBucket UUIDs for
+// every bucket of width bucket, at least a millisecond
+func NewTimeSampler(bucket time.Duration, perBucket int) *TimeSampler {
+	return &TimeSampler{
+		bucket:    max(bucket, time.Millisecond),
+		perBucket: max(perBucket, 1),
+		buckets:   make(map[int64]*timeBucket),
+	}
+}
+
+// Add offers u to the sample of its bucket. It reports false, ignoring u, if
+// u is not a UUIDv7.
+func (s *TimeSampler) Add(u UUID) bool {
+	if u.Version() != VersionTimeSorted {
+		return false
+	}
+	key := u.TimeBucket(s.bucket)
+	b := s.buckets[key]
+	if b == nil {
+		b = &timeBucket{}
+		s.buckets[key] = b
+	}
+	b.seen++
+
+	e := sampledID{u.Hash64(0), u}
+	for _, x := range b.ids {
+		if x.id == u {
+			return true
+		}
+	}
+	switch {
+	case len(b.ids) < s.perBucket:
+		b.ids = append(b.ids, e)
+		b.up(len(b.ids) - 1)
+	case e.hash < b.ids[0].hash:
+		b.ids[0] = e
+		b.down(0)
+	}
+	return true
+}
+
+// Samples returns the sample of every bucket seen, in time order
+func (s *TimeSampler) Samples() []TimeSample {
+	keys := make([]int64, 0, len(s.buckets))
+	for k := range s.buckets {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	samples := make([]TimeSample, len(keys))
+	for i, k := range keys {
+		b := s.buckets[k]
+		ids := make([]UUID, len(b.ids))
+		for j, x := range b.ids {
+			ids[j] = x.id
+		}
+		slices.SortFunc(ids, Compare)
+		samples[i] = TimeSample{
+			Start: time.UnixMilli(k * s.bucket.Milliseconds()),
+			Seen:  b.seen,
+			IDs:   ids,
+		}
+	}
+	return samples
+}
+
+// Reset drops all buckets
+func (s *TimeSampler) Reset() {
+	clear(s.buckets)
+}
+
+// up restores the heap order after appending at i
+func (b *timeBucket) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if b.ids[parent].hash >= b.ids[i].hash {
+			return
+		}
+		b.ids[parent], b.ids[i] = b.ids[i], b.ids[parent]
+		i = parent
+	}
+}
+
+// down restores the heap order after replacing the root
+func (b *timeBucket) down(i int) {
+	for {
+		largest := i
+		for _, c := range []int{2*i + 1, 2*i + 2} {
+			if c < len(b.ids) && b.ids[c].hash > b.ids[largest].hash {
+				largest = c
+			}
+		}
+		if largest == i {
+			return
+		}
+		b.ids[i], b.ids[largest] = b.ids[largest], b.ids[i]
+		i = largest
+	}
+}
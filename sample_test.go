@@ -0,0 +1,96 @@
+package guuid
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimeSampler(t *testing.T) {
+	gen := NewGenerator()
+	start := time.UnixMilli(1700000000000)
+	s := NewTimeSampler(time.Second, 5)
+
+	var all []UUID
+	for i := 0; i < 300; i++ {
+		at := start.Add(time.Duration(i) * 10 * time.Millisecond) // 100 per second
+		u := Must(gen.NewWithTime(at))
+		all = append(all, u)
+		if !s.Add(u) {
+			t.Fatalf("Add(%v) = false", u)
+		}
+	}
+	s.Add(all[0]) // duplicate: counted as seen, not sampled twice
+
+	samples := s.Samples()
+	if len(samples) != 3 {
+		t.Fatalf("Samples() returned %d buckets, want 3", len(samples))
+	}
+	for i, sm := range samples {
+		if want := start.Add(time.Duration(i) * time.Second); !sm.Start.Equal(want) {
+			t.Errorf("bucket %d Start = %v, want %v", i, sm.Start, want)
+		}
+		wantSeen := 100
+		if i == 0 {
+			wantSeen = 101
+		}
+		if sm.Seen != wantSeen || len(sm.IDs) != 5 {
+			t.Errorf("bucket %d Seen, len(IDs) = %d, %d, want %d, 5", i, sm.Seen, len(sm.IDs), wantSeen)
+		}
+		if !slices.IsSortedFunc(sm.IDs, Compare) {
+			t.Errorf("bucket %d IDs not sorted", i)
+		}
+		for _, u := range sm.IDs {
+			if !u.Truncate(time.Second).Equal(sm.Start) {
+				t.Errorf("bucket %d holds %v from %v", i, u, u.Time())
+			}
+		}
+	}
+
+	// the sample is the 5 smallest hashes of the first bucket
+	first := slices.Clone(all[:100])
+	slices.SortFunc(first, func(a, b UUID) int {
+		switch ha, hb := a.Hash64(0), b.Hash64(0); {
+		case ha < hb:
+			return -1
+		case ha > hb:
+			return 1
+		}
+		return 0
+	})
+	want := first[:5]
+	slices.SortFunc(want, Compare)
+	if !slices.Equal(samples[0].IDs, want) {
+		t.Errorf("bucket 0 IDs = %v, want %v", samples[0].IDs, want)
+	}
+}
+
+func TestTimeSampler_OrderIndependent(t *testing.T) {
+	ids := testIDs(1000)
+	a, b := NewTimeSampler(time.Hour, 10), NewTimeSampler(time.Hour, 10)
+	for i := range ids {
+		a.Add(ids[i])
+		b.Add(ids[len(ids)-1-i])
+	}
+	sa, sb := a.Samples(), b.Samples()
+	if len(sa) != len(sb) {
+		t.Fatalf("bucket counts differ: %d vs %d", len(sa), len(sb))
+	}
+	for i := range sa {
+		if !slices.Equal(sa[i].IDs, sb[i].IDs) {
+			t.Errorf("bucket %d differs by insertion order", i)
+		}
+	}
+}
+
+func TestTimeSampler_NonV7(t *testing.T) {
+	s := NewTimeSampler(time.Second, 1)
+	if s.Add(NewV5(NamespaceDNS, []byte("example.com"))) {
+		t.Error("Add(v5) = true")
+	}
+	s.Add(Must(New()))
+	s.Reset()
+	if got := s.Samples(); len(got) != 0 {
+		t.Errorf("Samples() after Reset() = %v", got)
+	}
+}
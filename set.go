@@ -0,0 +1,230 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// UUIDSet is a set of UUIDs backed by an open-addressing hash table over the
+// raw 16-byte keys. It stores each UUID inline in a 16-byte slot with no
+// per-entry pointers or metadata, far less than the per-entry overhead of
+// map[UUID]bool. The Nil UUID is tracked separately because it marks empty slots.
+//
+// A UUIDSet created with NewBloomSet is probabilistic: Contains may report
+// false positives at the configured rate, and Len is an approximation.
+// Union and Intersect are only supported between exact sets and panic if
+// either set is a Bloom set.
+//
+// The zero value is an empty exact set ready to use. UUIDSet is not safe for
+// concurrent use.
+type UUIDSet struct {
+	slots   []UUID
+	count   int
+	hasNil  bool
+	mask    uint64
+	bloom   []uint64 // bit array, non-nil in Bloom mode
+	hashes  int      // number of Bloom hash functions
+	bloomSz uint64   // number of bits in bloom
+}
+
+const (
+	minSetSlots = 16
+	maxLoadNum  = 3 // grow when count exceeds 3/4 of capacity
+	maxLoadDen  = 4
+)
+
+// NewUUIDSet returns an exact set sized to hold capacity UUIDs without growing
+func NewUUIDSet(capacity int) *UUIDSet {
+	n := minSetSlots
+	for n*maxLoadNum/maxLoadDen < capacity {
+		n <<= 1
+	}
+	return &UUIDSet{
+		slots: make([]UUID, n),
+		mask:  uint64(n - 1),
+	}
+}
+
+// NewBloomSet returns a probabilistic set sized for the expected number of
+// UUIDs and the desired false-positive rate (0 < fpRate < 1).
+func NewBloomSet(expected int, fpRate float64) *UUIDSet {
+	if expected < 1 {
+		expected = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.01
+	}
+	m := math.Ceil(-float64(expected) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / float64(expected) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	words := (uint64(m) + 63) / 64
+	return &UUIDSet{
+		bloom:   make([]uint64, words),
+		hashes:  k,
+		bloomSz: words * 64,
+	}
+}
+
+// IsBloom reports whether the set is probabilistic
+func (s *UUIDSet) IsBloom() bool {
+	return s.bloom != nil
+}
+
+// Len returns the number of UUIDs added. In Bloom mode it counts Add calls
+// that changed the filter, which underestimates the true size when false
+// positives occur.
+func (s *UUIDSet) Len() int {
+	if s.hasNil {
+		return s.count + 1
+	}
+	return s.count
+}
+
+// Add inserts u and reports whether it was newly added
+func (s *UUIDSet) Add(u UUID) bool {
+	if s.bloom != nil {
+		return s.bloomAdd(u)
+	}
+	if u == Nil {
+		added := !s.hasNil
+		s.hasNil = true
+		return added
+	}
+	if (s.count+1)*maxLoadDen > len(s.slots)*maxLoadNum {
+		s.grow()
+	}
+	i := s.find(u)
+	if s.slots[i] == u {
+		return false
+	}
+	s.slots[i] = u
+	s.count++
+	return true
+}
+
+// Contains reports whether u is in the set
+func (s *UUIDSet) Contains(u UUID) bool {
+	if s.bloom != nil {
+		return s.bloomContains(u)
+	}
+	if u == Nil {
+		return s.hasNil
+	}
+	if s.count == 0 {
+		return false
+	}
+	return s.slots[s.find(u)] == u
+}
+
+// Each calls fn for every UUID in the set, in unspecified order.
+// It is a no-op in Bloom mode.
+func (s *UUIDSet) Each(fn func(UUID)) {
+	if s.hasNil {
+		fn(Nil)
+	}
+	for _, u := range s.slots {
+		if u != Nil {
+			fn(u)
+		}
+	}
+}
+
+// Union returns a new exact set containing the UUIDs of s and other. It panics
+// if either is a Bloom set, whose members cannot be enumerated.
+func (s *UUIDSet) Union(other *UUIDSet) *UUIDSet {
+	mustBeExact("Union", s, other)
+	out := NewUUIDSet(s.Len() + other.Len())
+	s.Each(func(u UUID) { out.Add(u) })
+	other.Each(func(u UUID) { out.Add(u) })
+	return out
+}
+
+// Intersect returns a new exact set containing the UUIDs present in both s and
+// other. It panics if either is a Bloom set.
+func (s *UUIDSet) Intersect(other *UUIDSet) *UUIDSet {
+	mustBeExact("Intersect", s, other)
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	out := NewUUIDSet(small.Len())
+	small.Each(func(u UUID) {
+		if large.Contains(u) {
+			out.Add(u)
+		}
+	})
+	return out
+}
+
+// mustBeExact panics if s or other is a Bloom set
+func mustBeExact(op string, s, other *UUIDSet) {
+	if s.IsBloom() || other.IsBloom() {
+		panic("guuid: UUIDSet." + op + " of a Bloom set")
+	}
+}
+
+// find returns the slot holding u or the empty slot where it belongs
+func (s *UUIDSet) find(u UUID) uint64 {
+	i := setHash(u) & s.mask
+	for s.slots[i] != Nil && s.slots[i] != u {
+		i = (i + 1) & s.mask
+	}
+	return i
+}
+
+// grow doubles the table, or allocates it for a zero-value set, and
+// reinserts all entries
+func (s *UUIDSet) grow() {
+	old := s.slots
+	s.slots = make([]UUID, max(len(old)*2, minSetSlots))
+	s.mask = uint64(len(s.slots) - 1)
+	for _, u := range old {
+		if u != Nil {
+			s.slots[s.find(u)] = u
+		}
+	}
+}
+
+// setHash mixes both halves of u. The low bits of a UUIDv7 are random, but
+// mixing keeps the table well distributed for non-random inputs too.
+func setHash(u UUID) uint64 {
+	hi := binary.BigEndian.Uint64(u[0:8])
+	lo := binary.BigEndian.Uint64(u[8:16])
+	h, l := bits.Mul64(hi^0x9e3779b97f4a7c15, lo^0xbf58476d1ce4e5b9)
+	return h ^ l
+}
+
+// bloomAdd sets the k bits for u and reports whether any was previously unset
+func (s *UUIDSet) bloomAdd(u UUID) bool {
+	h1 := setHash(u)
+	h2 := bits.RotateLeft64(h1, 32) | 1
+	added := false
+	for i := 0; i < s.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % s.bloomSz
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if s.bloom[word]&mask == 0 {
+			s.bloom[word] |= mask
+			added = true
+		}
+	}
+	if added {
+		s.count++
+	}
+	return added
+}
+
+// bloomContains reports whether all k bits for u are set
+func (s *UUIDSet) bloomContains(u UUID) bool {
+	h1 := setHash(u)
+	h2 := bits.RotateLeft64(h1, 32) | 1
+	for i := 0; i < s.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % s.bloomSz
+		if s.bloom[bit/64]&(uint64(1)<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
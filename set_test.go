@@ -0,0 +1,142 @@
+package guuid
+
+import (
+	"testing"
+)
+
+func TestUUIDSet_AddContains(t *testing.T) {
+	gen := NewGenerator()
+	set := NewUUIDSet(0)
+	ids := make([]UUID, 10000)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+		if !set.Add(ids[i]) {
+			t.Fatalf("Add() reported existing UUID for new id %v", ids[i])
+		}
+	}
+
+	if set.Len() != len(ids) {
+		t.Errorf("Len() = %d, want %d", set.Len(), len(ids))
+	}
+	for _, id := range ids {
+		if !set.Contains(id) {
+			t.Fatalf("Contains(%v) = false", id)
+		}
+		if set.Add(id) {
+			t.Fatalf("Add() reported new UUID for duplicate %v", id)
+		}
+	}
+	if set.Contains(Must(gen.New())) {
+		t.Error("Contains() = true for UUID never added")
+	}
+
+	if set.Contains(Nil) {
+		t.Error("Contains(Nil) = true before adding Nil")
+	}
+	set.Add(Nil)
+	if !set.Contains(Nil) || set.Len() != len(ids)+1 {
+		t.Errorf("Nil handling: Contains = %v, Len = %d", set.Contains(Nil), set.Len())
+	}
+}
+
+func TestUUIDSet_UnionIntersect(t *testing.T) {
+	a, b := NewUUIDSet(4), NewUUIDSet(4)
+	shared := UUID{1}
+	a.Add(shared)
+	a.Add(UUID{2})
+	b.Add(shared)
+	b.Add(UUID{3})
+	b.Add(Nil)
+
+	union := a.Union(b)
+	if union.Len() != 4 {
+		t.Errorf("Union().Len() = %d, want 4", union.Len())
+	}
+	for _, u := range []UUID{shared, {2}, {3}, Nil} {
+		if !union.Contains(u) {
+			t.Errorf("Union() missing %v", u)
+		}
+	}
+
+	inter := a.Intersect(b)
+	if inter.Len() != 1 || !inter.Contains(shared) {
+		t.Errorf("Intersect() = %d entries, want only %v", inter.Len(), shared)
+	}
+}
+
+func TestUUIDSet_ZeroValue(t *testing.T) {
+	var set UUIDSet
+	if set.Contains(UUID{1}) || set.Contains(Nil) {
+		t.Error("zero value Contains() = true, want empty set")
+	}
+	for i := byte(1); i <= 100; i++ {
+		if !set.Add(UUID{i}) {
+			t.Fatalf("Add(%d) = false on a new UUID", i)
+		}
+	}
+	if set.Len() != 100 || !set.Contains(UUID{50}) {
+		t.Errorf("Len() = %d, want 100 with every UUID present", set.Len())
+	}
+}
+
+func TestUUIDSet_BloomCombinePanics(t *testing.T) {
+	exact, bloom := NewUUIDSet(4), NewBloomSet(4, 0.01)
+	for name, fn := range map[string]func(){
+		"Union":           func() { exact.Union(bloom) },
+		"Intersect":       func() { exact.Intersect(bloom) },
+		"Bloom Union":     func() { bloom.Union(exact) },
+		"Bloom Intersect": func() { bloom.Intersect(exact) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s with a Bloom set did not panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestUUIDSet_Bloom(t *testing.T) {
+	gen := NewGenerator()
+	set := NewBloomSet(10000, 0.01)
+	if !set.IsBloom() {
+		t.Fatal("IsBloom() = false for NewBloomSet")
+	}
+
+	for i := 0; i < 10000; i++ {
+		id := Must(gen.New())
+		set.Add(id)
+		if !set.Contains(id) {
+			t.Fatalf("Bloom Contains(%v) = false after Add", id)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if set.Contains(Must(gen.New())) {
+			falsePositives++
+		}
+	}
+	// Expected ~1%; allow generous slack to keep the test stable
+	if falsePositives > 300 {
+		t.Errorf("Bloom false positives = %d/10000, want around 100", falsePositives)
+	}
+}
+
+func BenchmarkUUIDSet_Add(b *testing.B) {
+	gen := NewGenerator()
+	ids := make([]UUID, 1<<16)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+	}
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		set := NewUUIDSet(len(ids))
+		for _, id := range ids {
+			set.Add(id)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package guuid
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// Sharded UUIDv8 layout. A shard byte precedes the 48-bit timestamp, so IDs
+// generated at the same time scatter across up to 256 key ranges instead of
+// piling onto the tail of the keyspace, a write hotspot in stores that range
+// partition by key such as HBase and Bigtable:
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|     shard     |             unix_ts_ms (24 bits)              |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|     unix_ts_ms (16 bits)      |  ver  | ts (8 bits)   | cnt_hi|
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|var|  cnt_lo (8 bits)  |            random (22 bits)           |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                        random (32 bits)                       |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// IDs sort by shard, then time, then counter, so the IDs of each shard stay in
+// generation order and a time range is read with one scan per shard (see
+// ShardRange) rather than a single scan.
+
+// MaxShards is the largest shard count of a ShardedGenerator
+const MaxShards = 256
+
+// ShardedGenerator generates shard-prefixed UUIDv8s, using a Generator for
+// the timestamp, counter and randomness. It is safe for concurrent use.
+type ShardedGenerator struct {
+	gen    *Generator
+	shards int
+}
+
+// NewShardedGenerator returns a ShardedGenerator spreading IDs over shards
+// key ranges, clamped to [1, MaxShards]; opts configure the underlying
+// Generator. Use only as many shards as the store has nodes to spread over:
+// every shard costs readers one more scan.
+func NewShardedGenerator(shards int, opts ...Option) *ShardedGenerator {
+	return &ShardedGenerator{gen: NewGenerator(opts...), shards: min(max(shards, 1), MaxShards)}
+}
+
+// Shards returns the number of shards IDs are spread over
+func (s *ShardedGenerator) Shards() int {
+	return s.shards
+}
+
+// New generates a sharded UUIDv8 with the current time, its shard derived
+// from a hash of the generated bits
+func (s *ShardedGenerator) New() (UUID, error) {
+	v7, err := s.gen.New()
+	if err != nil {
+		return Nil, err
+	}
+	return shardedFromV7(v7, s.shard(v7[:])), nil
+}
+
+// NewForKey generates a sharded UUIDv8 with the current time, its shard
+// derived from a hash of key, so all IDs for one key share a shard and stay
+// ordered relative to each other
+func (s *ShardedGenerator) NewForKey(key []byte) (UUID, error) {
+	v7, err := s.gen.New()
+	if err != nil {
+		return Nil, err
+	}
+	return shardedFromV7(v7, s.shard(key)), nil
+}
+
+// shard hashes b onto one of the generator's shards
+func (s *ShardedGenerator) shard(b []byte) uint8 {
+	h := fnv.New32a()
+	h.Write(b)
+	return uint8(h.Sum32() % uint32(s.shards))
+}
+
+// shardedFromV7 rearranges a UUIDv7 into the sharded layout, keeping its
+// timestamp, counter and 54 of its random bits
+func shardedFromV7(v7 UUID, shard uint8) UUID {
+	ts := uint64(v7[0])<<40 | uint64(v7[1])<<32 | uint64(v7[2])<<24 | uint64(v7[3])<<16 | uint64(v7[4])<<8 | uint64(v7[5])
+	counter := uint16(v7[6]&0x0F)<<8 | uint16(v7[7])
+	u := shardedUUID(shard, ts, counter)
+	u[9] |= v7[9] & 0x3F
+	copy(u[10:], v7[10:])
+	return u
+}
+
+// shardedUUID builds a sharded UUIDv8 with all random bits zero
+func shardedUUID(shard uint8, ts uint64, counter uint16) UUID {
+	var u UUID
+	u[0] = shard
+	u[1], u[2], u[3], u[4], u[5] = byte(ts>>40), byte(ts>>32), byte(ts>>24), byte(ts>>16), byte(ts>>8)
+	u[6] = 0x80 | byte(ts>>4)&0x0F
+	u[7] = byte(ts)<<4 | byte(counter>>8)&0x0F
+	u[8] = 0x80 | byte(counter)>>2
+	u[9] = byte(counter) << 6
+	return u
+}
+
+// ShardOf returns the shard of a sharded UUIDv8. ok is false if u is not an
+// RFC 9562 UUIDv8; as v8 layouts are application-defined, only use ShardOf
+// on IDs known to come from a ShardedGenerator.
+func ShardOf(u UUID) (shard uint8, ok bool) {
+	if u.Version() != VersionCustom || u.Variant() != VariantRFC4122 {
+		return 0, false
+	}
+	return u[0], true
+}
+
+// ShardTime returns the timestamp of a sharded UUIDv8, or the zero time if u
+// is not a UUIDv8
+func ShardTime(u UUID) time.Time {
+	if u.Version() != VersionCustom {
+		return time.Time{}
+	}
+	ms := int64(u[1])<<40 | int64(u[2])<<32 | int64(u[3])<<24 | int64(u[4])<<16 | int64(u[5])<<8 | int64(u[6]&0x0F)<<4 | int64(u[7]>>4)
+	return time.UnixMilli(ms)
+}
+
+// ShardRange returns the smallest and largest sharded UUIDv8 of shard with a
+// timestamp in [from, to] at millisecond precision. Scanning [lo, hi] for
+// every shard reads all IDs generated in the time range.
+func ShardRange(shard uint8, from, to time.Time) (lo, hi UUID) {
+	lo = shardedUUID(shard, uint64(from.UnixMilli()), 0)
+	hi = shardedUUID(shard, uint64(to.UnixMilli()), 0x0FFF)
+	hi[9] |= 0x3F
+	for i := 10; i < len(hi); i++ {
+		hi[i] = 0xFF
+	}
+	return lo, hi
+}
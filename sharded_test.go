@@ -0,0 +1,112 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShardedGenerator(t *testing.T) {
+	gen := NewShardedGenerator(16)
+	before := time.Now().Truncate(time.Millisecond)
+	seen := make(map[uint8]bool)
+	for i := 0; i < 1000; i++ {
+		u := Must(gen.New())
+		if u.Version() != VersionCustom || u.Variant() != VariantRFC4122 {
+			t.Fatalf("version/variant = %v/%v, want v8 RFC", u.Version(), u.Variant())
+		}
+		shard, ok := ShardOf(u)
+		if !ok || int(shard) >= gen.Shards() {
+			t.Fatalf("ShardOf() = %d, %v, want < %d", shard, ok, gen.Shards())
+		}
+		seen[shard] = true
+		// counter overflow may borrow a few milliseconds ahead of the clock
+		if got := ShardTime(u); got.Before(before) || got.After(time.Now().Add(time.Second)) {
+			t.Fatalf("ShardTime() = %v, want around %v", got, before)
+		}
+	}
+	if len(seen) != 16 {
+		t.Errorf("IDs spread over %d shards, want 16", len(seen))
+	}
+}
+
+func TestShardedGenerator_KeyOrdered(t *testing.T) {
+	gen := NewShardedGenerator(MaxShards)
+	key := []byte("customer-42")
+
+	prev := Must(gen.NewForKey(key))
+	for i := 0; i < 5000; i++ {
+		u := Must(gen.NewForKey(key))
+		if u[0] != prev[0] {
+			t.Fatalf("NewForKey() shard = %d, want %d", u[0], prev[0])
+		}
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("NewForKey() #%d = %v, not after %v", i, u, prev)
+		}
+		prev = u
+	}
+}
+
+func TestNewShardedGenerator_Clamp(t *testing.T) {
+	tests := []struct{ shards, want int }{{0, 1}, {-3, 1}, {8, 8}, {1000, MaxShards}}
+	for _, tt := range tests {
+		if got := NewShardedGenerator(tt.shards).Shards(); got != tt.want {
+			t.Errorf("NewShardedGenerator(%d).Shards() = %d, want %d", tt.shards, got, tt.want)
+		}
+	}
+}
+
+func TestShardedFromV7(t *testing.T) {
+	v7 := UUID{0x01, 0x8f, 0x3e, 0x5a, 0x7b, 0x2c, 0x7A, 0xBC, 0xBF, 0xFF, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66}
+	u := shardedFromV7(v7, 0xD3)
+
+	if u[0] != 0xD3 {
+		t.Errorf("shard = %#x, want 0xd3", u[0])
+	}
+	if got := ShardTime(u).UnixMilli(); got != 0x018f3e5a7b2c {
+		t.Errorf("ShardTime() = %#x, want 0x18f3e5a7b2c", got)
+	}
+	counter := uint16(u[7]&0x0F)<<8 | uint16(u[8]&0x3F)<<2 | uint16(u[9]>>6)
+	if counter != 0xABC {
+		t.Errorf("counter = %#x, want 0xabc", counter)
+	}
+	if u[9]&0x3F != 0x3F || [6]byte(u[10:]) != [6]byte(v7[10:]) {
+		t.Errorf("random bits not preserved: %v", u)
+	}
+}
+
+func TestShardRange(t *testing.T) {
+	gen := NewShardedGenerator(4)
+	from := time.Now()
+	var ids []UUID
+	for i := 0; i < 200; i++ {
+		ids = append(ids, Must(gen.New()))
+	}
+	to := time.Now().Add(time.Second) // leave room for counter borrowing
+
+	found := 0
+	for shard := 0; shard < gen.Shards(); shard++ {
+		lo, hi := ShardRange(uint8(shard), from, to)
+		for _, u := range ids {
+			if u.Compare(lo) >= 0 && u.Compare(hi) <= 0 {
+				found++
+			}
+		}
+	}
+	if found != len(ids) {
+		t.Errorf("ShardRange() scans found %d IDs, want %d", found, len(ids))
+	}
+
+	lo, hi := ShardRange(1, from, from)
+	if ShardTime(lo).UnixMilli() != from.UnixMilli() || ShardTime(hi).UnixMilli() != from.UnixMilli() {
+		t.Errorf("ShardRange() times = %v, %v, want %v", ShardTime(lo), ShardTime(hi), from)
+	}
+}
+
+func TestShardOf_NotV8(t *testing.T) {
+	if _, ok := ShardOf(Must(New())); ok {
+		t.Error("ShardOf(v7) ok = true")
+	}
+	if !ShardTime(Must(New())).IsZero() {
+		t.Error("ShardTime(v7) is not zero")
+	}
+}
@@ -0,0 +1,75 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+)
+
+// sharedStateSize is the size of the state file: the last timestamp and counter
+const sharedStateSize = 10
+
+// sharedState is generator state kept in a file and guarded by an advisory
+// lock, so that generators in several processes on one host act as one
+type sharedState struct {
+	f *os.File
+}
+
+// NewSharedGenerator returns a generator whose monotonic state lives in the
+// file at path, shared with every other process that opens the same file.
+// Each ID is generated under an exclusive advisory lock on the file, so the
+// combined output of all participating processes is strictly increasing, as
+// needed when several workers append to one log.
+//
+// The file is created if missing. Every generation costs a lock, a read and a
+// write on the file (a few microseconds), so prefer a plain Generator unless
+// cross-process ordering is required. Call Close to release the file.
+// Returns ErrNotSupported on platforms without flock(2).
+func NewSharedGenerator(path string, opts ...Option) (*Generator, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFileLock(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	g := NewGenerator(opts...)
+	g.shared = &sharedState{f: f}
+	return g, nil
+}
+
+// Close releases resources held by the generator, such as the state file of
+// a shared generator. It is a no-op for other generators.
+func (g *Generator) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.shared == nil {
+		return nil
+	}
+	err := g.shared.f.Close()
+	g.shared = nil
+	return err
+}
+
+// load reads the last timestamp and counter; an empty file reads as zero
+func (s *sharedState) load() (uint64, uint16, error) {
+	var buf [sharedStateSize]byte
+	n, err := s.f.ReadAt(buf[:], 0)
+	if n < sharedStateSize {
+		if n == 0 && err == io.EOF {
+			return 0, 0, nil // empty file: no IDs issued yet
+		}
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint64(buf[0:8]), binary.BigEndian.Uint16(buf[8:10]), nil
+}
+
+// store writes the last timestamp and counter
+func (s *sharedState) store(timestamp uint64, counter uint16) error {
+	var buf [sharedStateSize]byte
+	binary.BigEndian.PutUint64(buf[0:8], timestamp)
+	binary.BigEndian.PutUint16(buf[8:10], counter)
+	_, err := s.f.WriteAt(buf[:], 0)
+	return err
+}
@@ -0,0 +1,22 @@
+//go:build !(darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd)
+
+package guuid
+
+import (
+	"os"
+)
+
+// checkFileLock reports that advisory locking is unavailable on this platform
+func checkFileLock(*os.File) error {
+	return ErrNotSupported
+}
+
+// lockFile is never reached because NewSharedGenerator fails first
+func lockFile(*os.File) error {
+	return ErrNotSupported
+}
+
+// unlockFile is never reached because NewSharedGenerator fails first
+func unlockFile(*os.File) error {
+	return ErrNotSupported
+}
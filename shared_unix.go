@@ -0,0 +1,31 @@
+//go:build darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd
+
+package guuid
+
+import (
+	"os"
+	"syscall"
+)
+
+// checkFileLock reports whether f supports advisory locking
+func checkFileLock(f *os.File) error {
+	if err := lockFile(f); err != nil {
+		return err
+	}
+	return unlockFile(f)
+}
+
+// lockFile takes an exclusive advisory lock on f, blocking until available
+func lockFile(f *os.File) error {
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// unlockFile releases the advisory lock on f
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
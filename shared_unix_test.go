@@ -0,0 +1,145 @@
+//go:build darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd
+
+package guuid
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharedGenerator_InterleavedMonotonic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "guuid.state")
+	a, err := NewSharedGenerator(path)
+	if err != nil {
+		t.Fatalf("NewSharedGenerator() error = %v", err)
+	}
+	defer a.Close()
+	b, err := NewSharedGenerator(path)
+	if err != nil {
+		t.Fatalf("NewSharedGenerator() error = %v", err)
+	}
+	defer b.Close()
+
+	now := time.Now()
+	var prev UUID
+	for i := 0; i < 1000; i++ {
+		gen, ts := a, now
+		if i%2 == 1 {
+			// b's clock lags behind a's, as on a host with skewed workers
+			gen, ts = b, now.Add(-time.Second)
+		}
+		uuid := Must(gen.NewWithTime(ts))
+		if i > 0 && uuid.Compare(prev) <= 0 {
+			t.Fatalf("shared generators not monotonic at %d: %v <= %v", i, uuid, prev)
+		}
+		prev = uuid
+	}
+}
+
+func TestSharedGenerator_ConcurrentUnique(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "guuid.state")
+	gens := make([]*Generator, 4)
+	for i := range gens {
+		gen, err := NewSharedGenerator(path)
+		if err != nil {
+			t.Fatalf("NewSharedGenerator() error = %v", err)
+		}
+		defer gen.Close()
+		gens[i] = gen
+	}
+
+	var mu sync.Mutex
+	seen := make(map[UUID]bool)
+	var wg sync.WaitGroup
+	for _, gen := range gens {
+		wg.Add(1)
+		go func(gen *Generator) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				uuid, err := gen.New()
+				if err != nil {
+					t.Errorf("New() error = %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[uuid] {
+					t.Errorf("duplicate UUID across shared generators: %v", uuid)
+				}
+				seen[uuid] = true
+				mu.Unlock()
+			}
+		}(gen)
+	}
+	wg.Wait()
+}
+
+func TestSharedGenerator_StatePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "guuid.state")
+	future := time.Now().Add(time.Hour)
+
+	first, err := NewSharedGenerator(path)
+	if err != nil {
+		t.Fatalf("NewSharedGenerator() error = %v", err)
+	}
+	issued := Must(first.NewWithTime(future))
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	second, err := NewSharedGenerator(path)
+	if err != nil {
+		t.Fatalf("NewSharedGenerator() error = %v", err)
+	}
+	defer second.Close()
+	if next := Must(second.New()); next.Compare(issued) <= 0 {
+		t.Errorf("reopened shared generator issued %v, not after %v", next, issued)
+	}
+}
+
+func TestSharedState_LoadError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "guuid.state")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s := &sharedState{f: f}
+	if _, _, err := s.load(); err == nil {
+		t.Error("load() on an unreadable file returned no error, want the read error")
+	}
+}
+
+func TestSharedState_LoadEmpty(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "guuid.state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if ts, seq, err := (&sharedState{f: f}).load(); err != nil || ts != 0 || seq != 0 {
+		t.Errorf("load() on an empty file = %d, %d, %v, want 0, 0, nil", ts, seq, err)
+	}
+}
+
+func TestSharedGenerator_RestoreLoadError(t *testing.T) {
+	gen, err := NewSharedGenerator(filepath.Join(t.TempDir(), "guuid.state"))
+	if err != nil {
+		t.Fatalf("NewSharedGenerator() error = %v", err)
+	}
+	gen.shared.f.Close() // make every state file access fail
+
+	s := Snapshot{LastTimestamp: time.Now().UnixMilli(), Config: Config{TimestampDither: time.Second}}
+	if err := gen.Restore(s); err == nil {
+		t.Fatal("Restore() with unreadable state file error = nil")
+	}
+	if cfg := gen.Config(); cfg.TimestampDither != 0 {
+		t.Errorf("Restore() failure applied settings %+v", cfg)
+	}
+	if gen.lastTimestamp != 0 {
+		t.Errorf("Restore() failure moved lastTimestamp to %d", gen.lastTimestamp)
+	}
+}
@@ -0,0 +1,25 @@
+package guuid
+
+import (
+	"encoding/binary"
+)
+
+// Short64 returns the top 64 bits of a UUIDv7: the 48-bit millisecond
+// timestamp, the version nibble and the 12-bit rand_a counter. The value is
+// positive as an int64 and sorts in the same order as the UUIDs, for export to
+// systems that only handle 64-bit keys.
+//
+// Short64 is lossy. IDs from a single Generator map to distinct values, since
+// the timestamp and counter pair strictly increases. IDs from independent
+// generators collide whenever they share a millisecond and their 12-bit
+// counters coincide, roughly a 1 in 4096 chance per pair in the same
+// millisecond, so cross-node exports should key on the full UUID instead.
+func (u UUID) Short64() uint64 {
+	return binary.BigEndian.Uint64(u[0:8])
+}
+
+// VerifyShort64 reports whether s is the Short64 value of u, e.g. for checking
+// that a 64-bit key from a legacy system refers to the given UUID
+func VerifyShort64(u UUID, s uint64) bool {
+	return u.Version() == VersionTimeSorted && u.Short64() == s
+}
@@ -0,0 +1,38 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUUID_Short64(t *testing.T) {
+	uuid := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	if got, want := uuid.Short64(), uint64(0x01890a5dac96774b); got != want {
+		t.Errorf("Short64() = %#x, want %#x", got, want)
+	}
+	if !VerifyShort64(uuid, 0x01890a5dac96774b) {
+		t.Error("VerifyShort64() = false for matching value")
+	}
+	if VerifyShort64(uuid, 0x01890a5dac96774c) {
+		t.Error("VerifyShort64() = true for different value")
+	}
+	if VerifyShort64(MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), 0xf47ac10b58cc4372) {
+		t.Error("VerifyShort64() = true for non-v7 UUID")
+	}
+}
+
+func TestUUID_Short64_UniquePerGenerator(t *testing.T) {
+	gen := NewGenerator()
+	now := time.Now()
+	var prev uint64
+	for i := 0; i < 5000; i++ {
+		s := Must(gen.NewWithTime(now)).Short64()
+		if int64(s) < 0 {
+			t.Fatalf("Short64() = %#x is negative as int64", s)
+		}
+		if i > 0 && s <= prev {
+			t.Fatalf("Short64() not strictly increasing at %d: %#x <= %#x", i, s, prev)
+		}
+		prev = s
+	}
+}
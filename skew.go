@@ -0,0 +1,232 @@
+package guuid
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReferenceClock is a trusted time source, such as an NTP or Roughtime
+// server, that WithSkewGuard compares the local clock against. Now returns
+// the reference time at the moment it returns.
+type ReferenceClock interface {
+	Now(ctx context.Context) (time.Time, error)
+}
+
+// ReferenceClockFunc adapts a function to the ReferenceClock interface
+type ReferenceClockFunc func(ctx context.Context) (time.Time, error)
+
+// Now implements ReferenceClock
+func (f ReferenceClockFunc) Now(ctx context.Context) (time.Time, error) {
+	return f(ctx)
+}
+
+// SkewAction selects what a generator does when its clock deviates from the
+// reference by more than the WithSkewGuard threshold
+type SkewAction int
+
+const (
+	// SkewWarn reports the skew to WithOnSkew hooks and keeps generating
+	SkewWarn SkewAction = iota
+
+	// SkewRefuse also fails generation with ErrClockSkew until a later check
+	// finds the clock back within the threshold
+	SkewRefuse
+)
+
+// DefaultSkewCheckInterval is how often WithSkewGuard consults the reference
+// clock when no interval is given
+const DefaultSkewCheckInterval = time.Minute
+
+// skewCheckTimeout bounds a single query of the reference clock
+const skewCheckTimeout = 5 * time.Second
+
+// skewGuard is the state of WithSkewGuard
+type skewGuard struct {
+	ref       ReferenceClock
+	threshold time.Duration
+	interval  time.Duration
+	action    SkewAction
+
+	checking atomic.Bool // a check is in flight
+
+	mu   sync.Mutex
+	next time.Time     // when the next check is due
+	skew time.Duration // last measured local minus reference time
+}
+
+// WithSkewGuard makes the generator compare the local clock with ref every
+// interval (DefaultSkewCheckInterval if interval is zero or less) and act on
+// deviations beyond threshold. It complements the generator's handling of
+// clocks stepping backwards, which keeps IDs ordered but cannot tell a clock
+// that is simply wrong.
+//
+// Generation never waits for the reference: the first call that finds a check
+// due starts it in a background goroutine, and callers act on the last result
+// meanwhile, so a SkewRefuse generator refuses only once a check has found
+// the skew. If ref fails, the error is reported to WithOnSkew hooks and the
+// last measurement stays in effect, so a SkewRefuse generator keeps refusing
+// until ref recovers and agrees again.
+func WithSkewGuard(ref ReferenceClock, threshold, interval time.Duration, action SkewAction) Option {
+	if interval <= 0 {
+		interval = DefaultSkewCheckInterval
+	}
+	return func(g *Generator) {
+		g.skew = &skewGuard{ref: ref, threshold: threshold, interval: interval, action: action}
+	}
+}
+
+// WithOnSkew registers a function called after a skew check that found the
+// local clock more than the WithSkewGuard threshold off (err nil), or that
+// failed to reach the reference clock. skew is local minus reference time.
+// fn runs outside the generator lock, on the background goroutine of a
+// scheduled check or on the goroutine calling CheckSkew.
+func WithOnSkew(fn func(skew time.Duration, err error)) Option {
+	return func(g *Generator) {
+		g.onSkew = append(g.onSkew, fn)
+	}
+}
+
+// CheckSkew queries the WithSkewGuard reference clock now, updates the
+// generator's view of its skew and returns it. It returns ErrNotSupported if
+// the generator has no skew guard.
+func (g *Generator) CheckSkew(ctx context.Context) (time.Duration, error) {
+	if g.skew == nil {
+		return 0, ErrNotSupported
+	}
+	return g.measureSkew(ctx)
+}
+
+// guardSkew starts a due skew check in the background and returns
+// ErrClockSkew if the last result makes the generator refuse to generate
+func (g *Generator) guardSkew() error {
+	s := g.skew
+	s.mu.Lock()
+	due := !time.Now().Before(s.next)
+	s.mu.Unlock()
+	if due && s.checking.CompareAndSwap(false, true) {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), skewCheckTimeout)
+			_, _ = g.measureSkew(ctx)
+			cancel()
+			s.checking.Store(false)
+		}()
+	}
+
+	if s.action != SkewRefuse {
+		return nil
+	}
+	s.mu.Lock()
+	skew := s.skew
+	s.mu.Unlock()
+	if abs(skew) > s.threshold {
+		return fmt.Errorf("%w: local clock is %v off the reference", ErrClockSkew, skew)
+	}
+	return nil
+}
+
+// measureSkew queries the reference clock, records the result and reports
+// it to WithOnSkew hooks
+func (g *Generator) measureSkew(ctx context.Context) (time.Duration, error) {
+	s := g.skew
+	ref, err := s.ref.Now(ctx)
+	local := time.Now()
+	skew := local.Sub(ref)
+
+	s.mu.Lock()
+	s.next = local.Add(s.interval)
+	if err == nil {
+		s.skew = skew
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		skew = 0
+	}
+	if err != nil || abs(skew) > s.threshold {
+		for _, fn := range g.onSkew {
+			fn(skew, err)
+		}
+	}
+	return skew, err
+}
+
+// abs returns the absolute value of d
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// ntpEpoch is the NTP era 0 epoch, 1900-01-01 UTC, in Unix seconds
+const ntpEpoch = -2208988800
+
+// errNTPResponse is returned by NTPClock for a malformed or rejected reply
+var errNTPResponse = errors.New("guuid: invalid NTP response")
+
+// NTPClock returns a ReferenceClock that queries the NTP server at addr
+// ("host" or "host:port") with a single SNTP (RFC 4330) request, correcting
+// for network delay
+func NTPClock(addr string) ReferenceClock {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "123")
+	}
+	return ReferenceClockFunc(func(ctx context.Context) (time.Time, error) {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "udp", addr)
+		if err != nil {
+			return time.Time{}, err
+		}
+		defer conn.Close()
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+
+		var req, resp [48]byte
+		req[0] = 0x23 // LI 0, version 4, mode 3 (client)
+		t1 := time.Now()
+		binary.BigEndian.PutUint64(req[40:], toNTP(t1))
+		if _, err := conn.Write(req[:]); err != nil {
+			return time.Time{}, err
+		}
+		n, err := conn.Read(resp[:])
+		t4 := time.Now()
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		switch {
+		case n < len(resp), resp[0]&0x07 != 4, resp[1] == 0,
+			binary.BigEndian.Uint64(resp[24:]) != binary.BigEndian.Uint64(req[40:]):
+			return time.Time{}, errNTPResponse
+		}
+		t2 := fromNTP(binary.BigEndian.Uint64(resp[32:]))
+		t3 := fromNTP(binary.BigEndian.Uint64(resp[40:]))
+		delay := t4.Sub(t1) - t3.Sub(t2)
+		return t3.Add(delay/2 + time.Since(t4)), nil
+	})
+}
+
+// toNTP encodes t as a 64-bit NTP timestamp
+func toNTP(t time.Time) uint64 {
+	sec := uint64(t.Unix() - ntpEpoch)
+	frac := uint64(t.Nanosecond()) << 32 / 1e9
+	return sec<<32 | frac
+}
+
+// fromNTP decodes a 64-bit NTP timestamp. Seconds with the high bit clear
+// are taken to be in era 1, which starts in 2036, as RFC 4330 suggests.
+func fromNTP(ts uint64) time.Time {
+	sec := int64(ts >> 32)
+	if sec < 1<<31 {
+		sec += 1 << 32
+	}
+	nsec := int64((ts & 0xFFFFFFFF) * 1e9 >> 32)
+	return time.Unix(sec+ntpEpoch, nsec)
+}
@@ -0,0 +1,186 @@
+package guuid
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// offsetClock is a ReferenceClock running offset ahead of the local clock
+type offsetClock struct {
+	offset atomic.Int64
+	calls  atomic.Int32
+	err    error
+}
+
+func (c *offsetClock) Now(context.Context) (time.Time, error) {
+	c.calls.Add(1)
+	return time.Now().Add(time.Duration(c.offset.Load())), c.err
+}
+
+// waitSkewCheck waits for the skew check started by a generation call
+func waitSkewCheck(t *testing.T, g *Generator) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for g.skew.checking.Load() {
+		if time.Now().After(deadline) {
+			t.Fatal("skew check did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSkewGuard_Refuse(t *testing.T) {
+	ref := &offsetClock{}
+	ref.offset.Store(int64(time.Hour))
+	var reported time.Duration
+	gen := NewGenerator(
+		WithSkewGuard(ref, time.Second, time.Hour, SkewRefuse),
+		WithOnSkew(func(skew time.Duration, err error) { reported = skew }),
+	)
+
+	// The first call starts the check without waiting for it
+	if _, err := gen.New(); err != nil {
+		t.Fatalf("New() before the first check error = %v", err)
+	}
+	waitSkewCheck(t, gen)
+	if _, err := gen.New(); !errors.Is(err, ErrClockSkew) {
+		t.Fatalf("New() error = %v, want ErrClockSkew", err)
+	}
+	if reported > -time.Hour+time.Second || reported < -time.Hour-time.Second {
+		t.Errorf("reported skew = %v, want about -1h", reported)
+	}
+	if s := gen.Stats(); s.Errors != 1 || s.Generated != 1 {
+		t.Errorf("Stats() = %+v, want 1 generated and 1 error", s)
+	}
+
+	// The cached result holds until the next check
+	ref.offset.Store(0)
+	if _, err := gen.New(); !errors.Is(err, ErrClockSkew) {
+		t.Errorf("New() before next check error = %v, want ErrClockSkew", err)
+	}
+	if ref.calls.Load() != 1 {
+		t.Errorf("reference queried %d times, want 1", ref.calls.Load())
+	}
+
+	if skew, err := gen.CheckSkew(context.Background()); err != nil || abs(skew) > time.Second {
+		t.Fatalf("CheckSkew() = %v, %v, want about 0", skew, err)
+	}
+	if _, err := gen.New(); err != nil {
+		t.Errorf("New() after recovery error = %v", err)
+	}
+}
+
+func TestSkewGuard_Warn(t *testing.T) {
+	ref := &offsetClock{}
+	ref.offset.Store(int64(-time.Minute))
+	var warnings int
+	gen := NewGenerator(
+		WithSkewGuard(ref, time.Second, 0, SkewWarn),
+		WithOnSkew(func(time.Duration, error) { warnings++ }),
+	)
+	for i := 0; i < 10; i++ {
+		if _, err := gen.New(); err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+	}
+	waitSkewCheck(t, gen)
+	if warnings != 1 || ref.calls.Load() != 1 {
+		t.Errorf("warnings = %d, calls = %d, want 1 each within DefaultSkewCheckInterval", warnings, ref.calls.Load())
+	}
+}
+
+func TestSkewGuard_ReferenceError(t *testing.T) {
+	unreachable := errors.New("unreachable")
+	ref := &offsetClock{err: unreachable}
+	var got error
+	gen := NewGenerator(
+		WithSkewGuard(ref, time.Second, time.Hour, SkewRefuse),
+		WithOnSkew(func(_ time.Duration, err error) { got = err }),
+	)
+	if _, err := gen.New(); err != nil {
+		t.Errorf("New() with unreachable reference error = %v, want nil", err)
+	}
+	waitSkewCheck(t, gen)
+	if !errors.Is(got, unreachable) {
+		t.Errorf("WithOnSkew error = %v, want %v", got, unreachable)
+	}
+}
+
+func TestSkewGuard_SlowReference(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ref := ReferenceClockFunc(func(ctx context.Context) (time.Time, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return time.Now(), nil
+	})
+	gen := NewGenerator(WithSkewGuard(ref, time.Second, 0, SkewRefuse))
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if _, err := gen.New(); err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("New() waited %v for the reference clock", elapsed)
+	}
+}
+
+func TestCheckSkew_NoGuard(t *testing.T) {
+	if _, err := NewGenerator().CheckSkew(context.Background()); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("CheckSkew() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestNTPTimestamp(t *testing.T) {
+	for _, at := range []time.Time{
+		time.Date(2026, 10, 16, 12, 0, 0, 500_000_000, time.UTC),
+		time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if got := fromNTP(toNTP(at)); got.Sub(at).Abs() > time.Microsecond {
+			t.Errorf("fromNTP(toNTP(%v)) = %v", at, got)
+		}
+	}
+}
+
+func TestNTPClock(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP: %v", err)
+	}
+	defer conn.Close()
+	const offset = 3 * time.Second
+	go func() {
+		var buf [48]byte
+		for {
+			_, addr, err := conn.ReadFrom(buf[:])
+			if err != nil {
+				return
+			}
+			now := toNTP(time.Now().Add(offset))
+			resp := [48]byte{0: 0x24, 1: 2}
+			copy(resp[24:32], buf[40:48])
+			binary.BigEndian.PutUint64(resp[32:], now)
+			binary.BigEndian.PutUint64(resp[40:], now)
+			_, _ = conn.WriteTo(resp[:], addr)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ref, err := NTPClock(conn.LocalAddr().String()).Now(ctx)
+	if err != nil {
+		t.Fatalf("NTPClock.Now() error = %v", err)
+	}
+	if d := ref.Sub(time.Now()) - offset; d.Abs() > 100*time.Millisecond {
+		t.Errorf("NTPClock.Now() off by %v from the server's clock", d)
+	}
+}
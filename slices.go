@@ -0,0 +1,69 @@
+package guuid
+
+// smallSliceLen is the size below which Dedupe and Diff compare directly
+// instead of building a hash set
+const smallSliceLen = 16
+
+// Index returns the index of the first occurrence of u in ids, or -1
+func Index(ids []UUID, u UUID) int {
+	for i := range ids {
+		if ids[i] == u {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains reports whether u is present in ids
+func Contains(ids []UUID, u UUID) bool {
+	return Index(ids, u) >= 0
+}
+
+// Dedupe removes repeated UUIDs from ids, keeping the first occurrence of
+// each and preserving order. Like slices.Compact it works in place and returns
+// the shortened slice; the elements past the new length are zeroed.
+func Dedupe(ids []UUID) []UUID {
+	var seen *UUIDSet
+	if len(ids) > smallSliceLen {
+		seen = NewUUIDSet(len(ids))
+	}
+
+	out := ids[:0]
+	for _, u := range ids {
+		if seen != nil {
+			if !seen.Add(u) {
+				continue
+			}
+		} else if Contains(out, u) {
+			continue
+		}
+		out = append(out, u)
+	}
+	clear(ids[len(out):])
+	return out
+}
+
+// Diff returns the UUIDs of a that are not in b, in the order they appear in a.
+// Duplicates in a are kept.
+func Diff(a, b []UUID) []UUID {
+	var out []UUID
+	if len(b) <= smallSliceLen {
+		for _, u := range a {
+			if !Contains(b, u) {
+				out = append(out, u)
+			}
+		}
+		return out
+	}
+
+	exclude := NewUUIDSet(len(b))
+	for _, u := range b {
+		exclude.Add(u)
+	}
+	for _, u := range a {
+		if !exclude.Contains(u) {
+			out = append(out, u)
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package guuid
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func testIDs(n int) []UUID {
+	gen := NewGenerator()
+	ids := make([]UUID, n)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+	}
+	return ids
+}
+
+func TestIndexContains(t *testing.T) {
+	ids := testIDs(5)
+	ids = append(ids, ids[2])
+
+	if got := Index(ids, ids[2]); got != 2 {
+		t.Errorf("Index() = %d, want 2", got)
+	}
+	if got := Index(ids, Nil); got != -1 {
+		t.Errorf("Index(Nil) = %d, want -1", got)
+	}
+	if !Contains(ids, ids[4]) || Contains(nil, ids[0]) {
+		t.Error("Contains() returned wrong result")
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	for _, n := range []int{3, smallSliceLen * 4} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			unique := testIDs(n)
+			input := append(slices.Clone(unique), unique...)
+			input = append(input, Nil, unique[0], Nil)
+			want := append(slices.Clone(unique), Nil)
+
+			got := Dedupe(input)
+			if !slices.Equal(got, want) {
+				t.Errorf("Dedupe() = %v, want %v", got, want)
+			}
+			for _, u := range input[len(got):] {
+				if u != Nil {
+					t.Fatal("Dedupe() did not zero the tail")
+				}
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for _, n := range []int{4, smallSliceLen * 4} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			a := testIDs(n)
+			a = append(a, a[1])
+			b := append(slices.Clone(a[:n/2]), testIDs(3)...)
+
+			got := Diff(a, b)
+			want := a[n/2 : n]
+			if !slices.Equal(got, want) {
+				t.Errorf("Diff() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	if got := Diff(nil, testIDs(2)); got != nil {
+		t.Errorf("Diff(nil, b) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,36 @@
+package guuid
+
+import "time"
+
+// smearSeqMax is the largest value of the 14-bit counter extension used by
+// WithSmearWindow
+const smearSeqMax = 0x3FFF
+
+// WithSmearWindow makes the generator absorb backward clock steps of up to
+// window without advancing the embedded timestamp, for hosts whose clocks
+// routinely step back a little, such as during a leap second or under NTP
+// corrections.
+//
+// By default a clock that reads earlier than the last ID is clamped to the
+// last timestamp and ordered by the 12-bit counter. Once the counter runs
+// out, the generator borrows the next millisecond, so a busy generator's
+// embedded time drifts ahead of the clock. With a smear window, while the
+// clock is at most window behind the last timestamp, an exhausted counter
+// continues into the top 14 bits of rand_b instead, allowing 16384 more IDs
+// in the same millisecond before it borrows. Those IDs have 48 rather than
+// 62 random bits. Steps back of more than window are treated as usual.
+//
+// The extension is not recorded in shared state, so the option has no effect
+// on generators created with NewSharedGenerator. Windows shorter than a
+// millisecond disable it.
+func WithSmearWindow(window time.Duration) Option {
+	return func(g *Generator) {
+		g.smearMs = window.Milliseconds()
+	}
+}
+
+// smearing reports whether the WithSmearWindow extension applies when the
+// clock reads timestamp. It must be called with g.mu held.
+func (g *Generator) smearing(timestamp uint64) bool {
+	return g.smearMs > 0 && g.shared == nil && g.lastTimestamp-timestamp <= uint64(g.smearMs)
+}
@@ -0,0 +1,80 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSmearWindow_NoDrift(t *testing.T) {
+	tests := []struct {
+		name      string
+		step      time.Duration
+		wantDrift bool
+	}{
+		{"same millisecond", 0, false},
+		{"step within window", 500 * time.Millisecond, false},
+		{"step beyond window", 2 * time.Second, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGenerator(WithSmearWindow(time.Second))
+			at := time.UnixMilli(1700000000000)
+			prev := Must(gen.NewWithTime(at))
+
+			// Enough IDs to exhaust the 12-bit counter whatever its random start
+			drifted := false
+			for i := 0; i < 8192; i++ {
+				u := Must(gen.NewWithTime(at.Add(-tt.step)))
+				if u.Compare(prev) <= 0 {
+					t.Fatalf("ID #%d = %v, not after %v", i, u, prev)
+				}
+				if u.Version() != VersionTimeSorted || u.Variant() != VariantRFC4122 {
+					t.Fatalf("ID #%d = %v is not a valid UUIDv7", i, u)
+				}
+				drifted = drifted || u.Timestamp() != at.UnixMilli()
+				prev = u
+			}
+			if drifted != tt.wantDrift {
+				t.Errorf("embedded timestamp drifted = %v, want %v", drifted, tt.wantDrift)
+			}
+		})
+	}
+}
+
+func TestSmearWindow_ExtensionExhausted(t *testing.T) {
+	gen := NewGenerator(WithSmearWindow(time.Second))
+	at := time.UnixMilli(1700000000000)
+	prev := Must(gen.NewWithTime(at))
+	for i := 0; i < 0x1000+smearSeqMax+10; i++ {
+		u := Must(gen.NewWithTime(at))
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("ID #%d = %v, not after %v", i, u, prev)
+		}
+		prev = u
+	}
+	if prev.Timestamp() != at.UnixMilli()+1 {
+		t.Errorf("Timestamp() after exhausting the extension = %d, want %d", prev.Timestamp(), at.UnixMilli()+1)
+	}
+	if s := gen.Stats(); s.Overflows != 1 {
+		t.Errorf("Stats().Overflows = %d, want 1", s.Overflows)
+	}
+}
+
+func TestSmearWindow_ReloadMidCounter(t *testing.T) {
+	gen := NewGenerator()
+	at := time.UnixMilli(1700000000000)
+	prev := Must(gen.NewWithTime(at))
+	for prev.Timestamp() == at.UnixMilli() && gen.clockSeq != 0xFFF {
+		prev = Must(gen.NewWithTime(at))
+	}
+	if err := gen.Reload(Config{SmearWindow: time.Second}); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		u := Must(gen.NewWithTime(at))
+		if u.Compare(prev) <= 0 {
+			t.Fatalf("ID #%d after enabling the smear window = %v, not after %v", i, u, prev)
+		}
+		prev = u
+	}
+}
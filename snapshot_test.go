@@ -0,0 +1,81 @@
+package guuid
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerator_SnapshotRestore(t *testing.T) {
+	at := time.UnixMilli(1700000000000)
+	old := NewGenerator(WithRateLimit(1000, 10), WithOverflowSleep(time.Millisecond))
+	var last UUID
+	for i := 0; i < 100; i++ {
+		last = Must(old.NewWithTime(at))
+	}
+
+	snap, err := old.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if snap.LastTimestamp != at.UnixMilli() {
+		t.Errorf("Snapshot().LastTimestamp = %d, want %d", snap.LastTimestamp, at.UnixMilli())
+	}
+
+	// hand the snapshot over as JSON, as an orchestrator would
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"config":{"timestamp_dither":0,`) || !strings.Contains(string(data), `"rate_limit":1000,"rate_burst":10`) {
+		t.Errorf("json.Marshal(snapshot) = %s, want snake_case config keys", data)
+	}
+	var got Snapshot
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	next := NewGenerator()
+	if err := next.Restore(got); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if next.Config() != old.Config() {
+		t.Errorf("Config() after Restore() = %+v, want %+v", next.Config(), old.Config())
+	}
+	// even with a clock behind the old process, IDs continue after it
+	if u := Must(next.NewWithTime(at.Add(-time.Second))); u.Compare(last) <= 0 {
+		t.Errorf("NewWithTime() after Restore() = %v, not after %v", u, last)
+	}
+}
+
+func TestGenerator_RestoreOnlyForward(t *testing.T) {
+	gen := NewGenerator()
+	at := time.UnixMilli(1700000000000)
+	Must(gen.NewWithTime(at))
+	before, _ := gen.Snapshot()
+
+	stale := Snapshot{LastTimestamp: at.UnixMilli() - 10, Counter: 5}
+	if err := gen.Restore(stale); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if after, _ := gen.Snapshot(); after.LastTimestamp != before.LastTimestamp || after.Counter != before.Counter {
+		t.Errorf("Restore(stale) moved state from %+v to %+v", before, after)
+	}
+}
+
+func TestGenerator_RestoreInvalid(t *testing.T) {
+	gen := NewGenerator()
+	tests := []Snapshot{
+		{LastTimestamp: -1},
+		{LastTimestamp: 1 << 48},
+		{Counter: 0x1000},
+		{Config: Config{RateLimit: -1}},
+	}
+	for _, s := range tests {
+		if err := gen.Restore(s); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("Restore(%+v) error = %v, want ErrInvalidConfig", s, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"slices"
+	"time"
+)
+
+// SortedUUIDs is an ordered set of UUIDs kept in ascending byte order, which
+// for UUIDv7 is creation order. Lookups use binary search, and whole sets
+// merge in linear time, which suits compaction jobs keyed by v7 IDs.
+//
+// SortedUUIDs is not safe for concurrent use.
+type SortedUUIDs struct {
+	ids []UUID
+}
+
+// NewSortedUUIDs returns a set holding ids, sorted and with duplicates removed.
+// The input slice is not modified.
+func NewSortedUUIDs(ids []UUID) *SortedUUIDs {
+	s := &SortedUUIDs{ids: slices.Clone(ids)}
+	slices.SortFunc(s.ids, UUID.Compare)
+	s.ids = slices.Compact(s.ids)
+	return s
+}
+
+// Len returns the number of UUIDs in the set
+func (s *SortedUUIDs) Len() int {
+	return len(s.ids)
+}
+
+// At returns the i'th smallest UUID
+func (s *SortedUUIDs) At(i int) UUID {
+	return s.ids[i]
+}
+
+// Slice returns the UUIDs in ascending order. The slice shares storage with
+// the set and must not be modified.
+func (s *SortedUUIDs) Slice() []UUID {
+	return s.ids
+}
+
+// Search returns the position of u in the set, or the position where it would
+// be inserted, and whether it is present
+func (s *SortedUUIDs) Search(u UUID) (int, bool) {
+	return slices.BinarySearchFunc(s.ids, u, UUID.Compare)
+}
+
+// Contains reports whether u is in the set
+func (s *SortedUUIDs) Contains(u UUID) bool {
+	_, found := s.Search(u)
+	return found
+}
+
+// Insert adds u, keeping the set ordered, and reports whether it was added.
+// Appending IDs newer than every existing one, the common case for v7, takes
+// amortized constant time.
+func (s *SortedUUIDs) Insert(u UUID) bool {
+	if n := len(s.ids); n == 0 || s.ids[n-1].Compare(u) < 0 {
+		s.ids = append(s.ids, u)
+		return true
+	}
+	i, found := s.Search(u)
+	if found {
+		return false
+	}
+	s.ids = slices.Insert(s.ids, i, u)
+	return true
+}
+
+// Merge returns a new set holding the UUIDs of both s and other
+func (s *SortedUUIDs) Merge(other *SortedUUIDs) *SortedUUIDs {
+	a, b := s.ids, other.ids
+	out := make([]UUID, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch c := a[0].Compare(b[0]); {
+		case c < 0:
+			out, a = append(out, a[0]), a[1:]
+		case c > 0:
+			out, b = append(out, b[0]), b[1:]
+		default:
+			out, a, b = append(out, a[0]), a[1:], b[1:]
+		}
+	}
+	out = append(out, a...)
+	out = append(out, b...)
+	return &SortedUUIDs{ids: out}
+}
+
+// Range returns the UUIDv7s whose embedded time falls in [from, to), at
+// millisecond precision. The result shares storage with the set and must not
+// be modified. Other versions sort by their leading bytes and may be included
+// if those happen to fall in the range.
+func (s *SortedUUIDs) Range(from, to time.Time) []UUID {
+	lo, _ := s.Search(timeBound(from))
+	hi, _ := s.Search(timeBound(to))
+	if hi < lo {
+		return nil
+	}
+	return s.ids[lo:hi]
+}
+
+// timeBound returns the smallest UUID whose 48-bit timestamp field is t in
+// Unix milliseconds, clamped to the representable range
+func timeBound(t time.Time) UUID {
+	ms := t.UnixMilli()
+	ms = min(max(ms, 0), 1<<48-1)
+	var u UUID
+	binary.BigEndian.PutUint64(u[0:8], uint64(ms)<<16)
+	return u
+}
@@ -0,0 +1,126 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortedUUIDs_New(t *testing.T) {
+	a := MustParse("00000000-0000-7000-8000-000000000001")
+	b := MustParse("00000000-0000-7000-8000-000000000002")
+	c := MustParse("00000000-0000-7000-8000-000000000003")
+	input := []UUID{c, a, b, a}
+
+	s := NewSortedUUIDs(input)
+	if s.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", s.Len())
+	}
+	for i, want := range []UUID{a, b, c} {
+		if s.At(i) != want {
+			t.Errorf("At(%d) = %v, want %v", i, s.At(i), want)
+		}
+	}
+	if input[0] != c {
+		t.Error("NewSortedUUIDs() modified its input")
+	}
+}
+
+func TestSortedUUIDs_ContainsInsert(t *testing.T) {
+	gen := NewGenerator()
+	ids := make([]UUID, 100)
+	for i := range ids {
+		ids[i] = Must(gen.New())
+	}
+
+	s := NewSortedUUIDs(nil)
+	// Insert out of order to exercise the binary-search path
+	for i := len(ids) - 1; i >= 0; i -= 2 {
+		if !s.Insert(ids[i]) {
+			t.Fatalf("Insert() = false for new ID")
+		}
+	}
+	for i := 0; i < len(ids); i += 2 {
+		s.Insert(ids[i])
+	}
+	if s.Insert(ids[5]) {
+		t.Error("Insert() = true for duplicate")
+	}
+	if s.Len() != len(ids) {
+		t.Fatalf("Len() = %d, want %d", s.Len(), len(ids))
+	}
+	for i, id := range ids {
+		if s.At(i) != id {
+			t.Fatalf("At(%d) = %v, want %v", i, s.At(i), id)
+		}
+		if !s.Contains(id) {
+			t.Errorf("Contains(%v) = false", id)
+		}
+	}
+	if s.Contains(Must(gen.New())) {
+		t.Error("Contains() = true for absent ID")
+	}
+}
+
+func TestSortedUUIDs_Merge(t *testing.T) {
+	gen := NewGenerator()
+	var even, odd []UUID
+	for i := 0; i < 50; i++ {
+		id := Must(gen.New())
+		if i%2 == 0 {
+			even = append(even, id)
+		} else {
+			odd = append(odd, id)
+		}
+	}
+	shared := even[3]
+	odd = append(odd, shared)
+
+	merged := NewSortedUUIDs(even).Merge(NewSortedUUIDs(odd))
+	if merged.Len() != 50 {
+		t.Fatalf("Merge() Len() = %d, want 50", merged.Len())
+	}
+	for i := 1; i < merged.Len(); i++ {
+		if merged.At(i-1).Compare(merged.At(i)) >= 0 {
+			t.Fatalf("Merge() not strictly ordered at %d", i)
+		}
+	}
+}
+
+func TestSortedUUIDs_Range(t *testing.T) {
+	gen := NewGenerator()
+	base := time.UnixMilli(1700000000000)
+	var ids []UUID
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 3; j++ {
+			ids = append(ids, Must(gen.NewWithTime(base.Add(time.Duration(i)*time.Second))))
+		}
+	}
+	s := NewSortedUUIDs(ids)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     int
+	}{
+		{"all", base, base.Add(10 * time.Second), 30},
+		{"window", base.Add(2 * time.Second), base.Add(5 * time.Second), 9},
+		{"half-open end", base.Add(2 * time.Second), base.Add(2*time.Second + time.Millisecond), 3},
+		{"before", time.Unix(0, 0), base, 0},
+		{"inverted", base.Add(5 * time.Second), base, 0},
+		{"pre-epoch start", time.Unix(-100, 0), base.Add(time.Second), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Range(tt.from, tt.to)
+			if len(got) != tt.want {
+				t.Fatalf("Range() returned %d IDs, want %d", len(got), tt.want)
+			}
+			for _, id := range got {
+				if id.Time().Before(tt.from) || !id.Time().Before(tt.to) {
+					t.Errorf("Range() included %v at %v", id, id.Time())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package guuid
+
+import (
+	"sync"
+	"time"
+)
+
+// Source produces UUIDs. Generator, DedupeGenerator and the package-level New
+// satisfy it (the latter via SourceFunc), so code that only needs fresh IDs can
+// accept a Source and be handed any of them, optionally wrapped in decorators.
+type Source interface {
+	New() (UUID, error)
+}
+
+var (
+	_ Source = (*Generator)(nil)
+	_ Source = (*DedupeGenerator)(nil)
+)
+
+// SourceFunc adapts a function to the Source interface
+type SourceFunc func() (UUID, error)
+
+// New calls f
+func (f SourceFunc) New() (UUID, error) {
+	return f()
+}
+
+// Decorator wraps a Source to add behavior around each call to New
+type Decorator func(Source) Source
+
+// Decorate wraps src in decorators. The first decorator is the outermost, so
+// Decorate(gen, a, b) calls a, then b, then gen.
+func Decorate(src Source, decorators ...Decorator) Source {
+	for i := len(decorators) - 1; i >= 0; i-- {
+		src = decorators[i](src)
+	}
+	return src
+}
+
+// Hooked returns a Decorator calling h after every call to New with its result
+func Hooked(h Hook) Decorator {
+	return func(src Source) Source {
+		return SourceFunc(func() (UUID, error) {
+			uuid, err := src.New()
+			h(uuid, err)
+			return uuid, err
+		})
+	}
+}
+
+// Metered returns a Decorator reporting the latency and error of every call to
+// New to observe, e.g. to feed a histogram and an error counter
+func Metered(observe func(elapsed time.Duration, err error)) Decorator {
+	return func(src Source) Source {
+		return SourceFunc(func() (UUID, error) {
+			start := time.Now()
+			uuid, err := src.New()
+			observe(time.Since(start), err)
+			return uuid, err
+		})
+	}
+}
+
+// RateLimited returns a Decorator allowing at most rate calls per second on
+// average with bursts of up to burst calls. Calls over the limit block until
+// they are allowed. A rate of zero or less disables limiting.
+func RateLimited(rate float64, burst int) Decorator {
+	return func(src Source) Source {
+		if rate <= 0 {
+			return src
+		}
+		l := newTokenBucket(rate, burst)
+		return SourceFunc(func() (UUID, error) {
+			time.Sleep(l.reserve(time.Now()))
+			return src.New()
+		})
+	}
+}
+
+// Deduped returns a Decorator checking every UUID against the last window IDs
+// from the wrapped Source, like DedupeGenerator. onDuplicate is called for
+// every duplicate found; if nil, a duplicate panics.
+func Deduped(window int, onDuplicate func(UUID)) Decorator {
+	return func(src Source) Source {
+		w := newDedupeWindow(window)
+		return SourceFunc(func() (UUID, error) {
+			uuid, err := src.New()
+			if err == nil {
+				w.check(uuid, onDuplicate)
+			}
+			return uuid, err
+		})
+	}
+}
+
+// tokenBucket is a blocking token bucket rate limiter
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	b := float64(max(burst, 1))
+	return &tokenBucket{rate: rate, burst: b, tokens: b}
+}
+
+// reserve takes a token at now and returns how long the caller must wait
+// before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.After(b.last) {
+		if !b.last.IsZero() {
+			b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
@@ -0,0 +1,83 @@
+package guuid
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecorate_Order(t *testing.T) {
+	var calls []string
+	trace := func(name string) Decorator {
+		return func(src Source) Source {
+			return SourceFunc(func() (UUID, error) {
+				calls = append(calls, name)
+				return src.New()
+			})
+		}
+	}
+
+	src := Decorate(NewGenerator(), trace("a"), trace("b"), Hooked(func(UUID, error) {
+		calls = append(calls, "hook")
+	}))
+	Must(src.New())
+
+	if want := []string{"a", "b", "hook"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestMetered(t *testing.T) {
+	var n int
+	var lastErr error
+	src := Decorate(NewGeneratorWithReader(&brokenReader{}), Metered(func(d time.Duration, err error) {
+		n++
+		lastErr = err
+	}))
+	if _, err := src.New(); err == nil {
+		t.Fatal("New() expected error with broken reader")
+	}
+	if n != 1 || lastErr == nil {
+		t.Errorf("observe called %d times with err %v, want 1 call with error", n, lastErr)
+	}
+}
+
+func TestDeduped(t *testing.T) {
+	fixed := Must(Parse("018f3e5a-7b2c-7d4e-8f1a-2b3c4d5e6f70"))
+	var dups int
+	src := Decorate(SourceFunc(func() (UUID, error) { return fixed, nil }), Deduped(8, func(UUID) { dups++ }))
+	for i := 0; i < 3; i++ {
+		Must(src.New())
+	}
+	if dups != 2 {
+		t.Errorf("duplicates = %d, want 2", dups)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(10, 2)
+	now := time.Unix(0, 0)
+
+	tests := []struct {
+		at   time.Duration
+		want time.Duration
+	}{
+		{0, 0},
+		{0, 0},
+		{0, 100 * time.Millisecond},
+		{0, 200 * time.Millisecond},
+		{time.Second, 0},
+	}
+	for i, tt := range tests {
+		if got := b.reserve(now.Add(tt.at)); got != tt.want {
+			t.Errorf("reserve() #%d = %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimited_Disabled(t *testing.T) {
+	gen := NewGenerator()
+	if src := RateLimited(0, 1)(gen); src != Source(gen) {
+		t.Error("RateLimited(0) should return the Source unchanged")
+	}
+}
@@ -0,0 +1,124 @@
+package guuid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// SplitRange returns n-1 split points dividing the inclusive range [start,
+// end] into n parts of equal width in 128-bit arithmetic, in ascending order,
+// so a backfill over a UUID-keyed table can run n balanced range scans:
+// [start, p0), [p0, p1), ..., [pn-2, end]. It returns nil if n < 2 or end is
+// before start. When the range holds fewer than n UUIDs, points repeat.
+//
+// Uniform splits suit random keys such as UUIDv4. Time-clustered UUIDv7 keys
+// occupy a thin, unevenly filled slice of the keyspace; use SplitRangeV7.
+func SplitRange(start, end UUID, n int) []UUID {
+	if n < 2 || end.Compare(start) < 0 {
+		return nil
+	}
+	s, e := toUint128(start), toUint128(end)
+	width := e.sub(s)
+	q, r := width.divmod(uint64(n))
+
+	points := make([]UUID, n-1)
+	for i := 1; i < n; i++ {
+		// start + width*i/n == start + q*i + r*i/n
+		hi, lo := bits.Mul64(r, uint64(i))
+		extra, _ := bits.Div64(hi, lo, uint64(n))
+		points[i-1] = s.add(q.mul(uint64(i))).add(uint128{0, extra}).uuid()
+	}
+	return points
+}
+
+// SplitRangeV7 is SplitRange for UUIDv7 keys. It splits by timestamp first:
+// the milliseconds from the timestamp of start to that of end are divided
+// into n even time slices, with points at millisecond boundaries. When there
+// are fewer milliseconds than parts, it splits by randomness second: each
+// millisecond is divided evenly over its 74 counter and random bits, keeping
+// the version and variant bits every UUIDv7 shares so no part falls where no
+// key can be. Only the timestamps of start and end are used.
+func SplitRangeV7(start, end UUID, n int) []UUID {
+	tsStart, _, _ := start.V7Parts()
+	tsEnd, _, _ := end.V7Parts()
+	if n < 2 || tsEnd < tsStart {
+		return nil
+	}
+	span := uint64(tsEnd-tsStart) + 1
+
+	points := make([]UUID, n-1)
+	if span >= uint64(n) {
+		for i := 1; i < n; i++ {
+			hi, lo := bits.Mul64(span, uint64(i))
+			off, _ := bits.Div64(hi, lo, uint64(n))
+			points[i-1] = v7Point(tsStart+int64(off), 0, 0)
+		}
+		return points
+	}
+
+	// Positions count 2^74 per millisecond: span<<74 fits in 122 bits.
+	total := uint128{span << 10, 0}
+	q, r := total.divmod(uint64(n))
+	for i := 1; i < n; i++ {
+		hi, lo := bits.Mul64(r, uint64(i))
+		extra, _ := bits.Div64(hi, lo, uint64(n))
+		v := q.mul(uint64(i)).add(uint128{0, extra})
+		counter := uint16(v.hi&0x3FF)<<2 | uint16(v.lo>>62)
+		points[i-1] = v7Point(tsStart+int64(v.hi>>10), counter, v.lo&(1<<62-1))
+	}
+	return points
+}
+
+// v7Point returns the UUIDv7 with timestamp ts, a 12-bit counter and 62
+// random bits
+func v7Point(ts int64, counter uint16, rand uint64) UUID {
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], uint64(ts)<<16|0x7000|uint64(counter))
+	binary.BigEndian.PutUint64(u[8:], 1<<63|rand)
+	return u
+}
+
+// uint128 is an unsigned 128-bit integer
+type uint128 struct {
+	hi, lo uint64
+}
+
+// toUint128 reads u as a big-endian 128-bit integer
+func toUint128(u UUID) uint128 {
+	return uint128{binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])}
+}
+
+// uuid returns x as a UUID
+func (x uint128) uuid() UUID {
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], x.hi)
+	binary.BigEndian.PutUint64(u[8:], x.lo)
+	return u
+}
+
+// add returns x+y, wrapping on overflow
+func (x uint128) add(y uint128) uint128 {
+	lo, carry := bits.Add64(x.lo, y.lo, 0)
+	hi, _ := bits.Add64(x.hi, y.hi, carry)
+	return uint128{hi, lo}
+}
+
+// sub returns x-y, wrapping on underflow
+func (x uint128) sub(y uint128) uint128 {
+	lo, borrow := bits.Sub64(x.lo, y.lo, 0)
+	hi, _ := bits.Sub64(x.hi, y.hi, borrow)
+	return uint128{hi, lo}
+}
+
+// mul returns x*y, wrapping on overflow
+func (x uint128) mul(y uint64) uint128 {
+	hi, lo := bits.Mul64(x.lo, y)
+	return uint128{hi + x.hi*y, lo}
+}
+
+// divmod returns x/y and x%y; y must not be zero
+func (x uint128) divmod(y uint64) (uint128, uint64) {
+	qhi, r := bits.Div64(0, x.hi, y)
+	qlo, r := bits.Div64(r, x.lo, y)
+	return uint128{qhi, qlo}, r
+}
@@ -0,0 +1,165 @@
+package guuid
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSplitRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end UUID
+		n          int
+		want       []string
+	}{
+		{
+			name: "whole keyspace in quarters",
+			end:  MaxUUID, n: 4,
+			want: []string{
+				"3fffffff-ffff-ffff-ffff-ffffffffffff",
+				"7fffffff-ffff-ffff-ffff-ffffffffffff",
+				"bfffffff-ffff-ffff-ffff-ffffffffffff",
+			},
+		},
+		{
+			name:  "small range",
+			start: MustParse("00000000-0000-0000-0000-000000000000"),
+			end:   MustParse("00000000-0000-0000-0000-000000000064"), n: 4,
+			want: []string{
+				"00000000-0000-0000-0000-000000000019",
+				"00000000-0000-0000-0000-000000000032",
+				"00000000-0000-0000-0000-00000000004b",
+			},
+		},
+		{
+			name:  "carry into high word",
+			start: MustParse("00000000-0000-0000-ffff-ffffffffff00"),
+			end:   MustParse("00000000-0000-0001-0000-000000000100"), n: 2,
+			want: []string{"00000000-0000-0001-0000-000000000000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitRange(tt.start, tt.end, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SplitRange() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].String() != tt.want[i] {
+					t.Errorf("SplitRange()[%d] = %v, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSplitRange_Balanced(t *testing.T) {
+	start := MustParse("10000000-0000-0000-0000-000000000000")
+	end := MustParse("f0000000-0000-0000-0000-000000000000")
+	points := SplitRange(start, end, 7)
+	if len(points) != 6 || !slices.IsSortedFunc(points, Compare) {
+		t.Fatalf("SplitRange() = %v, want 6 ascending points", points)
+	}
+	bounds := append(append([]UUID{start}, points...), end)
+	w0 := toUint128(bounds[1]).sub(toUint128(bounds[0]))
+	for i := 1; i+1 < len(bounds); i++ {
+		w := toUint128(bounds[i+1]).sub(toUint128(bounds[i]))
+		if d := w.sub(w0); w.hi != w0.hi || (d.lo > 1 && d.lo < ^uint64(0)) {
+			t.Errorf("part %d width %x:%x differs from %x:%x", i, w.hi, w.lo, w0.hi, w0.lo)
+		}
+	}
+}
+
+func TestSplitRange_Invalid(t *testing.T) {
+	a, b := MustParse("10000000-0000-0000-0000-000000000000"), MustParse("20000000-0000-0000-0000-000000000000")
+	if got := SplitRange(a, b, 1); got != nil {
+		t.Errorf("SplitRange(n=1) = %v, want nil", got)
+	}
+	if got := SplitRange(b, a, 4); got != nil {
+		t.Errorf("SplitRange(end < start) = %v, want nil", got)
+	}
+}
+
+func TestSplitRangeV7_TimeSlices(t *testing.T) {
+	start := Must(NewV7FromParts(1000, 0x123, [8]byte{1}))
+	end := Must(NewV7FromParts(1999, 0xFFF, [8]byte{0xFF}))
+
+	points := SplitRangeV7(start, end, 4)
+	want := []int64{1250, 1500, 1750}
+	if len(points) != len(want) {
+		t.Fatalf("SplitRangeV7() = %v, want %d points", points, len(want))
+	}
+	for i, p := range points {
+		ts, counter, rand := p.V7Parts()
+		if ts != want[i] || counter != 0 || rand != [8]byte{} {
+			t.Errorf("point %d = ts %d counter %d rand %x, want ts %d at the millisecond start", i, ts, counter, rand, want[i])
+		}
+		if p.Version() != VersionTimeSorted || p.Variant() != VariantRFC4122 {
+			t.Errorf("point %d = %v, not an RFC UUIDv7", i, p)
+		}
+	}
+}
+
+func TestSplitRangeV7_Randomness(t *testing.T) {
+	start := Must(NewV7FromParts(5000, 0, [8]byte{}))
+	end := Must(NewV7FromParts(5000, 0xFFF, [8]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}))
+
+	points := SplitRangeV7(start, end, 4)
+	if len(points) != 3 || !slices.IsSortedFunc(points, Compare) {
+		t.Fatalf("SplitRangeV7() = %v, want 3 ascending points", points)
+	}
+	wantCounters := []uint16{0x400, 0x800, 0xC00}
+	for i, p := range points {
+		ts, counter, rand := p.V7Parts()
+		if ts != 5000 || counter != wantCounters[i] || rand != [8]byte{} {
+			t.Errorf("point %d = ts %d counter %#x rand %x, want ts 5000 counter %#x", i, ts, counter, rand, wantCounters[i])
+		}
+		if p.Version() != VersionTimeSorted || p.Variant() != VariantRFC4122 {
+			t.Errorf("point %d = %v, not an RFC UUIDv7", i, p)
+		}
+	}
+
+	// Two milliseconds in four parts: the second point is the boundary
+	end = Must(NewV7FromParts(5001, 0, [8]byte{}))
+	points = SplitRangeV7(start, end, 4)
+	if ts, counter, _ := points[1].V7Parts(); ts != 5001 || counter != 0 {
+		t.Errorf("middle point = ts %d counter %#x, want 5001, 0", ts, counter)
+	}
+	if ts, counter, _ := points[0].V7Parts(); ts != 5000 || counter != 0x800 {
+		t.Errorf("first point = ts %d counter %#x, want 5000, 0x800", ts, counter)
+	}
+}
+
+func TestSplitRangeV7_Balanced(t *testing.T) {
+	// IDs spread evenly over one second land evenly in the parts, where a
+	// uniform split of [Nil, MaxUUID] would put them all in one part
+	gen := NewGenerator()
+	var ids []UUID
+	for ms := int64(0); ms < 1000; ms++ {
+		for j := 0; j < 4; j++ {
+			ids = append(ids, Must(gen.NewWithTime(time.UnixMilli(1700000000000+ms))))
+		}
+	}
+	points := SplitRangeV7(ids[0], ids[len(ids)-1], 8)
+	counts := make([]int, len(points)+1)
+	for _, u := range ids {
+		i, _ := slices.BinarySearchFunc(points, u, Compare)
+		counts[i]++
+	}
+	for i, c := range counts {
+		if c != 500 {
+			t.Errorf("part %d holds %d IDs, want 500", i, c)
+		}
+	}
+}
+
+func TestSplitRangeV7_Invalid(t *testing.T) {
+	a, b := Must(NewV7FromParts(2000, 0, [8]byte{})), Must(NewV7FromParts(1000, 0, [8]byte{}))
+	if got := SplitRangeV7(a, b, 4); got != nil {
+		t.Errorf("SplitRangeV7(end < start) = %v, want nil", got)
+	}
+	if got := SplitRangeV7(b, a, 1); got != nil {
+		t.Errorf("SplitRangeV7(n=1) = %v, want nil", got)
+	}
+}
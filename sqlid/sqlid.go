@@ -0,0 +1,149 @@
+// Package sqlid fills zero-valued guuid.UUID fields with fresh UUIDv7s before
+// rows are inserted, replacing `ID: guuid.Must(guuid.New())` boilerplate.
+//
+// Fields opt in with a struct tag:
+//
+//	type Order struct {
+//	    ID    guuid.UUID `db:"id" guuid:"auto"`
+//	    Total int64      `db:"total"`
+//	}
+//
+// With sqlx (or any library with named parameters), pass the row through
+// NamedExec; *sqlx.DB and *sqlx.Tx satisfy NamedExecer:
+//
+//	_, err := sqlid.NamedExec(ctx, db, `INSERT INTO orders (id, total) VALUES (:id, :total)`, &order)
+//
+// With database/sql, pass the ID field by pointer to Exec, which fills it if
+// zero and sends its value:
+//
+//	_, err := sqlid.Exec(ctx, db, `INSERT INTO orders (id, total) VALUES ($1, $2)`, &order.ID, order.Total)
+package sqlid
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+
+	"github.com/Lzww0608/guuid"
+)
+
+// TagValue is the value of the guuid struct tag marking fields to fill
+const TagValue = "auto"
+
+// ErrNotPointer is returned by Fill when the row cannot be modified in place
+var ErrNotPointer = errors.New("sqlid: row must be a pointer to a struct or a slice of structs")
+
+// uuidType is the reflect.Type of guuid.UUID
+var uuidType = reflect.TypeOf(guuid.UUID{})
+
+// Fill sets every zero guuid.UUID field tagged `guuid:"auto"` in v to a new
+// UUIDv7 from the default generator. v is a pointer to a struct, or a slice
+// or pointer to a slice of structs or struct pointers; embedded structs are
+// searched too. Non-zero IDs are left alone.
+func Fill(v any) error {
+	return FillWith(guuid.Default(), v)
+}
+
+// FillWith is Fill drawing IDs from src
+func FillWith(src guuid.Source, v any) error {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Slice:
+	case rv.Kind() == reflect.Pointer && !rv.IsNil():
+		rv = rv.Elem()
+	default:
+		return ErrNotPointer
+	}
+	return fill(src, rv)
+}
+
+// fill walks rv, which must be addressable unless it is a slice
+func fill(src guuid.Source, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return fill(src, rv.Elem())
+	case reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			if err := fill(src, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		if !rv.CanSet() {
+			return ErrNotPointer
+		}
+		return fillStruct(src, rv)
+	}
+	return ErrNotPointer
+}
+
+// fillStruct fills the tagged fields of the struct rv
+func fillStruct(src guuid.Source, rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := rv.Field(i)
+		switch {
+		case f.Anonymous && f.Type.Kind() == reflect.Struct:
+			if err := fillStruct(src, fv); err != nil {
+				return err
+			}
+		case f.Type == uuidType && f.Tag.Get("guuid") == TagValue && f.IsExported():
+			if !fv.IsZero() {
+				continue
+			}
+			id, err := src.New()
+			if err != nil {
+				return err
+			}
+			fv.Set(reflect.ValueOf(id))
+		}
+	}
+	return nil
+}
+
+// NamedExecer runs a statement with named parameters bound from a struct,
+// as *sqlx.DB and *sqlx.Tx do
+type NamedExecer interface {
+	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
+}
+
+// NamedExec fills the IDs of arg, as Fill does, then executes query with it
+func NamedExec(ctx context.Context, db NamedExecer, query string, arg any) (sql.Result, error) {
+	if err := Fill(arg); err != nil {
+		return nil, err
+	}
+	return db.NamedExecContext(ctx, query, arg)
+}
+
+// Execer executes statements with positional arguments, as *sql.DB, *sql.Tx
+// and *sql.Conn do
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Exec executes query with args, first replacing each *guuid.UUID argument
+// that points to a zero UUID with a new UUIDv7 and passing the UUID itself
+func Exec(ctx context.Context, db Execer, query string, args ...any) (sql.Result, error) {
+	args = append([]any(nil), args...)
+	for i, arg := range args {
+		p, ok := arg.(*guuid.UUID)
+		if !ok || p == nil {
+			continue
+		}
+		if p.IsZero() {
+			id, err := guuid.New()
+			if err != nil {
+				return nil, err
+			}
+			*p = id
+		}
+		args[i] = *p
+	}
+	return db.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,111 @@
+package sqlid
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+type base struct {
+	ID guuid.UUID `guuid:"auto"`
+}
+
+type order struct {
+	base
+	Ref      guuid.UUID `guuid:"auto"`
+	Customer guuid.UUID // untagged: never filled
+	Total    int64
+}
+
+func TestFill(t *testing.T) {
+	keep := guuid.Must(guuid.New())
+	o := order{Ref: keep}
+	if err := Fill(&o); err != nil {
+		t.Fatalf("Fill() error = %v", err)
+	}
+	if o.ID.Version() != guuid.VersionTimeSorted {
+		t.Errorf("embedded ID = %v, want a UUIDv7", o.ID)
+	}
+	if o.Ref != keep {
+		t.Errorf("Ref = %v, want existing %v kept", o.Ref, keep)
+	}
+	if !o.Customer.IsZero() {
+		t.Errorf("untagged Customer = %v, want zero", o.Customer)
+	}
+}
+
+func TestFill_Slices(t *testing.T) {
+	rows := []order{{}, {}}
+	ptrs := []*order{{}, nil}
+	for _, v := range []any{rows, &ptrs} {
+		if err := Fill(v); err != nil {
+			t.Fatalf("Fill(%T) error = %v", v, err)
+		}
+	}
+	if rows[0].ID.IsZero() || rows[1].ID.IsZero() || rows[0].ID == rows[1].ID {
+		t.Errorf("slice rows = %v, want distinct IDs", rows)
+	}
+	if ptrs[0].ID.IsZero() {
+		t.Error("pointer slice row not filled")
+	}
+}
+
+func TestFill_Errors(t *testing.T) {
+	for _, v := range []any{order{}, (*order)(nil), 42, nil} {
+		if err := Fill(v); !errors.Is(err, ErrNotPointer) {
+			t.Errorf("Fill(%#v) error = %v, want ErrNotPointer", v, err)
+		}
+	}
+}
+
+func TestFillWith_SourceError(t *testing.T) {
+	boom := errors.New("boom")
+	src := guuid.SourceFunc(func() (guuid.UUID, error) { return guuid.Nil, boom })
+	if err := FillWith(src, &order{}); !errors.Is(err, boom) {
+		t.Errorf("FillWith() error = %v, want %v", err, boom)
+	}
+}
+
+// recorder captures statements passed to NamedExec and Exec
+type recorder struct {
+	query string
+	args  []any
+}
+
+func (r *recorder) NamedExecContext(_ context.Context, query string, arg any) (sql.Result, error) {
+	r.query, r.args = query, []any{arg}
+	return nil, nil
+}
+
+func (r *recorder) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	r.query, r.args = query, args
+	return nil, nil
+}
+
+func TestNamedExec(t *testing.T) {
+	var db recorder
+	o := &order{Total: 5}
+	if _, err := NamedExec(context.Background(), &db, "INSERT", o); err != nil {
+		t.Fatalf("NamedExec() error = %v", err)
+	}
+	if o.ID.IsZero() || db.args[0] != o {
+		t.Errorf("NamedExec() passed %v with ID %v", db.args, o.ID)
+	}
+}
+
+func TestExec(t *testing.T) {
+	var db recorder
+	var id guuid.UUID
+	if _, err := Exec(context.Background(), &db, "INSERT", &id, "alice"); err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	if id.IsZero() {
+		t.Fatal("Exec() did not fill the ID")
+	}
+	if db.args[0] != id || db.args[1] != "alice" {
+		t.Errorf("Exec() args = %v, want [%v alice]", db.args, id)
+	}
+}
@@ -0,0 +1,30 @@
+package guuid
+
+import "time"
+
+// Stats holds counters accumulated by a Generator since it was created
+type Stats struct {
+	Generated uint64 // UUIDs returned without error
+	Errors    uint64 // generation attempts that failed
+	Overflows uint64 // counter overflows that advanced the timestamp
+}
+
+// Stats returns a snapshot of the generator's counters. It is safe to call
+// concurrently with generation.
+func (g *Generator) Stats() Stats {
+	return Stats{
+		Generated: g.generated.Load(),
+		Errors:    g.errors.Load(),
+		Overflows: g.overflows.Load(),
+	}
+}
+
+// Lead returns how far the last timestamp issued runs ahead of the wall
+// clock, because of counter overflow or the clock stepping backwards. It is
+// zero when the generator is not ahead.
+func (g *Generator) Lead() time.Duration {
+	g.mu.Lock()
+	last := int64(g.lastTimestamp)
+	g.mu.Unlock()
+	return max(time.Duration(last-time.Now().UnixMilli())*time.Millisecond, 0)
+}
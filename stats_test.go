@@ -0,0 +1,35 @@
+package guuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGenerator_Stats(t *testing.T) {
+	gen := NewGenerator()
+	for i := 0; i < 5; i++ {
+		Must(gen.New())
+	}
+	if got, want := gen.Stats(), (Stats{Generated: 5}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	broken := NewGeneratorWithReader(&brokenReader{})
+	if _, err := broken.New(); err == nil {
+		t.Fatal("New() expected error with broken reader")
+	}
+	if got, want := broken.Stats(), (Stats{Errors: 1}); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGenerator_Lead(t *testing.T) {
+	gen := NewGenerator()
+	if got := gen.Lead(); got != 0 {
+		t.Errorf("Lead() of a new generator = %v, want 0", got)
+	}
+	Must(gen.NewWithTime(time.Now().Add(time.Hour)))
+	if got := gen.Lead(); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("Lead() = %v, want about 1h", got)
+	}
+}
@@ -0,0 +1,111 @@
+package guuid
+
+import (
+	"encoding/binary"
+)
+
+const (
+	// gregorianOffsetMs is the number of milliseconds between the UUIDv1 epoch
+	// (1582-10-15 00:00:00 UTC) and the Unix epoch
+	gregorianOffsetMs = 12219292800000
+
+	// ticksPerMs is the number of 100-nanosecond UUIDv1 ticks per millisecond
+	ticksPerMs = 10000
+)
+
+// V7ToV1 rewrites a UUIDv7 into the UUIDv1 (timeuuid) layout, for systems such
+// as Cassandra whose clustering columns require version 1.
+//
+// The v1 timestamp is the v7 millisecond timestamp plus the 12-bit rand_a
+// counter scaled to sub-millisecond ticks, so v1 timestamp ordering matches v7
+// ordering. The 62 bits of rand_b fill the 14-bit clock sequence and the
+// 48-bit node. The conversion is lossless, V1ToV7(V7ToV1(u)) == u, for every
+// UUIDv7 whose v1 timestamp fits in 60 bits: timestamps up to about
+// 1.03e14 ms, in the year 5236. Later ones return ErrTimestampOutOfRange.
+func V7ToV1(u UUID) (UUID, error) {
+	if u.Version() != VersionTimeSorted {
+		return Nil, ErrInvalidVersion
+	}
+
+	// Round up, so V1ToV7's rounded-down scaling recovers rand_a exactly
+	randA := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0FFF)
+	sub := (randA*ticksPerMs + 0x0FFF) / 0x1000
+	ticks := (uint64(u.Timestamp())+gregorianOffsetMs)*ticksPerMs + sub
+	if ticks >= 1<<60 {
+		return Nil, ErrTimestampOutOfRange
+	}
+
+	var v1 UUID
+	binary.BigEndian.PutUint32(v1[0:4], uint32(ticks))
+	binary.BigEndian.PutUint16(v1[4:6], uint16(ticks>>32))
+	binary.BigEndian.PutUint16(v1[6:8], uint16(ticks>>48)&0x0FFF|0x1000)
+	copy(v1[8:16], u[8:16]) // variant bits are identical in both layouts
+	return v1, nil
+}
+
+// V1ToV7 converts a UUIDv1 into a UUIDv7 with the same millisecond timestamp.
+// Sub-millisecond ticks are scaled into the 12-bit rand_a field, which
+// preserves ordering but not the original tick value, and the clock sequence
+// and node become rand_b. It inverts V7ToV1 exactly.
+//
+// It returns ErrTimestampOutOfRange for v1 timestamps before the Unix epoch.
+func V1ToV7(u UUID) (UUID, error) {
+	if u.Version() != VersionTimeBased {
+		return Nil, ErrInvalidVersion
+	}
+
+	ticks := v1Timestamp(u)
+	ms := ticks / ticksPerMs
+	if ms < gregorianOffsetMs {
+		return Nil, ErrTimestampOutOfRange
+	}
+	ms -= gregorianOffsetMs
+	sub := ticks % ticksPerMs * 0x1000 / ticksPerMs
+
+	var v7 UUID
+	binary.BigEndian.PutUint64(v7[0:8], ms<<16)
+	binary.BigEndian.PutUint16(v7[6:8], uint16(sub)|0x7000)
+	copy(v7[8:16], u[8:16])
+	return v7, nil
+}
+
+// V1ToV6 reorders a UUIDv1 into the UUIDv6 layout, which stores the timestamp
+// most significant bits first so that v6 UUIDs sort by time. The clock
+// sequence and node are unchanged, and V6ToV1 reverses the conversion exactly.
+func V1ToV6(u UUID) (UUID, error) {
+	if u.Version() != VersionTimeBased {
+		return Nil, ErrInvalidVersion
+	}
+	ticks := v1Timestamp(u)
+
+	var v6 UUID
+	binary.BigEndian.PutUint32(v6[0:4], uint32(ticks>>28))
+	binary.BigEndian.PutUint16(v6[4:6], uint16(ticks>>12))
+	binary.BigEndian.PutUint16(v6[6:8], uint16(ticks)&0x0FFF|0x6000)
+	copy(v6[8:16], u[8:16])
+	return v6, nil
+}
+
+// V6ToV1 converts a UUIDv6 back into the UUIDv1 layout
+func V6ToV1(u UUID) (UUID, error) {
+	if u.Version() != VersionReorderedTime {
+		return Nil, ErrInvalidVersion
+	}
+	ticks := uint64(binary.BigEndian.Uint32(u[0:4]))<<28 |
+		uint64(binary.BigEndian.Uint16(u[4:6]))<<12 |
+		uint64(binary.BigEndian.Uint16(u[6:8])&0x0FFF)
+
+	var v1 UUID
+	binary.BigEndian.PutUint32(v1[0:4], uint32(ticks))
+	binary.BigEndian.PutUint16(v1[4:6], uint16(ticks>>32))
+	binary.BigEndian.PutUint16(v1[6:8], uint16(ticks>>48)&0x0FFF|0x1000)
+	copy(v1[8:16], u[8:16])
+	return v1, nil
+}
+
+// v1Timestamp returns the 60-bit tick count of a UUIDv1
+func v1Timestamp(u UUID) uint64 {
+	return uint64(binary.BigEndian.Uint32(u[0:4])) |
+		uint64(binary.BigEndian.Uint16(u[4:6]))<<32 |
+		uint64(binary.BigEndian.Uint16(u[6:8])&0x0FFF)<<48
+}
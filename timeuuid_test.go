@@ -0,0 +1,188 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestV7ToV1_RoundTrip(t *testing.T) {
+	gen := NewGenerator()
+	now := time.Now()
+
+	var prevV1 UUID
+	for i := 0; i < 100; i++ {
+		v7 := Must(gen.NewWithTime(now))
+		v1, err := V7ToV1(v7)
+		if err != nil {
+			t.Fatalf("V7ToV1() error = %v", err)
+		}
+		if v1.Version() != VersionTimeBased || v1.Variant() != VariantRFC4122 {
+			t.Fatalf("V7ToV1() = %v, wrong version/variant", v1)
+		}
+		if i > 0 && v1Ticks(v1) <= v1Ticks(prevV1) {
+			t.Errorf("V7ToV1() timestamps not increasing at %d", i)
+		}
+		prevV1 = v1
+
+		back, err := V1ToV7(v1)
+		if err != nil {
+			t.Fatalf("V1ToV7() error = %v", err)
+		}
+		if back != v7 {
+			t.Errorf("V1ToV7(V7ToV1(%v)) = %v", v7, back)
+		}
+	}
+}
+
+func TestV1ToV7_KnownTime(t *testing.T) {
+	// A v1 UUID for 2024-01-01T00:00:00.000Z plus 5000 ticks (0.5ms)
+	ms := uint64(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli())
+	v7, err := V1ToV7(v1FromTicks((ms+gregorianOffsetMs)*ticksPerMs + 5000))
+	if err != nil {
+		t.Fatalf("V1ToV7() error = %v", err)
+	}
+	if v7.Timestamp() != int64(ms) {
+		t.Errorf("V1ToV7() timestamp = %d, want %d", v7.Timestamp(), ms)
+	}
+	if got := v7.Inspect().Counter; got != 5000*0x1000/ticksPerMs {
+		t.Errorf("V1ToV7() scaled counter = %d, want %d", got, 5000*0x1000/ticksPerMs)
+	}
+}
+
+func TestV1ToV7_SubMillisecondOrder(t *testing.T) {
+	base := (uint64(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()) + gregorianOffsetMs) * ticksPerMs
+
+	var prev UUID
+	for sub := uint64(0); sub < ticksPerMs; sub++ {
+		v7, err := V1ToV7(v1FromTicks(base + sub))
+		if err != nil {
+			t.Fatalf("V1ToV7() error = %v", err)
+		}
+		if sub > 0 && v7.Compare(prev) < 0 {
+			t.Fatalf("V1ToV7() at %d ticks = %v, sorts before %d ticks = %v", sub, v7, sub-1, prev)
+		}
+		prev = v7
+	}
+
+	a, _ := V1ToV7(v1FromTicks(base + 4095))
+	b, _ := V1ToV7(v1FromTicks(base + 4096))
+	if a.Compare(b) > 0 {
+		t.Errorf("V1ToV7() of 4095 ticks = %v, sorts after 4096 ticks = %v", a, b)
+	}
+}
+
+func TestV7ToV1_AllCounters(t *testing.T) {
+	for counter := 0; counter <= 0x0FFF; counter++ {
+		v7 := Must(NewV7FromParts(1700000000000, uint16(counter), [8]byte{0xff}))
+		v1, err := V7ToV1(v7)
+		if err != nil {
+			t.Fatalf("V7ToV1() error = %v", err)
+		}
+		if back, _ := V1ToV7(v1); back != v7 {
+			t.Fatalf("V1ToV7(V7ToV1(%v)) = %v", v7, back)
+		}
+	}
+}
+
+// v1FromTicks returns a v1 UUID with the given 60-bit timestamp
+func v1FromTicks(ticks uint64) UUID {
+	var v1 UUID
+	v1[0], v1[1], v1[2], v1[3] = byte(ticks>>24), byte(ticks>>16), byte(ticks>>8), byte(ticks)
+	v1[4], v1[5] = byte(ticks>>40), byte(ticks>>32)
+	v1[6], v1[7] = byte(ticks>>56)&0x0F|0x10, byte(ticks>>48)
+	v1[8] = 0x80
+	return v1
+}
+
+func TestV7ToV1_Errors(t *testing.T) {
+	v4 := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	if _, err := V7ToV1(v4); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("V7ToV1(v4) error = %v, want %v", err, ErrInvalidVersion)
+	}
+	if _, err := V1ToV7(v4); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("V1ToV7(v4) error = %v, want %v", err, ErrInvalidVersion)
+	}
+
+	// A v1 UUID at the Gregorian epoch predates Unix time
+	early := UUID{6: 0x10, 8: 0x80}
+	if _, err := V1ToV7(early); !errors.Is(err, ErrTimestampOutOfRange) {
+		t.Errorf("V1ToV7(1582) error = %v, want %v", err, ErrTimestampOutOfRange)
+	}
+}
+
+func TestV7ToV1_Range(t *testing.T) {
+	// The last millisecond whose ticks fit in 60 bits holds 6975 more ticks,
+	// so its low counters convert and its high ones do not
+	const lastMs = (1<<60)/ticksPerMs - gregorianOffsetMs
+	tests := []struct {
+		ms      int64
+		randA   uint16
+		wantErr bool
+	}{
+		{lastMs - 1, 0xFFF, false},
+		{lastMs, 0, false},
+		{lastMs, 0xB28, false}, // 6975 ticks
+		{lastMs, 0xB29, true},
+		{lastMs, 0xFFF, true},
+		{lastMs + 1, 0, true},
+		{1<<48 - 1, 0xFFF, true},
+	}
+	for _, tt := range tests {
+		u := Must(NewV7FromParts(tt.ms, tt.randA, [8]byte{0x80}))
+		v1, err := V7ToV1(u)
+		if tt.wantErr {
+			if !errors.Is(err, ErrTimestampOutOfRange) {
+				t.Errorf("V7ToV1(%d/%#x) error = %v, want %v", tt.ms, tt.randA, err, ErrTimestampOutOfRange)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("V7ToV1(%d/%#x) error = %v", tt.ms, tt.randA, err)
+		}
+		if back := Must(V1ToV7(v1)); back != u {
+			t.Errorf("V1ToV7(V7ToV1(%v)) = %v", u, back)
+		}
+	}
+}
+
+// v1Ticks extracts the 60-bit timestamp from a UUIDv1
+func v1Ticks(u UUID) uint64 {
+	return uint64(u[6]&0x0F)<<56 | uint64(u[7])<<48 | uint64(u[4])<<40 | uint64(u[5])<<32 |
+		uint64(u[0])<<24 | uint64(u[1])<<16 | uint64(u[2])<<8 | uint64(u[3])
+}
+
+func TestV1ToV6_RFCVector(t *testing.T) {
+	// RFC 9562 Appendix A.1 and A.5 encode the same instant as v1 and v6
+	v1 := MustParse("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6 := MustParse("1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+
+	got, err := V1ToV6(v1)
+	if err != nil {
+		t.Fatalf("V1ToV6() error = %v", err)
+	}
+	if got != v6 {
+		t.Errorf("V1ToV6() = %v, want %v", got, v6)
+	}
+	if got.Version() != VersionReorderedTime {
+		t.Errorf("V1ToV6() version = %v, want %v", got.Version(), VersionReorderedTime)
+	}
+
+	back, err := V6ToV1(v6)
+	if err != nil {
+		t.Fatalf("V6ToV1() error = %v", err)
+	}
+	if back != v1 {
+		t.Errorf("V6ToV1() = %v, want %v", back, v1)
+	}
+}
+
+func TestV1ToV6_Errors(t *testing.T) {
+	v7 := Must(New())
+	if _, err := V1ToV6(v7); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("V1ToV6(v7) error = %v, want %v", err, ErrInvalidVersion)
+	}
+	if _, err := V6ToV1(v7); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("V6ToV1(v7) error = %v, want %v", err, ErrInvalidVersion)
+	}
+}
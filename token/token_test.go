@@ -0,0 +1,104 @@
+package token
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Lzww0608/guuid"
+)
+
+func key(b byte) []byte {
+	return bytes.Repeat([]byte{b}, MinKeySize)
+}
+
+func TestKeyring_SignVerify(t *testing.T) {
+	kr, err := NewKeyring(key(1))
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	id := guuid.Must(guuid.New())
+
+	tok := kr.Sign(id)
+	if !strings.HasPrefix(tok, id.String()+".") {
+		t.Errorf("Sign() = %q, want prefix %q", tok, id.String()+".")
+	}
+	if tok != kr.Sign(id) {
+		t.Error("Sign() is not deterministic")
+	}
+
+	got, err := kr.Verify(tok)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got != id {
+		t.Errorf("Verify() = %v, want %v", got, id)
+	}
+}
+
+func TestKeyring_Verify_Errors(t *testing.T) {
+	kr, _ := NewKeyring(key(1))
+	other, _ := NewKeyring(key(2))
+	id := guuid.Must(guuid.New())
+	tok := kr.Sign(id)
+	_, sig, _ := strings.Cut(tok, ".")
+
+	tests := []struct {
+		name string
+		tok  string
+		want error
+	}{
+		{"empty", "", ErrMalformed},
+		{"no signature", id.String(), ErrMalformed},
+		{"bad uuid", "not-a-uuid." + sig, ErrMalformed},
+		{"hex uuid", id.EncodeToHex() + "." + sig, ErrMalformed},
+		{"bad base64", id.String() + ".!!!", ErrMalformed},
+		{"short signature", tok[:len(tok)-2], ErrMalformed},
+		{"other key", other.Sign(id), ErrSignature},
+		{"swapped uuid", guuid.Must(guuid.New()).String() + "." + sig, ErrSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := kr.Verify(tt.tok); !errors.Is(err, tt.want) {
+				t.Errorf("Verify(%q) error = %v, want %v", tt.tok, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	old, _ := NewKeyring(key(1))
+	id := guuid.Must(guuid.New())
+	oldTok := old.Sign(id)
+
+	rotated, err := old.Rotate(key(2), 1)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if _, err := rotated.Verify(oldTok); err != nil {
+		t.Errorf("Verify(old token) after rotation error = %v", err)
+	}
+	newTok := rotated.Sign(id)
+	if newTok == oldTok {
+		t.Error("Sign() after rotation still uses the old key")
+	}
+	if _, err := old.Verify(newTok); !errors.Is(err, ErrSignature) {
+		t.Errorf("old keyring verified a token from the new key")
+	}
+
+	retired, _ := rotated.Rotate(key(3), 0)
+	if _, err := retired.Verify(oldTok); !errors.Is(err, ErrSignature) {
+		t.Errorf("Verify() after retiring key error = %v, want %v", err, ErrSignature)
+	}
+}
+
+func TestNewKeyring_ShortKey(t *testing.T) {
+	if _, err := NewKeyring([]byte("short")); !errors.Is(err, ErrKey) {
+		t.Errorf("NewKeyring(short) error = %v, want %v", err, ErrKey)
+	}
+	if _, err := NewKeyring(key(1), []byte("short")); !errors.Is(err, ErrKey) {
+		t.Errorf("NewKeyring(short previous) error = %v, want %v", err, ErrKey)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -21,9 +22,9 @@ const (
 	VersionNameBasedMD5
 	VersionRandom
 	VersionNameBasedSHA1
-	_
-	VersionTimeSorted // UUIDv7
-	VersionCustom     // UUIDv8
+	VersionReorderedTime // UUIDv6
+	VersionTimeSorted    // UUIDv7
+	VersionCustom        // UUIDv8
 )
 
 // Variant represents the UUID variant
@@ -36,9 +37,79 @@ const (
 	VariantFuture
 )
 
+// versionNames holds the descriptions used by Version.String
+var versionNames = [...]string{
+	1: "time-based",
+	2: "DCE security",
+	3: "name-based MD5",
+	4: "random",
+	5: "name-based SHA-1",
+	6: "reordered time",
+	7: "time-sorted",
+	8: "custom",
+}
+
+// String returns a description such as "v7 (time-sorted)"
+func (v Version) String() string {
+	if int(v) < len(versionNames) && versionNames[v] != "" {
+		return "v" + strconv.Itoa(int(v)) + " (" + versionNames[v] + ")"
+	}
+	return "v" + strconv.Itoa(int(v))
+}
+
+// ParseVersion parses a version written as "7", "v7" or "v7 (time-sorted)"
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 15 {
+		return 0, ErrInvalidVersion
+	}
+	return Version(n), nil
+}
+
+// variantNames holds the names used by Variant.String
+var variantNames = [...]string{
+	VariantNCS:       "NCS",
+	VariantRFC4122:   "RFC 4122",
+	VariantMicrosoft: "Microsoft",
+	VariantFuture:    "Future",
+}
+
+// String returns the variant name, e.g. "RFC 4122"
+func (v Variant) String() string {
+	if int(v) < len(variantNames) {
+		return variantNames[v]
+	}
+	return "Variant(" + strconv.Itoa(int(v)) + ")"
+}
+
+// ParseVariant parses a variant name as returned by Variant.String,
+// ignoring case. "RFC 9562" and "RFC4122" are accepted as aliases.
+func ParseVariant(s string) (Variant, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "ncs":
+		return VariantNCS, nil
+	case "rfc 4122", "rfc4122", "rfc 9562", "rfc9562":
+		return VariantRFC4122, nil
+	case "microsoft":
+		return VariantMicrosoft, nil
+	case "future":
+		return VariantFuture, nil
+	default:
+		return 0, ErrInvalidVariant
+	}
+}
+
 // Nil is the nil UUID (all zeros)
 var Nil UUID
 
+// MaxUUID is the RFC 9562 Max UUID (all ones), the largest UUID in byte order
+var MaxUUID = UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
 // Version returns the version of the UUID
 func (u UUID) Version() Version {
 	return Version(u[6] >> 4)
@@ -58,6 +129,27 @@ func (u UUID) Variant() Variant {
 	}
 }
 
+// SetVersion sets the 4 version bits of the UUID, leaving all other bits intact
+func (u *UUID) SetVersion(v Version) {
+	u[6] = (u[6] & 0x0f) | byte(v)<<4
+}
+
+// SetVariant sets the variant bits of the UUID, leaving all other bits intact.
+// The number of bits written depends on the variant (1 for NCS, 2 for RFC 4122,
+// 3 for Microsoft and Future).
+func (u *UUID) SetVariant(v Variant) {
+	switch v {
+	case VariantNCS:
+		u[8] &= 0x7f
+	case VariantRFC4122:
+		u[8] = (u[8] & 0x3f) | 0x80
+	case VariantMicrosoft:
+		u[8] = (u[8] & 0x1f) | 0xc0
+	default:
+		u[8] = (u[8] & 0x1f) | 0xe0
+	}
+}
+
 // String returns the canonical string representation of the UUID
 // in the format: xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
 func (u UUID) String() string {
@@ -86,46 +178,7 @@ func encodeHex(dst []byte, u UUID) {
 //   - {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
 //   - xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx (without hyphens)
 func Parse(s string) (UUID, error) {
-	var uuid UUID
-
-	// Remove common prefixes and suffixes
-	s = strings.TrimPrefix(s, "urn:uuid:")
-	s = strings.TrimPrefix(s, "{")
-	s = strings.TrimSuffix(s, "}")
-
-	// Handle canonical format with hyphens
-	if len(s) == 36 {
-		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
-			return uuid, ErrInvalidFormat
-		}
-		// Decode each segment
-		if err := decodeHexSegment(uuid[0:4], s[0:8]); err != nil {
-			return uuid, err
-		}
-		if err := decodeHexSegment(uuid[4:6], s[9:13]); err != nil {
-			return uuid, err
-		}
-		if err := decodeHexSegment(uuid[6:8], s[14:18]); err != nil {
-			return uuid, err
-		}
-		if err := decodeHexSegment(uuid[8:10], s[19:23]); err != nil {
-			return uuid, err
-		}
-		if err := decodeHexSegment(uuid[10:16], s[24:36]); err != nil {
-			return uuid, err
-		}
-		return uuid, nil
-	}
-
-	// Handle format without hyphens
-	if len(s) == 32 {
-		if _, err := hex.Decode(uuid[:], []byte(s)); err != nil {
-			return uuid, ErrInvalidFormat
-		}
-		return uuid, nil
-	}
-
-	return uuid, ErrInvalidFormat
+	return parseText(s)
 }
 
 // MustParse is like Parse but panics if the string cannot be parsed.
@@ -138,14 +191,6 @@ func MustParse(s string) UUID {
 	return uuid
 }
 
-// decodeHexSegment decodes a hex string segment into a byte slice
-func decodeHexSegment(dst []byte, src string) error {
-	if _, err := hex.Decode(dst, []byte(src)); err != nil {
-		return ErrInvalidFormat
-	}
-	return nil
-}
-
 // Bytes returns the UUID as a byte slice
 func (u UUID) Bytes() []byte {
 	return u[:]
@@ -156,6 +201,26 @@ func (u UUID) IsNil() bool {
 	return u == Nil
 }
 
+// IsZero reports whether u is the nil UUID. It is equivalent to IsNil and lets
+// encoding/json's omitzero option (Go 1.24+) and similar libraries treat Nil
+// as an empty value.
+func (u UUID) IsZero() bool {
+	return u == Nil
+}
+
+// Ptr returns a pointer to a copy of u, for populating optional UUID fields
+func Ptr(u UUID) *UUID {
+	return &u
+}
+
+// FromPtr returns the UUID p points to, or Nil if p is nil
+func FromPtr(p *UUID) UUID {
+	if p == nil {
+		return Nil
+	}
+	return *p
+}
+
 // MarshalText implements the encoding.TextMarshaler interface
 func (u UUID) MarshalText() ([]byte, error) {
 	var buf [36]byte
@@ -163,9 +228,25 @@ func (u UUID) MarshalText() ([]byte, error) {
 	return buf[:], nil
 }
 
-// UnmarshalText implements the encoding.TextUnmarshaler interface
+// AppendText implements the encoding.TextAppender interface, appending the
+// canonical form to b. encoding/json implementations built on json/v2 use it
+// to write UUIDs straight into their output buffer without allocating.
+func (u UUID) AppendText(b []byte) ([]byte, error) {
+	b = append(b, make([]byte, 36)...)
+	encodeHex(b[len(b)-36:], u)
+	return b, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. See
+// SetStrictText for accepting padded or quoted input.
 func (u *UUID) UnmarshalText(data []byte) error {
-	id, err := Parse(string(data))
+	var id UUID
+	var err error
+	if lenientText.Load() {
+		id, err = Parse(trimText(string(data)))
+	} else {
+		id, err = parseText(data)
+	}
 	if err != nil {
 		return err
 	}
@@ -207,7 +288,7 @@ func (u *UUID) Scan(src interface{}) error {
 		if len(src) == 0 {
 			return nil
 		}
-		id, err := Parse(string(src))
+		id, err := parseText(src)
 		if err != nil {
 			return err
 		}
@@ -241,3 +322,28 @@ func (u UUID) Compare(other UUID) int {
 func (u UUID) Equal(other UUID) bool {
 	return u == other
 }
+
+// Compare returns -1, 0 or +1 as a is less than, equal to or greater than b.
+// It has the shape slices.SortFunc and slices.BinarySearchFunc expect:
+//
+//	slices.SortFunc(ids, guuid.Compare)
+//	i, found := slices.BinarySearchFunc(ids, id, guuid.Compare)
+func Compare(a, b UUID) int {
+	return a.Compare(b)
+}
+
+// Min returns the smaller of a and b
+func Min(a, b UUID) UUID {
+	if b.Compare(a) < 0 {
+		return b
+	}
+	return a
+}
+
+// Max returns the larger of a and b
+func Max(a, b UUID) UUID {
+	if b.Compare(a) > 0 {
+		return b
+	}
+	return a
+}
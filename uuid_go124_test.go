@@ -0,0 +1,31 @@
+//go:build go1.24
+
+package guuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUUID_JSONOmitZero(t *testing.T) {
+	type TestStruct struct {
+		ID UUID `json:"id,omitzero"`
+	}
+
+	data, err := json.Marshal(TestStruct{})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(data) != "{}" {
+		t.Errorf("json.Marshal() with Nil ID = %s, want {}", data)
+	}
+
+	uuid := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	data, err = json.Marshal(TestStruct{ID: uuid})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"id":"` + uuid.String() + `"}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
@@ -3,6 +3,7 @@ package guuid
 import (
 	"bytes"
 	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -47,6 +48,21 @@ func TestParse(t *testing.T) {
 			input:   "f47ac10b58cc-4372-a567-0e02b2c3d479",
 			wantErr: true,
 		},
+		{
+			name:    "uppercase",
+			input:   "F47AC10B-58CC-4372-A567-0E02B2C3D479",
+			wantErr: false,
+		},
+		{
+			name:    "invalid format - invalid hex in last digit",
+			input:   "f47ac10b-58cc-4372-a567-0e02b2c3d47z",
+			wantErr: true,
+		},
+		{
+			name:    "invalid format - invalid hex without hyphens",
+			input:   "f47ac10b58cc4372a5670e02b2c3d4-9",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +132,23 @@ func TestUUID_MarshalUnmarshalText(t *testing.T) {
 	}
 }
 
+func TestUUID_AppendText(t *testing.T) {
+	uuid := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+
+	got, err := uuid.AppendText([]byte(`"`))
+	if err != nil {
+		t.Fatalf("AppendText() error = %v", err)
+	}
+	if want := `"` + uuid.String(); string(got) != want {
+		t.Errorf("AppendText() = %s, want %s", got, want)
+	}
+
+	buf := make([]byte, 0, 64)
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = uuid.AppendText(buf[:0]) }); allocs != 0 {
+		t.Errorf("AppendText() with capacity allocates %v times, want 0", allocs)
+	}
+}
+
 func TestUUID_MarshalUnmarshalBinary(t *testing.T) {
 	uuid := UUID{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72, 0xa5, 0x67, 0x0e, 0x02, 0xb2, 0xc3, 0xd4, 0x79}
 
@@ -186,6 +219,45 @@ func TestUUID_Compare(t *testing.T) {
 	}
 }
 
+func TestCompareMinMax(t *testing.T) {
+	a, b := UUID{0x01}, UUID{0x02}
+
+	tests := []struct {
+		x, y     UUID
+		cmp      int
+		min, max UUID
+	}{
+		{a, b, -1, a, b},
+		{b, a, 1, a, b},
+		{a, a, 0, a, a},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.x, tt.y); got != tt.cmp {
+			t.Errorf("Compare(%v, %v) = %d, want %d", tt.x, tt.y, got, tt.cmp)
+		}
+		if got := Min(tt.x, tt.y); got != tt.min {
+			t.Errorf("Min(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.min)
+		}
+		if got := Max(tt.x, tt.y); got != tt.max {
+			t.Errorf("Max(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.max)
+		}
+	}
+}
+
+func TestCompare_Slices(t *testing.T) {
+	ids := []UUID{{0x03}, {0x01}, {0x02}}
+	slices.SortFunc(ids, Compare)
+	if !slices.IsSortedFunc(ids, Compare) {
+		t.Fatalf("SortFunc() = %v, not sorted", ids)
+	}
+	if i, found := slices.BinarySearchFunc(ids, UUID{0x02}, Compare); !found || i != 1 {
+		t.Errorf("BinarySearchFunc() = %d, %v, want 1, true", i, found)
+	}
+	if i, found := slices.BinarySearchFunc(ids, UUID{0x02, 0x01}, Compare); found || i != 2 {
+		t.Errorf("BinarySearchFunc() of missing ID = %d, %v, want 2, false", i, found)
+	}
+}
+
 func TestUUID_Equal(t *testing.T) {
 	uuid1 := UUID{0x01, 0x02, 0x03}
 	uuid2 := UUID{0x01, 0x02, 0x03}
@@ -306,3 +378,119 @@ func TestUUID_Bytes(t *testing.T) {
 		t.Error("Bytes() did not return correct byte slice")
 	}
 }
+
+func TestUUID_SetVersionVariant(t *testing.T) {
+	variants := []Variant{VariantNCS, VariantRFC4122, VariantMicrosoft, VariantFuture}
+	versions := []Version{VersionRandom, VersionTimeSorted, VersionCustom}
+
+	for _, base := range []UUID{Nil, {0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}} {
+		for _, ver := range versions {
+			for _, v := range variants {
+				uuid := base
+				uuid.SetVersion(ver)
+				uuid.SetVariant(v)
+				if uuid.Version() != ver {
+					t.Errorf("SetVersion(%v) on %v: Version() = %v", ver, base, uuid.Version())
+				}
+				if uuid.Variant() != v {
+					t.Errorf("SetVariant(%v) on %v: Variant() = %v", v, base, uuid.Variant())
+				}
+				if uuid[0] != base[0] || uuid[15] != base[15] || uuid[6]&0x0f != base[6]&0x0f {
+					t.Errorf("SetVersion/SetVariant modified unrelated bits: %v", uuid)
+				}
+			}
+		}
+	}
+}
+
+func TestUUID_IsZero(t *testing.T) {
+	if !Nil.IsZero() {
+		t.Error("Nil UUID should return true for IsZero()")
+	}
+	if (UUID{15: 1}).IsZero() {
+		t.Error("Non-nil UUID should return false for IsZero()")
+	}
+}
+
+func TestPtrHelpers(t *testing.T) {
+	uuid := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+
+	p := Ptr(uuid)
+	if p == nil || *p != uuid {
+		t.Fatalf("Ptr() = %v, want pointer to %v", p, uuid)
+	}
+	p[0] = 0
+	if uuid[0] != 0xf4 {
+		t.Error("Ptr() did not copy the UUID")
+	}
+
+	if got := FromPtr(Ptr(uuid)); got != uuid {
+		t.Errorf("FromPtr() = %v, want %v", got, uuid)
+	}
+	if got := FromPtr(nil); got != Nil {
+		t.Errorf("FromPtr(nil) = %v, want Nil", got)
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	tests := []struct {
+		v    Version
+		want string
+	}{
+		{VersionTimeBased, "v1 (time-based)"},
+		{VersionRandom, "v4 (random)"},
+		{VersionTimeSorted, "v7 (time-sorted)"},
+		{VersionCustom, "v8 (custom)"},
+		{Version(0), "v0"},
+		{Version(12), "v12"},
+	}
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("Version(%d).String() = %q, want %q", byte(tt.v), got, tt.want)
+		}
+	}
+}
+
+func TestParse_NoAllocs(t *testing.T) {
+	for _, s := range []string{
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		"f47ac10b58cc4372a5670e02b2c3d479",
+		"urn:uuid:f47ac10b-58cc-4372-a567-0e02b2c3d479",
+	} {
+		if allocs := testing.AllocsPerRun(100, func() { _, _ = Parse(s) }); allocs != 0 {
+			t.Errorf("Parse(%q) allocates %v times, want 0", s, allocs)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	for _, s := range []string{"7", "v7", "V7", "v7 (time-sorted)", VersionTimeSorted.String()} {
+		v, err := ParseVersion(s)
+		if err != nil || v != VersionTimeSorted {
+			t.Errorf("ParseVersion(%q) = %v, %v, want %v", s, v, err, VersionTimeSorted)
+		}
+	}
+	for _, s := range []string{"", "v", "seven", "16", "-1"} {
+		if _, err := ParseVersion(s); err == nil {
+			t.Errorf("ParseVersion(%q) expected error", s)
+		}
+	}
+}
+
+func TestVariant_StringParse(t *testing.T) {
+	for _, v := range []Variant{VariantNCS, VariantRFC4122, VariantMicrosoft, VariantFuture} {
+		parsed, err := ParseVariant(v.String())
+		if err != nil || parsed != v {
+			t.Errorf("ParseVariant(%q) = %v, %v, want %v", v.String(), parsed, err, v)
+		}
+	}
+	if got := VariantRFC4122.String(); got != "RFC 4122" {
+		t.Errorf("VariantRFC4122.String() = %q, want %q", got, "RFC 4122")
+	}
+	if got := Variant(9).String(); got != "Variant(9)" {
+		t.Errorf("Variant(9).String() = %q, want %q", got, "Variant(9)")
+	}
+	if _, err := ParseVariant("rfc 1234"); err == nil {
+		t.Error("ParseVariant(\"rfc 1234\") expected error")
+	}
+}
@@ -1,10 +1,12 @@
 package guuid
 
 import (
+	"crypto/cipher"
 	"crypto/rand"
 	"encoding/binary"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -15,21 +17,64 @@ type Generator struct {
 	lastTimestamp uint64
 	clockSeq      uint16 // 12-bit counter for sub-millisecond ordering
 	randReader    io.Reader
+
+	entropyPolicy EntropyPolicy
+	retryAttempts int
+	retryBackoff  time.Duration
+	fallback      cipher.Stream // CSPRNG used by EntropyFallback, seeded at construction
+	mixer         *entropyMixer // non-nil when WithEntropyMixing is set
+
+	entropyTimeout time.Duration
+	pendingRead    chan entropyRead // outstanding read when entropyTimeout is set
+
+	preHooks   []func()
+	postHooks  []Hook
+	onOverflow []func(lead time.Duration)
+
+	generated atomic.Uint64
+	errors    atomic.Uint64
+	overflows atomic.Uint64
+
+	shared *sharedState // cross-process state, set by NewSharedGenerator
+
+	ditherMs      int64 // WithTimestampDither window in milliseconds
+	granularityMs int64 // WithTimestampGranularity period in milliseconds
+
+	overflowSleep    bool          // wait for the next millisecond on overflow
+	overflowSleepMax time.Duration // bound on a single overflow wait, 0 for the default
+
+	smearMs  int64  // WithSmearWindow window in milliseconds
+	smearSeq uint16 // 14-bit counter extension while the 12-bit counter is exhausted
+
+	limiter atomic.Pointer[tokenBucket] // WithRateLimit bucket, nil for none
+
+	skew   *skewGuard // WithSkewGuard state, nil for none
+	onSkew []func(skew time.Duration, err error)
 }
 
+// Option configures a Generator at construction time
+type Option func(*Generator)
+
 // NewGenerator creates a new UUIDv7 generator with crypto/rand as the random source
-func NewGenerator() *Generator {
-	return &Generator{
-		randReader: rand.Reader,
-	}
+func NewGenerator(opts ...Option) *Generator {
+	return NewGeneratorWithReader(rand.Reader, opts...)
 }
 
 // NewGeneratorWithReader creates a new UUIDv7 generator with a custom random source.
 // This is primarily useful for testing with deterministic random sources.
-func NewGeneratorWithReader(r io.Reader) *Generator {
-	return &Generator{
-		randReader: r,
+func NewGeneratorWithReader(r io.Reader, opts ...Option) *Generator {
+	g := &Generator{
+		randReader:    r,
+		retryAttempts: defaultRetryAttempts,
+		retryBackoff:  defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(g)
 	}
+	if g.entropyPolicy == EntropyFallback {
+		g.fallback = newFallbackStream()
+	}
+	return g
 }
 
 // New generates a new UUIDv7 with the current timestamp.
@@ -42,7 +87,62 @@ func (g *Generator) New() (UUID, error) {
 // NewWithTime generates a new UUIDv7 with the specified timestamp.
 // This method is thread-safe and ensures monotonic ordering.
 func (g *Generator) NewWithTime(t time.Time) (UUID, error) {
-	var uuid UUID
+	return g.newWithTime(t, nil)
+}
+
+// newWithTime implements NewWithTime. finish, if not nil, rewrites the random
+// bits of a successfully generated UUID before hooks and counters see it.
+func (g *Generator) newWithTime(t time.Time, finish func(*UUID)) (UUID, error) {
+	return g.issue(func() (UUID, error) {
+		if g.skew != nil {
+			if err := g.guardSkew(); err != nil {
+				return Nil, err
+			}
+		}
+		uuid, overflowed, err := g.generate(t)
+		if err != nil {
+			return uuid, err
+		}
+		if finish != nil {
+			finish(&uuid)
+		}
+		if overflowed {
+			g.overflows.Add(1)
+			lead := time.UnixMilli(uuid.Timestamp()).Sub(t)
+			for _, h := range g.onOverflow {
+				h(lead)
+			}
+		}
+		return uuid, nil
+	})
+}
+
+// issue runs gen between the pre-hooks and rate limit on one side and the
+// counters and post-hooks on the other, the steps shared by every UUID
+// version the generator produces
+func (g *Generator) issue(gen func() (UUID, error)) (UUID, error) {
+	for _, h := range g.preHooks {
+		h()
+	}
+	if l := g.limiter.Load(); l != nil {
+		time.Sleep(l.reserve(time.Now()))
+	}
+	uuid, err := gen()
+	if err != nil {
+		g.errors.Add(1)
+	} else {
+		g.generated.Add(1)
+	}
+	for _, h := range g.postHooks {
+		h(uuid, err)
+	}
+	return uuid, err
+}
+
+// generate produces a UUIDv7 for t under the generator lock. overflowed
+// reports whether the counter overflowed and the timestamp was advanced past
+// the last one.
+func (g *Generator) generate(t time.Time) (uuid UUID, overflowed bool, err error) {
 
 	// Get Unix timestamp in milliseconds (48 bits)
 	timestamp := uint64(t.UnixMilli())
@@ -50,14 +150,46 @@ func (g *Generator) NewWithTime(t time.Time) (UUID, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	if g.granularityMs > 1 {
+		timestamp -= timestamp % uint64(g.granularityMs)
+	}
+	if g.ditherMs > 0 {
+		if timestamp, err = g.dither(timestamp); err != nil {
+			return uuid, false, err
+		}
+	}
+
+	if g.shared != nil {
+		if err := lockFile(g.shared.f); err != nil {
+			return uuid, false, err
+		}
+		defer unlockFile(g.shared.f)
+
+		if g.lastTimestamp, g.clockSeq, err = g.shared.load(); err != nil {
+			return uuid, false, err
+		}
+	}
+
 	// Handle monotonicity: if timestamp is same or earlier, increment counter
 	if timestamp <= g.lastTimestamp {
-		g.clockSeq++
+		smearing := g.smearing(timestamp)
+		// Reuse the last timestamp so the embedded time never moves backwards
+		timestamp = g.lastTimestamp
+		if smearing && g.clockSeq == 0xFFF && g.smearSeq < smearSeqMax {
+			g.smearSeq++
+		} else {
+			g.clockSeq++
+		}
 		// If counter overflows (> 12 bits), we need to wait or use last timestamp + 1
 		if g.clockSeq > 0xFFF {
 			g.clockSeq = 0
+			g.smearSeq = 0
 			timestamp = g.lastTimestamp + 1
+			if g.overflowSleep {
+				g.waitForMilli(timestamp)
+			}
 			g.lastTimestamp = timestamp
+			overflowed = true
 		}
 	} else {
 		/*
@@ -66,13 +198,20 @@ func (g *Generator) NewWithTime(t time.Time) (UUID, error) {
 		 */
 		// New millisecond, generate new random clock sequence
 		var randBytes [2]byte
-		if _, err := io.ReadFull(g.randReader, randBytes[:]); err != nil {
-			return uuid, err
+		if err := g.readRandom(randBytes[:]); err != nil {
+			return uuid, false, err
 		}
 		g.clockSeq = binary.BigEndian.Uint16(randBytes[:]) & 0xFFF // 12 bits
+		g.smearSeq = 0
 		g.lastTimestamp = timestamp
 	}
 
+	if g.shared != nil {
+		if err := g.shared.store(g.lastTimestamp, g.clockSeq); err != nil {
+			return uuid, false, err
+		}
+	}
+
 	// Encode timestamp (48 bits) - bytes 0-5
 	binary.BigEndian.PutUint64(uuid[0:8], timestamp<<16)
 
@@ -82,14 +221,24 @@ func (g *Generator) NewWithTime(t time.Time) (UUID, error) {
 	uuid[7] = byte(g.clockSeq)               // clock_seq_lo (8 bits)
 
 	// Generate random data for bytes 8-15 (64 bits)
-	if _, err := io.ReadFull(g.randReader, uuid[8:]); err != nil {
-		return uuid, err
+	if err := g.readRandom(uuid[8:]); err != nil {
+		return uuid, false, err
+	}
+
+	if g.mixer != nil {
+		g.mixer.mix(uuid[8:], timestamp, g.clockSeq)
+	}
+
+	// With WithSmearWindow, an exhausted counter continues in the top of rand_b
+	if g.smearMs > 0 && g.shared == nil && g.clockSeq == 0xFFF {
+		uuid[8] = byte(g.smearSeq >> 8)
+		uuid[9] = byte(g.smearSeq)
 	}
 
 	// Set variant to RFC 4122 (10xx xxxx)
 	uuid[8] = (uuid[8] & 0x3F) | 0x80
 
-	return uuid, nil
+	return uuid, overflowed, nil
 }
 
 // Must is a helper that wraps a call to a function returning (UUID, error)
@@ -104,18 +253,65 @@ func Must(uuid UUID, err error) UUID {
 	return uuid
 }
 
-// defaultGenerator is the package-level generator used by the New* functions
-var defaultGenerator = NewGenerator()
+// defaultGenerator holds the package-level generator used by the New* functions
+var defaultGenerator = newDefaultGenerator()
+
+// newDefaultGenerator returns the initial value of defaultGenerator
+func newDefaultGenerator() *atomic.Pointer[Generator] {
+	var p atomic.Pointer[Generator]
+	p.Store(NewGenerator())
+	return &p
+}
+
+// Default returns the generator used by the package-level New* functions
+func Default() *Generator {
+	return defaultGenerator.Load()
+}
+
+// SetDefault replaces the generator used by the package-level New* functions,
+// so applications can configure one Generator (options, hooks, entropy) at
+// startup without threading it through every package. The swap is atomic and
+// safe to perform while other goroutines generate IDs. Passing nil restores a
+// new generator with default settings.
+//
+// Switching generators does not carry over monotonic state: IDs from the new
+// generator are only ordered after earlier ones if the clock has advanced.
+func SetDefault(gen *Generator) {
+	if gen == nil {
+		gen = NewGenerator()
+	}
+	defaultGenerator.Store(gen)
+}
 
 // New generates a new UUIDv7 using the default generator.
 // This is a convenience function that uses the package-level generator.
 func New() (UUID, error) {
-	return defaultGenerator.New()
+	return Default().New()
 }
 
 // NewV7 is an alias for New() for explicit version specification
 func NewV7() (UUID, error) {
-	return defaultGenerator.New()
+	return Default().New()
+}
+
+// MustNew is like New but panics if the UUID cannot be generated. It is meant
+// for initializers and tests where the error path is unreachable in practice.
+func MustNew() UUID {
+	return Must(Default().New())
+}
+
+// MustNewV7 is an alias for MustNew() for explicit version specification
+func MustNewV7() UUID {
+	return Must(Default().New())
+}
+
+// NewPtr generates a new UUIDv7 using the default generator and returns a pointer to it
+func NewPtr() (*UUID, error) {
+	uuid, err := Default().New()
+	if err != nil {
+		return nil, err
+	}
+	return &uuid, nil
 }
 
 // Timestamp extracts the Unix timestamp (in milliseconds) from a UUIDv7
@@ -133,6 +329,31 @@ func (u UUID) Timestamp() int64 {
 	return int64(timestamp)
 }
 
+// TimestampMilli returns the embedded Unix time of a UUIDv7 in milliseconds.
+// It is the same as Timestamp.
+func (u UUID) TimestampMilli() int64 {
+	return u.Timestamp()
+}
+
+// TimestampMicro returns the embedded Unix time of a UUIDv7 in microseconds.
+// UUIDv7 timestamps have millisecond precision, so the result is always a
+// multiple of 1000.
+func (u UUID) TimestampMicro() int64 {
+	return u.Timestamp() * 1000
+}
+
+// TimestampNano returns the embedded Unix time of a UUIDv7 in nanoseconds,
+// a multiple of 1e6
+func (u UUID) TimestampNano() int64 {
+	return u.Timestamp() * 1000000
+}
+
+// TimeIn returns the timestamp of a UUIDv7 in the given location. It panics
+// if loc is nil, like time.Time.In.
+func (u UUID) TimeIn(loc *time.Location) time.Time {
+	return u.Time().In(loc)
+}
+
 // Time returns the timestamp as a time.Time for UUIDv7
 func (u UUID) Time() time.Time {
 	if u.Version() != VersionTimeSorted {
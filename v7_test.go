@@ -171,6 +171,45 @@ func TestUUID_Time(t *testing.T) {
 	}
 }
 
+func TestUUID_TimestampPrecisions(t *testing.T) {
+	uuid := MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	const ms int64 = 0x017f22e279b0
+
+	if got := uuid.TimestampMilli(); got != ms {
+		t.Errorf("TimestampMilli() = %d, want %d", got, ms)
+	}
+	if got := uuid.TimestampMicro(); got != ms*1000 {
+		t.Errorf("TimestampMicro() = %d, want %d", got, ms*1000)
+	}
+	if got := uuid.TimestampNano(); got != ms*1000000 {
+		t.Errorf("TimestampNano() = %d, want %d", got, ms*1000000)
+	}
+	if got := uuid.TimestampNano(); got != uuid.Time().UnixNano() {
+		t.Errorf("TimestampNano() = %d, want Time().UnixNano() %d", got, uuid.Time().UnixNano())
+	}
+
+	v4 := MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	if v4.TimestampMicro() != 0 || v4.TimestampNano() != 0 {
+		t.Error("timestamps of non-v7 UUID should be 0")
+	}
+}
+
+func TestUUID_TimeIn(t *testing.T) {
+	uuid := MustParse("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	loc := time.FixedZone("UTC+9", 9*60*60)
+
+	got := uuid.TimeIn(loc)
+	if got.Location() != loc {
+		t.Errorf("TimeIn() location = %v, want %v", got.Location(), loc)
+	}
+	if !got.Equal(uuid.Time()) {
+		t.Errorf("TimeIn() = %v, want instant %v", got, uuid.Time())
+	}
+	if got.Hour() != (uuid.Time().UTC().Hour()+9)%24 {
+		t.Errorf("TimeIn() hour = %d, want UTC hour + 9", got.Hour())
+	}
+}
+
 func TestMust(t *testing.T) {
 	// Valid UUID should not panic
 	gen := NewGenerator()
@@ -191,6 +230,23 @@ func TestMust(t *testing.T) {
 	Must(brokenGen.New())
 }
 
+func TestMustNew(t *testing.T) {
+	for name, fn := range map[string]func() UUID{"MustNew": MustNew, "MustNewV7": MustNewV7} {
+		if u := fn(); u.Version() != VersionTimeSorted {
+			t.Errorf("%s() version = %v, want v7", name, u.Version())
+		}
+	}
+
+	defer SetDefault(nil)
+	SetDefault(NewGeneratorWithReader(&brokenReader{}))
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustNew() did not panic on error")
+		}
+	}()
+	MustNew()
+}
+
 // brokenReader is a reader that always returns an error
 type brokenReader struct{}
 
@@ -299,3 +355,60 @@ func TestSortability(t *testing.T) {
 		}
 	}
 }
+
+func TestNewPtr(t *testing.T) {
+	p, err := NewPtr()
+	if err != nil {
+		t.Fatalf("NewPtr() error = %v", err)
+	}
+	if p == nil || p.IsNil() {
+		t.Fatal("NewPtr() returned nil pointer or nil UUID")
+	}
+	if p.Version() != VersionTimeSorted {
+		t.Errorf("NewPtr() version = %v, want %v", p.Version(), VersionTimeSorted)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	original := Default()
+	defer SetDefault(original)
+
+	calls := 0
+	gen := NewGenerator(WithHook(func(UUID, error) { calls++ }))
+	SetDefault(gen)
+	if Default() != gen {
+		t.Fatal("Default() did not return the generator passed to SetDefault")
+	}
+
+	Must(New())
+	Must(NewV7())
+	if _, err := NewPtr(); err != nil {
+		t.Fatalf("NewPtr() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("custom default generator used %d times, want 3", calls)
+	}
+
+	SetDefault(nil)
+	if Default() == nil || Default() == gen {
+		t.Error("SetDefault(nil) did not install a fresh generator")
+	}
+}
+
+func TestGenerator_MonotonicAcrossOverflowAndRollback(t *testing.T) {
+	gen := NewGenerator()
+	now := time.Now()
+
+	var prev UUID
+	for i := 0; i < 10000; i++ {
+		ts := now
+		if i%7 == 0 {
+			ts = now.Add(-time.Second) // simulated clock rollback
+		}
+		uuid := Must(gen.NewWithTime(ts))
+		if i > 0 && uuid.Compare(prev) <= 0 {
+			t.Fatalf("UUIDs not monotonically increasing at index %d: %v <= %v", i, uuid, prev)
+		}
+		prev = uuid
+	}
+}
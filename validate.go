@@ -0,0 +1,32 @@
+package guuid
+
+import (
+	"time"
+)
+
+// ValidateV7 checks that u is an RFC 9562 UUIDv7 whose embedded timestamp lies
+// within maxSkew of the current time in either direction. It is intended for
+// rejecting IDs supplied by untrusted clients, whose timestamps can be forged.
+//
+// It returns ErrInvalidVersion, ErrInvalidVariant or ErrTimestampOutOfRange.
+func ValidateV7(u UUID, maxSkew time.Duration) error {
+	return validateV7At(u, time.Now(), maxSkew)
+}
+
+// validateV7At is ValidateV7 with an explicit reference time
+func validateV7At(u UUID, now time.Time, maxSkew time.Duration) error {
+	if u.Version() != VersionTimeSorted {
+		return ErrInvalidVersion
+	}
+	if u.Variant() != VariantRFC4122 {
+		return ErrInvalidVariant
+	}
+	skew := now.Sub(u.Time())
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew {
+		return ErrTimestampOutOfRange
+	}
+	return nil
+}
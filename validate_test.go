@@ -0,0 +1,33 @@
+package guuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateV7(t *testing.T) {
+	gen := NewGenerator()
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		uuid UUID
+		want error
+	}{
+		{"current", Must(gen.NewWithTime(now)), nil},
+		{"slightly past", Must(gen.NewWithTime(now.Add(-30 * time.Second))), nil},
+		{"far future", Must(gen.NewWithTime(now.Add(48 * time.Hour))), ErrTimestampOutOfRange},
+		{"far past", Must(gen.NewWithTime(now.Add(-48 * time.Hour))), ErrTimestampOutOfRange},
+		{"not v7", MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479"), ErrInvalidVersion},
+		{"bad variant", UUID{6: 0x70, 8: 0xc0}, ErrInvalidVariant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateV7(tt.uuid, time.Hour); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateV7() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package guuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewWithNodeTag(t *testing.T) {
+	gen := NewGenerator()
+	var prev UUID
+	for _, tag := range []string{"", "d", "dead", "BEEF", "c0ffe", "0123456789ab"} {
+		uuid, err := gen.NewWithNodeTag(tag)
+		if err != nil {
+			t.Fatalf("NewWithNodeTag(%q) error = %v", tag, err)
+		}
+		if !strings.HasPrefix(uuid.String()[24:], strings.ToLower(tag)) {
+			t.Errorf("NewWithNodeTag(%q) = %v, final group lacks tag", tag, uuid)
+		}
+		if uuid.Version() != VersionTimeSorted || uuid.Variant() != VariantRFC4122 {
+			t.Errorf("NewWithNodeTag(%q) = %v, wrong version/variant", tag, uuid)
+		}
+		if uuid.Compare(prev) <= 0 {
+			t.Errorf("NewWithNodeTag(%q) = %v, not after %v", tag, uuid, prev)
+		}
+		prev = uuid
+	}
+}
+
+func TestNewWithNodeTag_Hook(t *testing.T) {
+	var hooked UUID
+	gen := NewGenerator(WithHook(func(uuid UUID, _ error) { hooked = uuid }))
+	uuid, err := gen.NewWithNodeTag("cafe")
+	if err != nil {
+		t.Fatalf("NewWithNodeTag() error = %v", err)
+	}
+	if hooked != uuid {
+		t.Errorf("hook saw %v, NewWithNodeTag returned %v", hooked, uuid)
+	}
+}
+
+func TestNewWithNodeTag_Invalid(t *testing.T) {
+	for _, tag := range []string{"xyz", "dead-beef", "0123456789abc"} {
+		if _, err := NewWithNodeTag(tag); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("NewWithNodeTag(%q) error = %v, want %v", tag, err, ErrInvalidFormat)
+		}
+	}
+}